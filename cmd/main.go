@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/your-org/go-backend-starter/internal/application/service"
@@ -36,25 +40,55 @@ func main() {
 	userRepo := infraRepo.NewUserRepository()
 	roleRepo := infraRepo.NewRoleRepository()
 	permissionRepo := infraRepo.NewPermissionRepository()
+	roleRequestRepo := infraRepo.NewRoleRequestRepository()
 	dormitoryRepo := infraRepo.NewDormitoryRepository()
 	auditLogRepo := infraRepo.NewAuditLogRepository()
+	refreshTokenRepo := infraRepo.NewRefreshTokenRepository()
+	sessionRepo := infraRepo.NewSessionRepository()
+	userTokenRepo := infraRepo.NewUserTokenRepository()
+	mfaRepo := infraRepo.NewMFARepository()
+	apiKeyRepo := infraRepo.NewAPIKeyRepository()
+	linkedIdentityRepo := infraRepo.NewLinkedIdentityRepository()
+	oidcStateRepo := infraRepo.NewOIDCStateRepository()
 	provinceRepo := infraRepo.NewProvinceRepository()
 	regencyRepo := infraRepo.NewRegencyRepository()
 	districtRepo := infraRepo.NewDistrictRepository()
 	villageRepo := infraRepo.NewVillageRepository()
+	txManager := infraRepo.NewTransactionManager()
 
 	// Initialize services
-	tokenService := infraService.NewJWTService()
+	tokenService, err := infraService.NewJWTService()
+	if err != nil {
+		log.Fatalf("Failed to initialize token service: %v", err)
+	}
+	tokenDenylist := infraService.NewTokenDenylistFromEnv()
+	mailer := infraService.NewMailerFromEnv()
+	otpService := infraService.NewTOTPService(mfaIssuer())
 	auditLogger := service.NewAuditLogger(auditLogRepo)
+	loginThrottle := service.NewLoginThrottle(infraService.NewLoginAttemptStoreFromEnv(), loadLoginThrottleOptions())
+	permissionRegistry := service.NewPermissionRegistry()
+	cursorCodec := service.NewCursorCodec(cursorSecret())
+	identityProviders, err := infraService.NewIdentityProvidersFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize identity providers: %v", err)
+	}
+	policyEngine, err := loadPolicyEngine()
+	if err != nil {
+		log.Fatalf("Failed to initialize policy engine: %v", err)
+	}
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenService)
-	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, auditLogger)
-	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger)
-	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger)
-	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo)
-	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo)
-	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo)
+	authUseCase := usecase.NewAuthUseCase(userRepo, refreshTokenRepo, sessionRepo, userTokenRepo, mfaRepo, tokenService, tokenDenylist, otpService, mailer, auditLogger, loginThrottle, txManager, loadAuthOptions())
+	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, dormitoryRepo, auditLogger, cursorCodec, txManager)
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger, txManager)
+	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger, cursorCodec)
+	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo, cursorCodec)
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo, cursorCodec)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, userRepo, permissionRepo, auditLogger)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, refreshTokenRepo, auditLogger)
+	oidcUseCase := usecase.NewOIDCUseCase(identityProviders, linkedIdentityRepo, oidcStateRepo, userRepo, roleRepo, authUseCase, auditLogger)
+	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo, permissionRegistry, auditLogger, txManager)
+	roleRequestUseCase := usecase.NewRoleRequestUseCase(roleRequestRepo, userRepo, roleRepo, auditLogger, txManager)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
@@ -64,12 +98,41 @@ func main() {
 	locationHandler := handler.NewLocationHandler(locationUseCase)
 	permissionHandler := handler.NewPermissionHandler(permissionUseCase)
 	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUseCase)
+	oidcHandler := handler.NewOIDCHandler(oidcUseCase)
+	sessionHandler := handler.NewSessionHandler(sessionUseCase)
+	roleRequestHandler := handler.NewRoleRequestHandler(roleRequestUseCase)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, tokenDenylist, userRepo, apiKeyRepo)
+	rateLimiter := middleware.NewRateLimiter(infraService.NewMemoryRateLimitStore())
+	explainPolicies, _ := strconv.ParseBool(os.Getenv("POLICY_EXPLAIN"))
+	policyMiddleware := middleware.NewPolicyMiddleware(policyEngine, explainPolicies)
 
 	// Setup router (includes global CORS & audit context middleware inside SetupRouter)
-	r := router.SetupRouter(authHandler, userHandler, dormitoryHandler, roleHandler, locationHandler, permissionHandler, auditLogHandler, authMiddleware)
+	r := router.SetupRouter(authHandler, userHandler, dormitoryHandler, roleHandler, locationHandler, permissionHandler, auditLogHandler, apiKeyHandler, oidcHandler, sessionHandler, roleRequestHandler, authMiddleware, policyMiddleware, rateLimiter, permissionRegistry)
+
+	// Create permissions required by routes that are missing from the database
+	syncResult, err := permissionUseCase.SyncPermissions(context.Background())
+	if err != nil {
+		log.Printf("Failed to sync permissions: %v", err)
+	} else {
+		for _, name := range syncResult.Created {
+			log.Printf("Created permission %s", name)
+		}
+		for _, name := range syncResult.Orphaned {
+			log.Printf("Permission %s is not required by any route", name)
+		}
+	}
+
+	// Revoke role grants whose expiry has passed
+	service.NewSweeper("role-grants", roleGrantSweepInterval(), func(ctx context.Context) error {
+		revoked, err := userUseCase.RevokeExpiredRoles(ctx)
+		if revoked > 0 {
+			log.Printf("Revoked %d expired role grants", revoked)
+		}
+		return err
+	}).Start(context.Background())
 
 	// Get server port
 	port := os.Getenv("SERVER_PORT")
@@ -83,3 +146,86 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// loadAuthOptions reads authentication options from environment variables
+func loadAuthOptions() usecase.AuthOptions {
+	options := usecase.DefaultAuthOptions()
+
+	if v, err := strconv.ParseBool(os.Getenv("AUTH_REQUIRE_EMAIL_VERIFICATION")); err == nil {
+		options.RequireEmailVerification = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("PASSWORD_RESET_TOKEN_EXPIRY")); err == nil {
+		options.PasswordResetTokenTTL = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("EMAIL_VERIFICATION_TOKEN_EXPIRY")); err == nil {
+		options.EmailVerificationTokenTTL = v
+	}
+	if v := os.Getenv("APP_BASE_URL"); v != "" {
+		options.AppBaseURL = v
+	}
+
+	return options
+}
+
+// loadPolicyEngine creates the access policy engine with the policies from POLICY_FILE (YAML or JSON).
+// Without a policy file, authorization falls back to plain permission checks.
+func loadPolicyEngine() (service.PolicyEngine, error) {
+	var policies []service.Policy
+	if path := os.Getenv("POLICY_FILE"); path != "" {
+		loaded, err := service.LoadPolicyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		policies = loaded
+	}
+
+	return service.NewPolicyEngine(policies)
+}
+
+// loadLoginThrottleOptions reads brute-force protection options from environment variables
+func loadLoginThrottleOptions() service.LoginThrottleOptions {
+	options := service.DefaultLoginThrottleOptions()
+
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILED_ATTEMPTS")); err == nil {
+		options.MaxAccountFailures = v
+	}
+	if v, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT_DURATION")); err == nil {
+		options.AccountLockout = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP")); err == nil {
+		options.MaxIPFailures = v
+	}
+
+	return options
+}
+
+// roleGrantSweepInterval returns how often expired role grants are revoked
+func roleGrantSweepInterval() time.Duration {
+	if v, err := time.ParseDuration(os.Getenv("ROLE_GRANT_SWEEP_INTERVAL")); err == nil && v > 0 {
+		return v
+	}
+	return time.Minute
+}
+
+// cursorSecret returns the key signing pagination cursors. Without CURSOR_SECRET a random
+// key is generated, so cursors stop working after a restart and are not shared between instances.
+func cursorSecret() []byte {
+	if secret := os.Getenv("CURSOR_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("Failed to generate cursor secret: %v", err)
+	}
+	log.Println("CURSOR_SECRET is not set, using a random key; pagination cursors will not survive a restart")
+	return secret
+}
+
+// mfaIssuer returns the issuer name shown in authenticator apps
+func mfaIssuer() string {
+	if issuer := os.Getenv("MFA_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return "Go Backend Starter"
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
+)
+
+// purge permanently removes users and dormitories that were soft-deleted
+// more than -days days ago, together with the rows that reference them
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using environment variables")
+	}
+
+	// Parse command line flags
+	days := flag.Int("days", 30, "Purge records deleted more than this many days ago")
+	flag.Parse()
+
+	if *days < 0 {
+		log.Fatal("-days must not be negative")
+	}
+
+	// Connect to database
+	if err := database.Connect(); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	before := time.Now().AddDate(0, 0, -*days)
+
+	users, err := infraRepo.NewUserRepository().PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Fatalf("Failed to purge users: %v", err)
+	}
+	dormitories, err := infraRepo.NewDormitoryRepository().PurgeDeleted(ctx, before)
+	if err != nil {
+		log.Fatalf("Failed to purge dormitories: %v", err)
+	}
+
+	fmt.Printf("\n✅ Purged %d users and %d dormitories deleted before %s\n", users, dormitories, before.Format(time.RFC3339))
+}
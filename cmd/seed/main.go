@@ -51,6 +51,18 @@ func main() {
 		{ID: uuid.New(), Name: "role:delete", Slug: "role-delete", Resource: "role", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 		// Audit permissions
 		{ID: uuid.New(), Name: "audit:read", Slug: "audit-read", Resource: "audit_log", Action: "read", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		// Wildcard permissions (also cover permissions added later)
+		{ID: uuid.New(), Name: "user:*", Slug: "user-all", Resource: "user", Action: "*", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "dorm:*", Slug: "dorm-all", Resource: "dorm", Action: "*", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "role:*", Slug: "role-all", Resource: "role", Action: "*", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "*", Slug: "all", Resource: "*", Action: "*", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		// Access to every dormitory without an assignment (covered by dorm:* and *)
+		{ID: uuid.New(), Name: entity.PermissionDormAccessAll, Slug: "dorm-access-all", Resource: "dorm", Action: "access_all", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		// Permission management (covered by *)
+		{ID: uuid.New(), Name: "permission:create", Slug: "permission-create", Resource: "permission", Action: "create", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "permission:update", Slug: "permission-update", Resource: "permission", Action: "update", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "permission:delete", Slug: "permission-delete", Resource: "permission", Action: "delete", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.New(), Name: "permission:*", Slug: "permission-all", Resource: "permission", Action: "*", CreatedAt: time.Now(), UpdatedAt: time.Now()},
 	}
 
 	log.Println("Creating permissions...")
@@ -70,6 +82,22 @@ func main() {
 		}
 	}
 
+	// Look permissions up by name so reordering the list above cannot grant the wrong ones
+	permissionsByName := make(map[string]*entity.Permission, len(permissions))
+	for _, perm := range permissions {
+		permissionsByName[perm.Name] = perm
+	}
+	dormRead := permissionsByName["dorm:read"]
+
+	// Wildcard grants for the protected roles
+	adminPermissions := []entity.Permission{
+		*permissionsByName["user:*"], *permissionsByName["dorm:*"], *permissionsByName["role:*"],
+		*permissionsByName["audit:read"],
+	}
+	superAdminPermissions := []entity.Permission{
+		*permissionsByName["*"],
+	}
+
 	// Check if roles already exist (from migration)
 	var existingUserRole, existingAdminRole, existingSuperAdminRole *entity.Role
 	userRolePtr, _ := roleRepo.GetBySlug(ctx, "user")
@@ -104,7 +132,7 @@ func main() {
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 			Permissions: []entity.Permission{
-				*permissions[4], // dorm:read
+				*dormRead,
 			},
 		}
 		if err := roleRepo.Create(ctx, userRole); err != nil {
@@ -115,7 +143,7 @@ func main() {
 	} else {
 		// Assign permissions to existing user role
 		if existingUserRole != nil {
-			roleRepo.AssignPermission(ctx, existingUserRole.ID, permissions[4].ID) // dorm:read
+			roleRepo.AssignPermission(ctx, existingUserRole.ID, dormRead.ID)
 			log.Println("Updated user role permissions")
 		}
 	}
@@ -128,13 +156,10 @@ func main() {
 			Slug:        "admin",
 			IsActive:    true,
 			IsProtected: true,
+			RequireMFA:  true,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
-			Permissions: []entity.Permission{
-				*permissions[0], *permissions[1], *permissions[2], *permissions[3], // user:*
-				*permissions[4], *permissions[5], *permissions[6], *permissions[7], // dorm:*
-				*permissions[8], *permissions[9], *permissions[10], *permissions[11], // role:*
-			},
+			Permissions: adminPermissions,
 		}
 		if err := roleRepo.Create(ctx, adminRole); err != nil {
 			log.Printf("Failed to create admin role: %v", err)
@@ -142,9 +167,9 @@ func main() {
 			log.Println("Created admin role")
 		}
 	} else {
-		// Assign all permissions to existing admin role
+		// Assign wildcard permissions to existing admin role
 		if existingAdminRole != nil {
-			for _, perm := range permissions {
+			for _, perm := range adminPermissions {
 				roleRepo.AssignPermission(ctx, existingAdminRole.ID, perm.ID)
 			}
 			log.Println("Updated admin role permissions")
@@ -159,13 +184,10 @@ func main() {
 			Slug:        "super_admin",
 			IsActive:    true,
 			IsProtected: true,
+			RequireMFA:  true,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
-			Permissions: []entity.Permission{
-				*permissions[0], *permissions[1], *permissions[2], *permissions[3], // user:*
-				*permissions[4], *permissions[5], *permissions[6], *permissions[7], // dorm:*
-				*permissions[8], *permissions[9], *permissions[10], *permissions[11], // role:*
-			},
+			Permissions: superAdminPermissions,
 		}
 		if err := roleRepo.Create(ctx, superAdminRole); err != nil {
 			log.Printf("Failed to create super admin role: %v", err)
@@ -173,9 +195,9 @@ func main() {
 			log.Println("Created super admin role")
 		}
 	} else {
-		// Assign all permissions to existing super admin role
+		// Assign the global wildcard to existing super admin role
 		if existingSuperAdminRole != nil {
-			for _, perm := range permissions {
+			for _, perm := range superAdminPermissions {
 				roleRepo.AssignPermission(ctx, existingSuperAdminRole.ID, perm.ID)
 			}
 			log.Println("Updated super admin role permissions")
@@ -195,19 +217,33 @@ func main() {
 		superAdminRoleEntity, _ = roleRepo.GetBySlug(ctx, "super_admin")
 	}
 
+	// Super admin inherits the permissions of admin
+	if adminRoleEntity != nil && superAdminRoleEntity != nil && superAdminRoleEntity.ParentID == nil {
+		superAdminRoleEntity.ParentID = &adminRoleEntity.ID
+		if err := roleRepo.Update(ctx, superAdminRoleEntity); err != nil {
+			log.Printf("Failed to set parent of super admin role: %v", err)
+		} else {
+			log.Println("Super admin role now inherits from admin role")
+		}
+	}
+
+	// Seeded accounts are considered verified
+	verifiedAt := time.Now()
+
 	// Create admin user (only if doesn't exist)
 	existingAdminUser, _ := userRepo.GetByEmail(ctx, "admin@example.com")
 	if existingAdminUser == nil && adminRoleEntity != nil {
 		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
 		adminUser := &entity.User{
-			ID:        uuid.New(),
-			Email:     "admin@example.com",
-			Password:  string(hashedPassword),
-			Name:      "Admin User",
-			IsActive:  true,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-			Roles:     []entity.Role{*adminRoleEntity},
+			ID:              uuid.New(),
+			Email:           "admin@example.com",
+			Password:        string(hashedPassword),
+			Name:            "Admin User",
+			IsActive:        true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			EmailVerifiedAt: &verifiedAt,
+			Roles:           []entity.Role{*adminRoleEntity},
 		}
 
 		log.Println("Creating admin user...")
@@ -225,14 +261,15 @@ func main() {
 	if existingSuperAdminUser == nil && superAdminRoleEntity != nil {
 		hashedPasswordSuper, _ := bcrypt.GenerateFromPassword([]byte("superadmin123"), bcrypt.DefaultCost)
 		superAdminUser := &entity.User{
-			ID:        uuid.New(),
-			Email:     "superadmin@example.com",
-			Password:  string(hashedPasswordSuper),
-			Name:      "Super Admin User",
-			IsActive:  true,
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-			Roles:     []entity.Role{*superAdminRoleEntity},
+			ID:              uuid.New(),
+			Email:           "superadmin@example.com",
+			Password:        string(hashedPasswordSuper),
+			Name:            "Super Admin User",
+			IsActive:        true,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			EmailVerifiedAt: &verifiedAt,
+			Roles:           []entity.Role{*superAdminRoleEntity},
 		}
 
 		log.Println("Creating super admin user...")
@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CreateAPIKeyRequest represents the request to create a personal access token
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// UpdateAPIKeyRequest represents the request to update a personal access token
+type UpdateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+// APIKeyResponse represents API key data in responses
+type APIKeyResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Prefix      string   `json:"prefix"`
+	Permissions []string `json:"permissions"`
+	ExpiresAt   string   `json:"expires_at,omitempty"`
+	LastUsedAt  string   `json:"last_used_at,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents a newly created API key.
+// Key is the plaintext token and is only returned once.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -26,3 +26,9 @@ type ListAuditLogsResponse struct {
 	PageSize   int                `json:"page_size"`
 	TotalPages int                `json:"total_pages"`
 }
+
+// CursorAuditLogsResponse represents a cursor-paginated audit log list response
+type CursorAuditLogsResponse struct {
+	Logs []AuditLogResponse `json:"logs"`
+	CursorPagination
+}
@@ -18,12 +18,85 @@ type RefreshTokenRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-// AuthResponse represents the authentication response
+// LogoutRequest represents the request for logging out a session
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RevokeTokenRequest represents the request for revoking an access token
+type RevokeTokenRequest struct {
+	TokenID string `json:"token_id" binding:"required"`
+}
+
+// ForgotPasswordRequest represents the request for a password reset link
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest represents the request for resetting a password
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+// ResendVerificationRequest represents the request for a new email verification link
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// VerifyEmailRequest represents the request for verifying an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// MFATokenRequest represents a request authorized by the mfa_token from login
+type MFATokenRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+}
+
+// MFAVerifyRequest represents the second login step.
+// Code is either a TOTP code or a recovery code.
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	Code     string `json:"code" binding:"required"`
+}
+
+// MFACodeRequest represents a request confirmed with a TOTP or recovery code
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAEnrollResponse represents the data needed to add the account to an authenticator app
+type MFAEnrollResponse struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is an otpauth:// URI, rendered as a QR code by clients
+	ProvisioningURI string `json:"provisioning_uri"`
+	// MFAToken is returned during forced enrollment at login so the flow can continue
+	MFAToken string `json:"mfa_token,omitempty"`
+}
+
+// MFARecoveryCodesResponse represents newly generated recovery codes, shown only once
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// AuthResponse represents the authentication response.
+// When MFARequired is set, login is not complete: only MFAToken is returned
+// and must be exchanged together with a code at /api/auth/mfa/verify.
 type AuthResponse struct {
-	AccessToken  string   `json:"access_token"`
-	RefreshToken string   `json:"refresh_token"`
-	ExpiresAt    string   `json:"expires_at"`
-	User         UserDTO  `json:"user"`
+	AccessToken  string   `json:"access_token,omitempty"`
+	RefreshToken string   `json:"refresh_token,omitempty"`
+	ExpiresAt    string   `json:"expires_at,omitempty"`
+	User         *UserDTO `json:"user,omitempty"`
+
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+	// MFAEnrollmentRequired is set when a role requires MFA but the user has not enrolled yet
+	MFAEnrollmentRequired bool `json:"mfa_enrollment_required,omitempty"`
+	// RecoveryCodes is only set when a login completed a forced enrollment
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	// EmailVerificationRequired is set when registration issued no tokens until the email is verified
+	EmailVerificationRequired bool `json:"email_verification_required,omitempty"`
 }
 
 // UserDTO represents user data in responses
@@ -21,6 +21,14 @@ type DormitoryResponse struct {
 	IsActive    bool   `json:"is_active"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+}
+
+// ListDormitoriesFilter represents search, filtering and sorting options for listing dormitories
+type ListDormitoriesFilter struct {
+	Search   string
+	IsActive *bool
+	Sort     string // Field name, prefixed with "-" for descending order
 }
 
 // ListDormitoriesResponse represents paginated dormitory list response
@@ -31,3 +39,31 @@ type ListDormitoriesResponse struct {
 	PageSize    int                 `json:"page_size"`
 	TotalPages  int                 `json:"total_pages"`
 }
+
+// AddDormitoryMemberRequest represents the request to add a user to a dormitory
+type AddDormitoryMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+// DormitoryMemberResponse represents a member of a dormitory in responses
+type DormitoryMemberResponse struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"is_active"`
+}
+
+// ListDormitoryMembersResponse represents paginated dormitory member list response
+type ListDormitoryMembersResponse struct {
+	Members    []DormitoryMemberResponse `json:"members"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	PageSize   int                       `json:"page_size"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+// CursorDormitoriesResponse represents a cursor-paginated dormitory list response
+type CursorDormitoriesResponse struct {
+	Dormitories []DormitoryResponse `json:"dormitories"`
+	CursorPagination
+}
@@ -71,3 +71,27 @@ type PaginatedVillageResponse struct {
 	PageSize   int               `json:"page_size"`
 	TotalPages int               `json:"total_pages"`
 }
+
+// CursorProvinceResponse represents a cursor-paginated province list response
+type CursorProvinceResponse struct {
+	Items []ProvinceResponse `json:"items"`
+	CursorPagination
+}
+
+// CursorRegencyResponse represents a cursor-paginated regency list response
+type CursorRegencyResponse struct {
+	Items []RegencyResponse `json:"items"`
+	CursorPagination
+}
+
+// CursorDistrictResponse represents a cursor-paginated district list response
+type CursorDistrictResponse struct {
+	Items []DistrictResponse `json:"items"`
+	CursorPagination
+}
+
+// CursorVillageResponse represents a cursor-paginated village list response
+type CursorVillageResponse struct {
+	Items []VillageResponse `json:"items"`
+	CursorPagination
+}
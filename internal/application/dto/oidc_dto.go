@@ -0,0 +1,19 @@
+package dto
+
+// OIDCProvidersResponse lists the configured external identity providers
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// OIDCAuthorizeResponse contains the URL the client redirects the user to for external login
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+	ExpiresAt        string `json:"expires_at"`
+}
+
+// OIDCCallbackRequest represents the code and state returned by the identity provider
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+// CursorQuery represents the cursor pagination parameters of a list request
+type CursorQuery struct {
+	Cursor    string // Empty for the first page
+	Limit     int
+	WithCount bool // Include the total number of matching rows
+}
+
+// CursorPagination describes a page of a cursor-paginated list
+type CursorPagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"` // Empty on the last page
+	Total      *int64 `json:"total,omitempty"`       // Only when requested with count=true
+}
@@ -1,5 +1,18 @@
 package dto
 
+// CreatePermissionRequest represents the request to create a permission.
+// The slug is derived from the name when omitted.
+type CreatePermissionRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// UpdatePermissionRequest represents the request to update a permission
+type UpdatePermissionRequest struct {
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
 // PermissionResponse represents permission data in responses
 type PermissionResponse struct {
 	ID       string `json:"id"`
@@ -17,3 +30,12 @@ type ListPermissionsResponse struct {
 	PageSize    int                  `json:"page_size"`
 	TotalPages  int                  `json:"total_pages"`
 }
+
+// PermissionCatalogEntry describes which endpoints a permission unlocks.
+// Orphaned permissions are stored but neither required by a route nor checked in code.
+type PermissionCatalogEntry struct {
+	Name      string   `json:"name"`
+	Slug      string   `json:"slug"`
+	Endpoints []string `json:"endpoints"`
+	Orphaned  bool     `json:"orphaned"`
+}
@@ -1,31 +1,40 @@
 package dto
 
+import "time"
+
 // CreateRoleRequest represents the request to create a role
 type CreateRoleRequest struct {
-	Name        string   `json:"name" binding:"required"`
-	Slug        string   `json:"slug" binding:"required"`
-	IsActive    bool     `json:"is_active"`
-	IsProtected bool     `json:"is_protected"`
+	Name          string   `json:"name" binding:"required"`
+	Slug          string   `json:"slug" binding:"required"`
+	IsActive      bool     `json:"is_active"`
+	IsProtected   bool     `json:"is_protected"`
+	RequireMFA    bool     `json:"require_mfa"`
+	ParentID      string   `json:"parent_id,omitempty"` // Role to inherit permissions from
 	PermissionIDs []string `json:"permission_ids,omitempty"`
 }
 
 // UpdateRoleRequest represents the request to update a role
 type UpdateRoleRequest struct {
-	Name     string `json:"name,omitempty"`
-	Slug     string `json:"slug,omitempty"`
-	IsActive *bool  `json:"is_active,omitempty"`
+	Name       string  `json:"name,omitempty"`
+	Slug       string  `json:"slug,omitempty"`
+	IsActive   *bool   `json:"is_active,omitempty"`
+	RequireMFA *bool   `json:"require_mfa,omitempty"`
+	ParentID   *string `json:"parent_id,omitempty"` // Empty string removes the parent role
 }
 
 // RoleResponse represents role data in responses
 type RoleResponse struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Slug        string   `json:"slug"`
-	IsActive    bool     `json:"is_active"`
-	IsProtected bool     `json:"is_protected"`
-	Permissions []string `json:"permissions,omitempty"`
-	CreatedAt   string   `json:"created_at"`
-	UpdatedAt   string   `json:"updated_at"`
+	ID                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	Slug                 string   `json:"slug"`
+	IsActive             bool     `json:"is_active"`
+	IsProtected          bool     `json:"is_protected"`
+	RequireMFA           bool     `json:"require_mfa"`
+	ParentID             string   `json:"parent_id,omitempty"`
+	Permissions          []string `json:"permissions,omitempty"`           // Assigned directly to the role
+	InheritedPermissions []string `json:"inherited_permissions,omitempty"` // Inherited from the parent role and its ancestors
+	CreatedAt            string   `json:"created_at"`
+	UpdatedAt            string   `json:"updated_at"`
 }
 
 // ListRolesResponse represents paginated role list response
@@ -47,7 +56,20 @@ type RemovePermissionRequest struct {
 	PermissionID string `json:"permission_id" binding:"required"`
 }
 
+// SyncRolePermissionsRequest represents the desired set of permissions assigned directly to a role
+type SyncRolePermissionsRequest struct {
+	PermissionIDs []string `json:"permission_ids" binding:"required"`
+}
+
+// SyncRolePermissionsResponse represents the role after syncing its permissions and the applied diff
+type SyncRolePermissionsResponse struct {
+	Role    RoleResponse `json:"role"`
+	Added   []string     `json:"added"`
+	Removed []string     `json:"removed"`
+}
+
 // AssignRoleToUserRequest represents the request to assign a role to a user
 type AssignRoleToUserRequest struct {
-	RoleID string `json:"role_id" binding:"required"`
+	RoleID    string     `json:"role_id" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Grant the role only until this time
 }
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// RequestRoleRequest represents a user's request to be granted a role
+type RequestRoleRequest struct {
+	RoleID    string     `json:"role_id" binding:"required"`
+	Reason    string     `json:"reason" binding:"required,max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Requested end of the grant
+}
+
+// ReviewRoleRequestRequest represents the approval or denial of a role request
+type ReviewRoleRequestRequest struct {
+	Note      string     `json:"note" binding:"max=500"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Overrides the requested end of the grant on approval
+}
+
+// RoleRequestResponse represents role request data in responses
+type RoleRequestResponse struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	UserEmail  string `json:"user_email,omitempty"`
+	RoleID     string `json:"role_id"`
+	RoleSlug   string `json:"role_slug,omitempty"`
+	Reason     string `json:"reason"`
+	Status     string `json:"status"`
+	ExpiresAt  string `json:"expires_at,omitempty"`
+	ReviewerID string `json:"reviewer_id,omitempty"`
+	ReviewNote string `json:"review_note,omitempty"`
+	ReviewedAt string `json:"reviewed_at,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// ListRoleRequestsResponse represents paginated role request list response
+type ListRoleRequestsResponse struct {
+	RoleRequests []RoleRequestResponse `json:"role_requests"`
+	Total        int64                 `json:"total"`
+	Page         int                   `json:"page"`
+	PageSize     int                   `json:"page_size"`
+	TotalPages   int                   `json:"total_pages"`
+}
@@ -35,6 +35,16 @@ type UserResponse struct {
 	Dormitories []UserDormitorySummary `json:"dormitories"`
 	CreatedAt   string                 `json:"created_at"`
 	UpdatedAt   string                 `json:"updated_at"`
+	DeletedAt   string                 `json:"deleted_at,omitempty"`
+}
+
+// ListUsersFilter represents search, filtering and sorting options for listing users
+type ListUsersFilter struct {
+	Search      string
+	Role        string
+	IsActive    *bool
+	DormitoryID *uuid.UUID
+	Sort        string // Field name, prefixed with "-" for descending order
 }
 
 // ListUsersResponse represents paginated user list response
@@ -51,3 +61,24 @@ type AssignRoleRequest struct {
 	UserID uuid.UUID `json:"user_id" binding:"required"`
 	RoleID uuid.UUID `json:"role_id" binding:"required"`
 }
+
+// AssignDormitoryRoleRequest represents the request to assign a role to a user within a dormitory
+type AssignDormitoryRoleRequest struct {
+	RoleID      string `json:"role_id" binding:"required"`
+	DormitoryID string `json:"dormitory_id" binding:"required"`
+}
+
+// UserDormitoryRoleResponse represents a role scoped to a dormitory in responses
+type UserDormitoryRoleResponse struct {
+	RoleID        string `json:"role_id"`
+	RoleName      string `json:"role_name"`
+	DormitoryID   string `json:"dormitory_id"`
+	DormitoryName string `json:"dormitory_name"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// CursorUsersResponse represents a cursor-paginated user list response
+type CursorUsersResponse struct {
+	Users []UserResponse `json:"users"`
+	CursorPagination
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+type testPosition struct {
+	ID int `json:"id"`
+}
+
+func TestCursorCodec_RoundTrip(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+
+	cursor, err := codec.Encode("villages", testPosition{ID: 42})
+	require.NoError(t, err)
+
+	var position testPosition
+	require.NoError(t, codec.Decode("villages", cursor, &position))
+	assert.Equal(t, 42, position.ID)
+}
+
+func TestCursorCodec_RejectsInvalidCursors(t *testing.T) {
+	codec := NewCursorCodec([]byte("secret"))
+	cursor, err := codec.Encode("villages", testPosition{ID: 42})
+	require.NoError(t, err)
+
+	payload, signature, _ := strings.Cut(cursor, ".")
+	forged, err := codec.Encode("villages", testPosition{ID: 7})
+	require.NoError(t, err)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	otherKey, err := NewCursorCodec([]byte("other")).Encode("villages", testPosition{ID: 42})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		scope  string
+		cursor string
+	}{
+		"garbage":         {scope: "villages", cursor: "not-a-cursor"},
+		"bad encoding":    {scope: "villages", cursor: "!!!." + signature},
+		"edited payload":  {scope: "villages", cursor: forgedPayload + "." + signature},
+		"missing payload": {scope: "villages", cursor: "." + signature},
+		"other listing":   {scope: "audit_logs", cursor: cursor},
+		"other secret":    {scope: "villages", cursor: otherKey},
+		"truncated":       {scope: "villages", cursor: payload + "." + signature[:len(signature)-2]},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var position testPosition
+			assert.Equal(t, domainErrors.ErrInvalidCursor, codec.Decode(tt.scope, tt.cursor, &position))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	domainService "github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// LoginThrottleOptions configures brute-force protection for login
+type LoginThrottleOptions struct {
+	// MaxAccountFailures is the number of failed logins after which the account is locked
+	MaxAccountFailures int
+	AccountLockout     time.Duration
+	// MaxIPFailures is the number of failed attempts from one client IP before it is throttled
+	MaxIPFailures int
+	// BaseBackoff is the delay after the first counted failure, doubled with each further failure
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// FailureWindow is how long a counter is kept after the last failure
+	FailureWindow time.Duration
+}
+
+// DefaultLoginThrottleOptions returns the default brute-force protection options
+func DefaultLoginThrottleOptions() LoginThrottleOptions {
+	return LoginThrottleOptions{
+		MaxAccountFailures: 5,
+		AccountLockout:     15 * time.Minute,
+		MaxIPFailures:      20,
+		BaseBackoff:        time.Second,
+		MaxBackoff:         15 * time.Minute,
+		FailureWindow:      15 * time.Minute,
+	}
+}
+
+// LoginThrottle defines brute-force protection for credential checks.
+// Failures are counted per account email and per client IP (taken from the
+// request context, see CtxKeyIPAddress). An empty email only uses the client IP.
+type LoginThrottle interface {
+	// Check returns a *RetryAfterError wrapping ErrAccountLocked or ErrTooManyAttempts
+	// while the account or client must wait
+	Check(ctx context.Context, email string) error
+	// RecordFailure counts a failed attempt and reports whether the account is now locked
+	RecordFailure(ctx context.Context, email string) (locked bool, err error)
+	// Reset clears the account counter after a successful login
+	Reset(ctx context.Context, email string) error
+}
+
+type loginThrottle struct {
+	store   domainService.LoginAttemptStore
+	options LoginThrottleOptions
+}
+
+// NewLoginThrottle creates a new LoginThrottle
+func NewLoginThrottle(store domainService.LoginAttemptStore, options LoginThrottleOptions) LoginThrottle {
+	return &loginThrottle{store: store, options: options}
+}
+
+func (t *loginThrottle) Check(ctx context.Context, email string) error {
+	now := time.Now()
+
+	if email != "" {
+		attempt, err := t.store.Get(ctx, accountKey(email))
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.IsLocked(now) {
+			lockErr := domainErrors.ErrTooManyAttempts
+			if attempt.Failures >= t.options.MaxAccountFailures {
+				lockErr = domainErrors.ErrAccountLocked
+			}
+			return &domainErrors.RetryAfterError{Err: lockErr, RetryAfter: attempt.LockedUntil.Sub(now)}
+		}
+	}
+
+	if ip := clientIP(ctx); ip != "" {
+		attempt, err := t.store.Get(ctx, ipKey(ip))
+		if err != nil {
+			return err
+		}
+		if attempt != nil && attempt.IsLocked(now) {
+			return &domainErrors.RetryAfterError{Err: domainErrors.ErrTooManyAttempts, RetryAfter: attempt.LockedUntil.Sub(now)}
+		}
+	}
+
+	return nil
+}
+
+func (t *loginThrottle) RecordFailure(ctx context.Context, email string) (bool, error) {
+	now := time.Now()
+	locked := false
+
+	if email != "" {
+		attempt, err := t.store.Increment(ctx, accountKey(email), t.options.FailureWindow)
+		if err != nil {
+			return false, err
+		}
+
+		// Back off exponentially, then lock the account
+		delay := t.backoff(attempt.Failures - 1)
+		if attempt.Failures >= t.options.MaxAccountFailures {
+			delay = t.options.AccountLockout
+			locked = true
+		}
+		if err := t.store.Lock(ctx, attempt.Key, now.Add(delay)); err != nil {
+			return false, err
+		}
+	}
+
+	if ip := clientIP(ctx); ip != "" {
+		attempt, err := t.store.Increment(ctx, ipKey(ip), t.options.FailureWindow)
+		if err != nil {
+			return false, err
+		}
+
+		// Shared addresses (NAT, proxies) get some headroom before backing off
+		if attempt.Failures >= t.options.MaxIPFailures {
+			delay := t.backoff(attempt.Failures - t.options.MaxIPFailures)
+			if err := t.store.Lock(ctx, attempt.Key, now.Add(delay)); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return locked, nil
+}
+
+func (t *loginThrottle) Reset(ctx context.Context, email string) error {
+	return t.store.Reset(ctx, accountKey(email))
+}
+
+// backoff returns BaseBackoff doubled n times, capped at MaxBackoff
+func (t *loginThrottle) backoff(n int) time.Duration {
+	delay := t.options.BaseBackoff
+	for i := 0; i < n && delay < t.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > t.options.MaxBackoff {
+		delay = t.options.MaxBackoff
+	}
+	return delay
+}
+
+func accountKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// clientIP returns the client IP injected by AuditContextMiddleware
+func clientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(CtxKeyIPAddress).(string)
+	return ip
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	infraService "github.com/your-org/go-backend-starter/internal/infrastructure/service"
+)
+
+func testThrottleOptions() LoginThrottleOptions {
+	return LoginThrottleOptions{
+		MaxAccountFailures: 3,
+		AccountLockout:     time.Hour,
+		MaxIPFailures:      5,
+		BaseBackoff:        time.Minute,
+		MaxBackoff:         10 * time.Minute,
+		FailureWindow:      time.Hour,
+	}
+}
+
+func TestLoginThrottle_AccountBackoffAndLockout(t *testing.T) {
+	throttle := NewLoginThrottle(infraService.NewMemoryLoginAttemptStore(), testThrottleOptions())
+	ctx := context.Background()
+
+	require.NoError(t, throttle.Check(ctx, "user@example.com"))
+
+	// First failures back off without locking the account
+	locked, err := throttle.RecordFailure(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	var retryErr *domainErrors.RetryAfterError
+	err = throttle.Check(ctx, "User@Example.com")
+	require.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, domainErrors.ErrTooManyAttempts, retryErr.Err)
+	assert.InDelta(t, time.Minute, retryErr.RetryAfter, float64(time.Second))
+
+	_, err = throttle.RecordFailure(ctx, "user@example.com")
+	require.NoError(t, err)
+	locked, err = throttle.RecordFailure(ctx, "user@example.com")
+	require.NoError(t, err)
+	assert.True(t, locked)
+
+	err = throttle.Check(ctx, "user@example.com")
+	require.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, domainErrors.ErrAccountLocked, retryErr.Err)
+	assert.InDelta(t, time.Hour, retryErr.RetryAfter, float64(time.Second))
+
+	// Other accounts are unaffected
+	assert.NoError(t, throttle.Check(ctx, "other@example.com"))
+
+	require.NoError(t, throttle.Reset(ctx, "user@example.com"))
+	assert.NoError(t, throttle.Check(ctx, "user@example.com"))
+}
+
+func TestLoginThrottle_ClientIP(t *testing.T) {
+	throttle := NewLoginThrottle(infraService.NewMemoryLoginAttemptStore(), testThrottleOptions())
+	ctx := context.WithValue(context.Background(), CtxKeyIPAddress, "203.0.113.7")
+
+	// Failures spread over many accounts still count against the client IP
+	for i := 0; i < 4; i++ {
+		_, err := throttle.RecordFailure(ctx, "")
+		require.NoError(t, err)
+		require.NoError(t, throttle.Check(ctx, ""))
+	}
+
+	_, err := throttle.RecordFailure(ctx, "")
+	require.NoError(t, err)
+
+	var retryErr *domainErrors.RetryAfterError
+	err = throttle.Check(ctx, "someone@example.com")
+	require.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, domainErrors.ErrTooManyAttempts, retryErr.Err)
+
+	// Another client is unaffected
+	otherCtx := context.WithValue(context.Background(), CtxKeyIPAddress, "198.51.100.1")
+	assert.NoError(t, throttle.Check(otherCtx, "someone@example.com"))
+}
+
+func TestLoginThrottle_BackoffIsCapped(t *testing.T) {
+	throttle := &loginThrottle{options: testThrottleOptions()}
+
+	assert.Equal(t, time.Minute, throttle.backoff(0))
+	assert.Equal(t, 4*time.Minute, throttle.backoff(2))
+	assert.Equal(t, 10*time.Minute, throttle.backoff(10))
+	assert.Equal(t, 10*time.Minute, throttle.backoff(1000))
+}
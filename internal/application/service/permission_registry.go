@@ -0,0 +1,113 @@
+package service
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// RoutePermission is an endpoint together with the permission it requires
+type RoutePermission struct {
+	Permission string `json:"permission"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+}
+
+// Endpoint returns the endpoint as "METHOD /path"
+func (r RoutePermission) Endpoint() string {
+	return r.Method + " " + r.Path
+}
+
+// PermissionRegistry is the single source of the permissions the application
+// checks. Routes are recorded when the router is set up, and permissions that
+// are checked in code instead of by a route (e.g. dorm:access_all) are declared.
+// The registry is used to create missing permissions at startup and to build
+// the permission catalog.
+type PermissionRegistry struct {
+	mu       sync.RWMutex
+	routes   []RoutePermission
+	declared map[string]bool
+}
+
+// NewPermissionRegistry creates an empty permission registry
+func NewPermissionRegistry() *PermissionRegistry {
+	return &PermissionRegistry{
+		declared: make(map[string]bool),
+	}
+}
+
+// RegisterRoute records that an endpoint requires a permission
+func (r *PermissionRegistry) RegisterRoute(permission, method, path string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, RoutePermission{Permission: permission, Method: method, Path: path})
+}
+
+// Declare records permissions that are checked in code rather than by a route
+func (r *PermissionRegistry) Declare(permissions ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, permission := range permissions {
+		r.declared[permission] = true
+	}
+}
+
+// Routes returns the registered routes in registration order
+func (r *PermissionRegistry) Routes() []RoutePermission {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	routes := make([]RoutePermission, len(r.routes))
+	copy(routes, r.routes)
+	return routes
+}
+
+// Permissions returns the sorted names of all permissions required by routes or declared
+func (r *PermissionRegistry) Permissions() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]bool, len(r.routes)+len(r.declared))
+	for _, route := range r.routes {
+		seen[route.Permission] = true
+	}
+	for permission := range r.declared {
+		seen[permission] = true
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Endpoints returns the endpoints a granted permission unlocks, including
+// those unlocked through a wildcard pattern or an implied action
+func (r *PermissionRegistry) Endpoints(granted string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	endpoints := make([]string, 0)
+	for _, route := range r.routes {
+		if entity.MatchPermission(granted, route.Permission) {
+			endpoints = append(endpoints, route.Endpoint())
+		}
+	}
+	return endpoints
+}
+
+// Covers checks if a granted permission matches at least one registered or
+// declared permission. Permissions that cover nothing are orphans.
+func (r *PermissionRegistry) Covers(granted string) bool {
+	for _, permission := range r.Permissions() {
+		if entity.MatchPermission(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPermissionRegistry() *PermissionRegistry {
+	registry := NewPermissionRegistry()
+	registry.RegisterRoute("user:read", "GET", "/api/users/:id/dormitory-roles")
+	registry.RegisterRoute("user:update", "PUT", "/api/users/:id")
+	registry.RegisterRoute("user:update", "POST", "/api/users/:id/roles")
+	registry.RegisterRoute("user:delete", "DELETE", "/api/users/:id")
+	registry.RegisterRoute("role:read", "GET", "/api/roles")
+	registry.Declare("dorm:access_all")
+	return registry
+}
+
+func TestPermissionRegistry_Permissions(t *testing.T) {
+	registry := newTestPermissionRegistry()
+
+	assert.Equal(t, []string{"dorm:access_all", "role:read", "user:delete", "user:read", "user:update"}, registry.Permissions())
+	assert.Len(t, registry.Routes(), 5)
+}
+
+func TestPermissionRegistry_Endpoints(t *testing.T) {
+	registry := newTestPermissionRegistry()
+
+	assert.Equal(t, []string{"GET /api/roles"}, registry.Endpoints("role:read"))
+	// user:update implies user:read
+	assert.Equal(t, []string{
+		"GET /api/users/:id/dormitory-roles",
+		"PUT /api/users/:id",
+		"POST /api/users/:id/roles",
+	}, registry.Endpoints("user:update"))
+	assert.Equal(t, []string{
+		"GET /api/users/:id/dormitory-roles",
+		"PUT /api/users/:id",
+		"POST /api/users/:id/roles",
+		"DELETE /api/users/:id",
+	}, registry.Endpoints("user:*"))
+	assert.Len(t, registry.Endpoints("*"), 5)
+	assert.Empty(t, registry.Endpoints("dorm:access_all"))
+	assert.Empty(t, registry.Endpoints("room:read"))
+}
+
+func TestPermissionRegistry_Covers(t *testing.T) {
+	registry := newTestPermissionRegistry()
+
+	assert.True(t, registry.Covers("user:update"))
+	assert.True(t, registry.Covers("user:*"))
+	assert.True(t, registry.Covers("dorm:access_all"))
+	assert.False(t, registry.Covers("room:read"))
+	assert.False(t, registry.Covers("dorm:create"))
+}
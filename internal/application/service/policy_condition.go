@@ -0,0 +1,201 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// policyCondition is a compiled condition of the form "<operand> <operator> <operand>".
+//
+// Operands are attribute paths (action, subject.id, subject.email, subject.roles,
+// subject.dormitories, subject.dormitory_roles.<role slug>, subject.permissions,
+// subject.<attribute>, resource.type,
+// resource.id, resource.<attribute>) or quoted string literals.
+// Operators are ==, !=, in, not in, contains and not contains.
+// Missing or empty attributes never compare equal, so they do not satisfy
+// ==, in or contains.
+type policyCondition struct {
+	expr     string
+	left     policyOperand
+	operator string
+	right    policyOperand
+	negate   bool
+}
+
+type policyOperand struct {
+	path    string
+	literal string
+}
+
+// policyValue is a resolved operand: a single value, a list or a permission set
+type policyValue struct {
+	value       string
+	list        []string
+	permissions *entity.PermissionSet
+}
+
+func parsePolicyCondition(expr string) (*policyCondition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, fmt.Errorf("condition %q: %w", expr, err)
+	}
+
+	cond := &policyCondition{expr: expr}
+	switch {
+	case len(tokens) == 3:
+		cond.operator = tokens[1]
+	case len(tokens) == 4 && tokens[1] == "not" && (tokens[2] == "in" || tokens[2] == "contains"):
+		cond.operator = tokens[2]
+		cond.negate = true
+		tokens = []string{tokens[0], tokens[2], tokens[3]}
+	default:
+		return nil, fmt.Errorf("condition %q: expected \"<operand> <operator> <operand>\"", expr)
+	}
+
+	switch cond.operator {
+	case "==", "in", "contains":
+	case "!=":
+		cond.operator = "=="
+		cond.negate = true
+	default:
+		return nil, fmt.Errorf("condition %q: unknown operator %q", expr, cond.operator)
+	}
+
+	if cond.left, err = parseOperand(tokens[0]); err != nil {
+		return nil, fmt.Errorf("condition %q: %w", expr, err)
+	}
+	if cond.right, err = parseOperand(tokens[2]); err != nil {
+		return nil, fmt.Errorf("condition %q: %w", expr, err)
+	}
+
+	return cond, nil
+}
+
+// tokenizeCondition splits a condition on whitespace, keeping quoted literals together
+func tokenizeCondition(expr string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	var quote rune
+	inToken := false
+
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			current.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inToken = true
+			current.WriteRune(r)
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			inToken = true
+			current.WriteRune(r)
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated string literal")
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+func parseOperand(token string) (policyOperand, error) {
+	if len(token) >= 2 && (token[0] == '"' || token[0] == '\'') && token[len(token)-1] == token[0] {
+		return policyOperand{literal: token[1 : len(token)-1]}, nil
+	}
+
+	root, name, _ := strings.Cut(token, ".")
+	switch {
+	case token == "action":
+	case (root == "subject" || root == "resource") && name != "":
+	default:
+		return policyOperand{}, fmt.Errorf("unknown operand %q", token)
+	}
+	return policyOperand{path: token}, nil
+}
+
+func (c *policyCondition) evaluate(req *AccessRequest) bool {
+	left := c.left.resolve(req)
+	right := c.right.resolve(req)
+
+	var result bool
+	switch c.operator {
+	case "==":
+		result = left.value != "" && left.value == right.value
+	case "in":
+		result = right.has(left.value)
+	case "contains":
+		result = left.has(right.value)
+	}
+
+	if c.negate {
+		return !result
+	}
+	return result
+}
+
+func (o policyOperand) resolve(req *AccessRequest) policyValue {
+	if o.path == "" {
+		return policyValue{value: o.literal}
+	}
+
+	root, name, _ := strings.Cut(o.path, ".")
+	switch root {
+	case "action":
+		return policyValue{value: req.Action}
+	case "subject":
+		switch name {
+		case "id":
+			return policyValue{value: req.Subject.ID}
+		case "email":
+			return policyValue{value: req.Subject.Email}
+		case "roles":
+			return policyValue{list: req.Subject.Roles}
+		case "dormitories":
+			return policyValue{list: req.Subject.Dormitories}
+		case "permissions":
+			return policyValue{permissions: req.Subject.Permissions}
+		default:
+			if slug, ok := strings.CutPrefix(name, "dormitory_roles."); ok {
+				return policyValue{list: req.Subject.DormitoryRoles[slug]}
+			}
+			return policyValue{value: req.Subject.Attributes[name]}
+		}
+	default:
+		switch name {
+		case "type":
+			return policyValue{value: req.Resource.Type}
+		case "id":
+			return policyValue{value: req.Resource.ID}
+		default:
+			return policyValue{value: req.Resource.Attributes[name]}
+		}
+	}
+}
+
+// has checks if a resolved list or permission set holds a value
+func (v policyValue) has(value string) bool {
+	if value == "" {
+		return false
+	}
+	if v.permissions != nil {
+		return v.permissions.Has(value)
+	}
+	if v.list != nil {
+		return containsString(v.list, value)
+	}
+	return v.value == value
+}
@@ -0,0 +1,338 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// PolicyEffect is the outcome a policy produces when it matches
+type PolicyEffect string
+
+const (
+	PolicyAllow PolicyEffect = "allow"
+	PolicyDeny  PolicyEffect = "deny"
+)
+
+// Subject holds the attributes of the principal making a request
+type Subject struct {
+	ID    string
+	Email string
+	// Roles are role slugs
+	Roles []string
+	// Dormitories are the IDs of the dormitories the subject is assigned to,
+	// as a member or through a dormitory-scoped role
+	Dormitories []string
+	// DormitoryRoles maps the slugs of dormitory-scoped roles to the IDs of the
+	// dormitories they are held in, addressed as subject.dormitory_roles.<slug>
+	DormitoryRoles map[string][]string
+	Permissions    *entity.PermissionSet
+	// Scope is the permission scope of the API key the request was made with,
+	// nil otherwise. It is a ceiling: actions outside it are denied even when
+	// an allow policy matches.
+	Scope *entity.PermissionSet
+	// Attributes holds any further attributes, addressed as subject.<name> in conditions
+	Attributes map[string]string
+}
+
+// Resource holds the attributes of the object a request acts on
+type Resource struct {
+	// Type is the kind of resource, e.g. "dormitory", "user" or "audit_log"
+	Type string
+	ID   string
+	// Attributes holds any further attributes, addressed as resource.<name> in conditions
+	Attributes map[string]string
+}
+
+// AccessRequest asks whether a subject may perform an action on a resource
+type AccessRequest struct {
+	Subject Subject
+	// Action is a permission name, e.g. "dorm:update"
+	Action   string
+	Resource Resource
+}
+
+// Policy is an attribute-based access rule evaluated on top of RBAC.
+// A policy applies when the action, resource type and subject roles match;
+// it matches when all of its conditions hold.
+type Policy struct {
+	Name        string       `yaml:"name" json:"name"`
+	Description string       `yaml:"description" json:"description"`
+	Effect      PolicyEffect `yaml:"effect" json:"effect"`
+	// Actions are permission names or patterns such as "dorm:*"
+	Actions []string `yaml:"actions" json:"actions"`
+	// Resources are resource types; empty applies to any resource
+	Resources []string `yaml:"resources" json:"resources"`
+	// Roles are role slugs the subject must hold one of; empty applies to any subject
+	Roles []string `yaml:"roles" json:"roles"`
+	// Conditions are expressions such as "subject.dormitories contains resource.id"
+	Conditions []string `yaml:"conditions" json:"conditions"`
+	// Condition is an optional predicate for policies defined in Go,
+	// evaluated after Conditions
+	Condition func(req *AccessRequest) bool `yaml:"-" json:"-"`
+}
+
+// Decision is the result of evaluating an access request
+type Decision struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+	// Policy is the policy that decided, empty when RBAC decided
+	Policy string `json:"policy,omitempty"`
+	// Trace is only filled in by Explain
+	Trace []PolicyTrace `json:"trace,omitempty"`
+}
+
+// PolicyTrace records how one policy was evaluated
+type PolicyTrace struct {
+	Policy     string           `json:"policy"`
+	Effect     PolicyEffect     `json:"effect"`
+	Applicable bool             `json:"applicable"`
+	Matched    bool             `json:"matched"`
+	Conditions []ConditionTrace `json:"conditions,omitempty"`
+}
+
+// ConditionTrace records the result of one condition
+type ConditionTrace struct {
+	Expression string `json:"expression"`
+	Result     bool   `json:"result"`
+}
+
+// Explanation formats the decision and its trace for logs
+func (d Decision) Explanation() string {
+	var b strings.Builder
+	b.WriteString(d.Reason)
+	for _, trace := range d.Trace {
+		fmt.Fprintf(&b, "; %s(%s)", trace.Policy, trace.Effect)
+		if !trace.Applicable {
+			b.WriteString(" not applicable")
+			continue
+		}
+		fmt.Fprintf(&b, " matched=%t", trace.Matched)
+		for _, cond := range trace.Conditions {
+			fmt.Fprintf(&b, " [%s => %t]", cond.Expression, cond.Result)
+		}
+	}
+	return b.String()
+}
+
+// PolicyEngine decides access requests with attribute-based policies.
+// Deny policies take precedence over allow policies; when no policy matches,
+// the subject's permissions decide as with plain RBAC.
+type PolicyEngine interface {
+	Evaluate(req AccessRequest) Decision
+	// Explain evaluates every policy and records a trace for debugging
+	Explain(req AccessRequest) Decision
+}
+
+type compiledPolicy struct {
+	Policy
+	conditions []*policyCondition
+}
+
+type policyEngine struct {
+	policies []compiledPolicy
+}
+
+// NewPolicyEngine creates a policy engine, validating the policies and their conditions
+func NewPolicyEngine(policies []Policy) (PolicyEngine, error) {
+	engine := &policyEngine{policies: make([]compiledPolicy, 0, len(policies))}
+	names := make(map[string]bool, len(policies))
+
+	for _, policy := range policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("policy without name")
+		}
+		if names[policy.Name] {
+			return nil, fmt.Errorf("duplicate policy %q", policy.Name)
+		}
+		names[policy.Name] = true
+
+		if policy.Effect != PolicyAllow && policy.Effect != PolicyDeny {
+			return nil, fmt.Errorf("policy %q: effect must be %q or %q", policy.Name, PolicyAllow, PolicyDeny)
+		}
+		if len(policy.Actions) == 0 {
+			return nil, fmt.Errorf("policy %q: at least one action is required", policy.Name)
+		}
+
+		compiled := compiledPolicy{Policy: policy}
+		for _, expr := range policy.Conditions {
+			cond, err := parsePolicyCondition(expr)
+			if err != nil {
+				return nil, fmt.Errorf("policy %q: %w", policy.Name, err)
+			}
+			compiled.conditions = append(compiled.conditions, cond)
+		}
+		engine.policies = append(engine.policies, compiled)
+	}
+
+	return engine, nil
+}
+
+func (e *policyEngine) Evaluate(req AccessRequest) Decision {
+	return e.evaluate(&req, false)
+}
+
+func (e *policyEngine) Explain(req AccessRequest) Decision {
+	return e.evaluate(&req, true)
+}
+
+func (e *policyEngine) evaluate(req *AccessRequest, explain bool) Decision {
+	var traces []PolicyTrace
+	deniedBy, allowedBy := "", ""
+
+	for i := range e.policies {
+		policy := &e.policies[i]
+
+		trace := PolicyTrace{Policy: policy.Name, Effect: policy.Effect}
+		trace.Applicable = policy.appliesTo(req)
+		if trace.Applicable {
+			trace.Matched = policy.matches(req, &trace, explain)
+		}
+		if explain {
+			traces = append(traces, trace)
+		}
+
+		if !trace.Matched {
+			continue
+		}
+		if policy.Effect == PolicyDeny && deniedBy == "" {
+			deniedBy = policy.Name
+			if !explain {
+				break
+			}
+		}
+		if policy.Effect == PolicyAllow && allowedBy == "" {
+			allowedBy = policy.Name
+		}
+	}
+
+	var decision Decision
+	switch {
+	case deniedBy != "":
+		decision = Decision{Allowed: false, Reason: fmt.Sprintf("denied by policy %s", deniedBy), Policy: deniedBy}
+	case req.Subject.Scope != nil && !req.Subject.Scope.Has(req.Action):
+		decision = Decision{Allowed: false, Reason: fmt.Sprintf("%s is outside the API key scope", req.Action)}
+	case allowedBy != "":
+		decision = Decision{Allowed: true, Reason: fmt.Sprintf("allowed by policy %s", allowedBy), Policy: allowedBy}
+	case req.Subject.Permissions.Has(req.Action):
+		decision = Decision{Allowed: true, Reason: fmt.Sprintf("granted by permission %s", req.Action)}
+	default:
+		decision = Decision{Allowed: false, Reason: fmt.Sprintf("no policy or permission grants %s", req.Action)}
+	}
+	decision.Trace = traces
+
+	return decision
+}
+
+// appliesTo checks the action, resource type and role filters of a policy
+func (p *compiledPolicy) appliesTo(req *AccessRequest) bool {
+	actionMatches := false
+	for _, action := range p.Actions {
+		if matchAction(action, req.Action) {
+			actionMatches = true
+			break
+		}
+	}
+	if !actionMatches {
+		return false
+	}
+
+	if len(p.Resources) > 0 && !containsString(p.Resources, req.Resource.Type) {
+		return false
+	}
+
+	if len(p.Roles) > 0 {
+		for _, role := range req.Subject.Roles {
+			if containsString(p.Roles, role) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// matches checks the conditions of an applicable policy
+func (p *compiledPolicy) matches(req *AccessRequest, trace *PolicyTrace, explain bool) bool {
+	matched := true
+	for _, cond := range p.conditions {
+		result := cond.evaluate(req)
+		if explain {
+			trace.Conditions = append(trace.Conditions, ConditionTrace{Expression: cond.expr, Result: result})
+		}
+		if !result {
+			matched = false
+			if !explain {
+				return false
+			}
+		}
+	}
+	if matched && p.Condition != nil {
+		matched = p.Condition(req)
+	}
+	return matched
+}
+
+// SubjectFromUser builds the subject attributes of a user loaded with roles and dormitories.
+// When permissions is nil it is resolved from the user's roles.
+func SubjectFromUser(user *entity.User, permissions *entity.PermissionSet) Subject {
+	if permissions == nil {
+		permissions = user.PermissionSet()
+	}
+
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = role.Slug
+	}
+	dormitories := make([]string, len(user.Dormitories))
+	for i, dorm := range user.Dormitories {
+		dormitories[i] = dorm.ID.String()
+	}
+	dormitoryRoles := make(map[string][]string)
+	for _, scoped := range user.DormitoryRoles {
+		dormitoryID := scoped.DormitoryID.String()
+		if !containsString(dormitories, dormitoryID) {
+			dormitories = append(dormitories, dormitoryID)
+		}
+		dormitoryRoles[scoped.Role.Slug] = append(dormitoryRoles[scoped.Role.Slug], dormitoryID)
+	}
+
+	return Subject{
+		ID:             user.ID.String(),
+		Email:          user.Email,
+		Roles:          roles,
+		Dormitories:    dormitories,
+		DormitoryRoles: dormitoryRoles,
+		Permissions:    permissions,
+	}
+}
+
+// matchAction matches an action against a policy action or pattern.
+// Unlike permission grants, implied actions are not expanded: a policy on
+// "dorm:update" does not apply to "dorm:read".
+func matchAction(pattern, action string) bool {
+	if pattern == action || pattern == entity.PermissionWildcard {
+		return true
+	}
+	patternResource, patternAction, ok := strings.Cut(pattern, ":")
+	if !ok {
+		return false
+	}
+	resource, act, ok := strings.Cut(action, ":")
+	if !ok {
+		return false
+	}
+	return (patternResource == entity.PermissionWildcard || patternResource == resource) &&
+		(patternAction == entity.PermissionWildcard || patternAction == act)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
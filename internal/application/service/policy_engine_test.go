@@ -0,0 +1,350 @@
+package service
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+const testPolicies = `
+policies:
+  - name: wardens-update-assigned-dormitories
+    effect: allow
+    actions: ["dorm:update"]
+    resources: ["dormitory"]
+    roles: ["warden"]
+    conditions:
+      - subject.dormitories contains resource.id
+  - name: users-update-own-profile
+    effect: allow
+    actions: ["user:update"]
+    resources: ["user"]
+    conditions:
+      - resource.id == subject.id
+  - name: audit-read-own-dormitory
+    effect: deny
+    actions: ["audit:read"]
+    resources: ["audit_log"]
+    conditions:
+      - resource.dormitory_id not in subject.dormitories
+      - subject.permissions not contains "dorm:access_all"
+`
+
+func TestPolicyEngine_Evaluate(t *testing.T) {
+	policies, err := ParsePolicies([]byte(testPolicies))
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policies)
+	require.NoError(t, err)
+
+	warden := Subject{
+		ID:          "warden-1",
+		Roles:       []string{"warden"},
+		Dormitories: []string{"dorm-a"},
+		Permissions: entity.NewPermissionSet("dorm:read", "audit:read"),
+	}
+	auditor := Subject{
+		ID:          "auditor-1",
+		Roles:       []string{"auditor"},
+		Permissions: entity.NewPermissionSet("audit:read", entity.PermissionDormAccessAll),
+	}
+
+	tests := []struct {
+		name           string
+		req            AccessRequest
+		expectedResult bool
+		expectedPolicy string
+	}{
+		{
+			name:           "allow - warden updates assigned dormitory",
+			req:            AccessRequest{Subject: warden, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: "dorm-a"}},
+			expectedResult: true,
+			expectedPolicy: "wardens-update-assigned-dormitories",
+		},
+		{
+			name:           "deny - warden updates other dormitory",
+			req:            AccessRequest{Subject: warden, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: "dorm-b"}},
+			expectedResult: false,
+		},
+		{
+			name:           "deny - role filter excludes other roles",
+			req:            AccessRequest{Subject: Subject{ID: "u", Dormitories: []string{"dorm-a"}}, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: "dorm-a"}},
+			expectedResult: false,
+		},
+		{
+			name:           "allow - user updates own profile",
+			req:            AccessRequest{Subject: warden, Action: "user:update", Resource: Resource{Type: "user", ID: "warden-1"}},
+			expectedResult: true,
+			expectedPolicy: "users-update-own-profile",
+		},
+		{
+			name:           "deny - user updates another profile",
+			req:            AccessRequest{Subject: warden, Action: "user:update", Resource: Resource{Type: "user", ID: "someone-else"}},
+			expectedResult: false,
+		},
+		{
+			name:           "deny - missing attributes never compare equal",
+			req:            AccessRequest{Subject: Subject{}, Action: "user:update", Resource: Resource{Type: "user"}},
+			expectedResult: false,
+		},
+		{
+			name:           "allow - audit event of own dormitory falls back to permission",
+			req:            AccessRequest{Subject: warden, Action: "audit:read", Resource: Resource{Type: "audit_log", Attributes: map[string]string{"dormitory_id": "dorm-a"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "deny - audit event of other dormitory",
+			req:            AccessRequest{Subject: warden, Action: "audit:read", Resource: Resource{Type: "audit_log", Attributes: map[string]string{"dormitory_id": "dorm-b"}}},
+			expectedResult: false,
+			expectedPolicy: "audit-read-own-dormitory",
+		},
+		{
+			name:           "allow - dorm:access_all is exempt from the deny policy",
+			req:            AccessRequest{Subject: auditor, Action: "audit:read", Resource: Resource{Type: "audit_log", Attributes: map[string]string{"dormitory_id": "dorm-b"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "allow - no policy applies, permission grants",
+			req:            AccessRequest{Subject: warden, Action: "dorm:read", Resource: Resource{Type: "dormitory", ID: "dorm-b"}},
+			expectedResult: true,
+		},
+		{
+			name:           "deny - no policy applies, no permission",
+			req:            AccessRequest{Subject: warden, Action: "role:delete", Resource: Resource{Type: "role"}},
+			expectedResult: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := engine.Evaluate(tt.req)
+			assert.Equal(t, tt.expectedResult, decision.Allowed, decision.Reason)
+			assert.Equal(t, tt.expectedPolicy, decision.Policy)
+			assert.Empty(t, decision.Trace)
+
+			// Explain reaches the same decision
+			explained := engine.Explain(tt.req)
+			assert.Equal(t, decision.Allowed, explained.Allowed)
+			assert.Len(t, explained.Trace, len(policies))
+		})
+	}
+}
+
+func TestPolicyEngine_DenyOverridesAllow(t *testing.T) {
+	engine, err := NewPolicyEngine([]Policy{
+		{Name: "allow-all-dorms", Effect: PolicyAllow, Actions: []string{"dorm:*"}},
+		{
+			Name:    "deny-inactive-dorms",
+			Effect:  PolicyDeny,
+			Actions: []string{"dorm:update"},
+			Condition: func(req *AccessRequest) bool {
+				return req.Resource.Attributes["is_active"] == "false"
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	active := AccessRequest{Action: "dorm:update", Resource: Resource{Type: "dormitory", Attributes: map[string]string{"is_active": "true"}}}
+	inactive := AccessRequest{Action: "dorm:update", Resource: Resource{Type: "dormitory", Attributes: map[string]string{"is_active": "false"}}}
+
+	assert.True(t, engine.Evaluate(active).Allowed)
+	decision := engine.Evaluate(inactive)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, "deny-inactive-dorms", decision.Policy)
+
+	// Policy actions are not widened by implied actions
+	assert.True(t, engine.Evaluate(AccessRequest{Action: "dorm:read", Resource: inactive.Resource}).Allowed)
+}
+
+func TestPolicyEngine_APIKeyScopeIsCeiling(t *testing.T) {
+	policies, err := ParsePolicies([]byte(testPolicies))
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policies)
+	require.NoError(t, err)
+
+	warden := Subject{
+		ID:          "warden-1",
+		Roles:       []string{"warden"},
+		Dormitories: []string{"dorm-a"},
+		Permissions: entity.NewPermissionSet("dorm:read"),
+		Scope:       entity.NewPermissionSet("dorm:read"),
+	}
+	update := AccessRequest{Subject: warden, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: "dorm-a"}}
+
+	// The allow policy matches, but the key does not cover dorm:update
+	decision := engine.Evaluate(update)
+	assert.False(t, decision.Allowed)
+	assert.Contains(t, decision.Reason, "outside the API key scope")
+
+	update.Subject.Scope = entity.NewPermissionSet("dorm:*")
+	decision = engine.Evaluate(update)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, "wardens-update-assigned-dormitories", decision.Policy)
+}
+
+func TestSubjectFromUser_DormitoryRoles(t *testing.T) {
+	member := uuid.New()
+	scoped := uuid.New()
+	user := &entity.User{
+		ID:          uuid.New(),
+		Roles:       []entity.Role{{Slug: "user"}},
+		Dormitories: []entity.Dormitory{{ID: member}},
+		DormitoryRoles: []entity.UserDormitoryRole{
+			{DormitoryID: scoped, Role: entity.Role{Slug: "warden"}},
+			{DormitoryID: member, Role: entity.Role{Slug: "cleaner"}},
+		},
+	}
+
+	subject := SubjectFromUser(user, entity.NewPermissionSet())
+	assert.Equal(t, []string{"user"}, subject.Roles)
+	assert.Equal(t, []string{member.String(), scoped.String()}, subject.Dormitories)
+	assert.Equal(t, map[string][]string{"warden": {scoped.String()}, "cleaner": {member.String()}}, subject.DormitoryRoles)
+
+	engine, err := NewPolicyEngine([]Policy{{
+		Name:       "dormitory-wardens",
+		Effect:     PolicyAllow,
+		Actions:    []string{"dorm:update"},
+		Resources:  []string{"dormitory"},
+		Conditions: []string{"resource.id in subject.dormitory_roles.warden"},
+	}})
+	require.NoError(t, err)
+
+	update := func(id uuid.UUID) bool {
+		return engine.Evaluate(AccessRequest{Subject: subject, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: id.String()}}).Allowed
+	}
+	assert.True(t, update(scoped))
+	// Holding another role in the dormitory is not enough
+	assert.False(t, update(member))
+}
+
+func TestPolicyEngine_ExamplePolicies(t *testing.T) {
+	data, err := os.ReadFile("../../../policies.example.yaml")
+	require.NoError(t, err)
+	policies, err := ParsePolicies(data)
+	require.NoError(t, err)
+	engine, err := NewPolicyEngine(policies)
+	require.NoError(t, err)
+
+	warden := Subject{
+		ID:             "warden-1",
+		Dormitories:    []string{"dorm-a"},
+		DormitoryRoles: map[string][]string{"warden": {"dorm-a"}},
+		Permissions:    entity.NewPermissionSet("audit:read"),
+	}
+
+	tests := []struct {
+		name           string
+		req            AccessRequest
+		expectedResult bool
+	}{
+		{
+			name:           "allow - dormitory warden updates their dormitory",
+			req:            AccessRequest{Subject: warden, Action: "dorm:update", Resource: Resource{Type: "dormitory", ID: "dorm-a"}},
+			expectedResult: true,
+		},
+		{
+			name:           "allow - user renames themselves",
+			req:            AccessRequest{Subject: warden, Action: "user:update", Resource: Resource{Type: "user", ID: "warden-1", Attributes: map[string]string{"privileged": "false"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "deny - user changes their own roles",
+			req:            AccessRequest{Subject: warden, Action: "user:update", Resource: Resource{Type: "user", ID: "warden-1", Attributes: map[string]string{"privileged": "true"}}},
+			expectedResult: false,
+		},
+		{
+			name:           "allow - audit logs of own dormitory",
+			req:            AccessRequest{Subject: warden, Action: "audit:read", Resource: Resource{Type: "audit_log", Attributes: map[string]string{"dormitory_id": "dorm-a"}}},
+			expectedResult: true,
+		},
+		{
+			name:           "deny - unfiltered audit logs",
+			req:            AccessRequest{Subject: warden, Action: "audit:read", Resource: Resource{Type: "audit_log", Attributes: map[string]string{"dormitory_id": ""}}},
+			expectedResult: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := engine.Evaluate(tt.req)
+			assert.Equal(t, tt.expectedResult, decision.Allowed, decision.Reason)
+		})
+	}
+}
+
+func TestPolicyEngine_Explain(t *testing.T) {
+	engine, err := NewPolicyEngine([]Policy{
+		{Name: "roles-only", Effect: PolicyAllow, Actions: []string{"role:*"}},
+		{
+			Name:       "own-dormitory",
+			Effect:     PolicyAllow,
+			Actions:    []string{"dorm:update"},
+			Conditions: []string{"subject.dormitories contains resource.id", `action == 'dorm:update'`},
+		},
+	})
+	require.NoError(t, err)
+
+	decision := engine.Explain(AccessRequest{
+		Subject:  Subject{Dormitories: []string{"dorm-a"}},
+		Action:   "dorm:update",
+		Resource: Resource{Type: "dormitory", ID: "dorm-b"},
+	})
+
+	assert.False(t, decision.Allowed)
+	require.Len(t, decision.Trace, 2)
+	assert.False(t, decision.Trace[0].Applicable)
+	assert.True(t, decision.Trace[1].Applicable)
+	assert.False(t, decision.Trace[1].Matched)
+	assert.Equal(t, []ConditionTrace{
+		{Expression: "subject.dormitories contains resource.id", Result: false},
+		{Expression: "action == 'dorm:update'", Result: true},
+	}, decision.Trace[1].Conditions)
+	assert.Contains(t, decision.Explanation(), "[subject.dormitories contains resource.id => false]")
+}
+
+func TestNewPolicyEngine_InvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{name: "missing name", policy: Policy{Effect: PolicyAllow, Actions: []string{"dorm:read"}}},
+		{name: "unknown effect", policy: Policy{Name: "p", Effect: "maybe", Actions: []string{"dorm:read"}}},
+		{name: "missing actions", policy: Policy{Name: "p", Effect: PolicyAllow}},
+		{name: "unknown operator", policy: Policy{Name: "p", Effect: PolicyAllow, Actions: []string{"dorm:read"}, Conditions: []string{"resource.id ~= subject.id"}}},
+		{name: "unknown operand", policy: Policy{Name: "p", Effect: PolicyAllow, Actions: []string{"dorm:read"}, Conditions: []string{"user.id == subject.id"}}},
+		{name: "incomplete condition", policy: Policy{Name: "p", Effect: PolicyAllow, Actions: []string{"dorm:read"}, Conditions: []string{"resource.id =="}}},
+		{name: "unterminated literal", policy: Policy{Name: "p", Effect: PolicyAllow, Actions: []string{"dorm:read"}, Conditions: []string{`resource.id == "abc`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolicyEngine([]Policy{tt.policy})
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewPolicyEngine([]Policy{
+		{Name: "p", Effect: PolicyAllow, Actions: []string{"dorm:read"}},
+		{Name: "p", Effect: PolicyDeny, Actions: []string{"dorm:read"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := ParsePolicies([]byte(`{"policies": [{"name": "p", "effect": "allow", "actions": ["dorm:read"], "conditions": ["resource.id in subject.dormitories"]}]}`))
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Equal(t, PolicyAllow, policies[0].Effect)
+	assert.Equal(t, []string{"resource.id in subject.dormitories"}, policies[0].Conditions)
+
+	// Unknown fields are rejected
+	_, err = ParsePolicies([]byte("policies:\n  - name: p\n    efect: allow\n"))
+	assert.Error(t, err)
+
+	policies, err = ParsePolicies(nil)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
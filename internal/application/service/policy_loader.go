@@ -0,0 +1,49 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// policyFile is the layout of a policy file:
+//
+//	policies:
+//	  - name: wardens-update-assigned-dormitories
+//	    effect: allow
+//	    actions: ["dorm:update"]
+//	    resources: ["dormitory"]
+//	    roles: ["warden"]
+//	    conditions:
+//	      - subject.dormitories contains resource.id
+type policyFile struct {
+	Policies []Policy `yaml:"policies"`
+}
+
+// ParsePolicies parses policies from YAML or JSON (JSON is valid YAML).
+// Unknown fields are rejected so that typos do not silently widen a policy.
+func ParsePolicies(data []byte) ([]Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var file policyFile
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse policies: %w", err)
+	}
+
+	return file.Policies, nil
+}
+
+// LoadPolicyFile reads policies from a YAML or JSON file
+func LoadPolicyFile(path string) ([]Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file: %w", err)
+	}
+
+	return ParsePolicies(data)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Sweeper runs a maintenance task in the background at a fixed interval
+type Sweeper struct {
+	name     string
+	interval time.Duration
+	task     func(ctx context.Context) error
+}
+
+// NewSweeper creates a sweeper that runs task every interval
+func NewSweeper(name string, interval time.Duration, task func(ctx context.Context) error) *Sweeper {
+	return &Sweeper{
+		name:     name,
+		interval: interval,
+		task:     task,
+	}
+}
+
+// Start runs the task once immediately and then on every tick until ctx is cancelled.
+// It does not block; errors are logged and do not stop the sweeper.
+func (s *Sweeper) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Sweeper) run(ctx context.Context) {
+	if err := s.task(ctx); err != nil {
+		log.Printf("Sweeper %s failed: %v", s.name, err)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweeper_RunsImmediatelyAndOnInterval(t *testing.T) {
+	var runs atomic.Int32
+	sweeper := NewSweeper("test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("keeps running after errors")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sweeper.Start(ctx)
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSweeper_StopsWhenContextCancelled(t *testing.T) {
+	var runs atomic.Int32
+	sweeper := NewSweeper("test", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sweeper.Start(ctx)
+	assert.Eventually(t, func() bool { return runs.Load() >= 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	// Allow an in-flight tick to finish, then the count must stay put
+	time.Sleep(20 * time.Millisecond)
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// apiKeyPrefixLength is how much of the plaintext key is kept for identification
+const apiKeyPrefixLength = 12
+
+// APIKeyUseCase handles personal access token use cases
+type APIKeyUseCase struct {
+	apiKeyRepo     repository.APIKeyRepository
+	userRepo       repository.UserRepository
+	permissionRepo repository.PermissionRepository
+	auditLogger    appService.AuditLogger
+}
+
+// NewAPIKeyUseCase creates a new API key use case
+func NewAPIKeyUseCase(
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	permissionRepo repository.PermissionRepository,
+	auditLogger appService.AuditLogger,
+) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo:     apiKeyRepo,
+		userRepo:       userRepo,
+		permissionRepo: permissionRepo,
+		auditLogger:    auditLogger,
+	}
+}
+
+// CreateAPIKey creates a new API key for a user.
+// The key may only be scoped to permissions the user currently holds,
+// directly or through a wildcard pattern or implied action.
+func (uc *APIKeyUseCase) CreateAPIKey(ctx context.Context, userID uuid.UUID, req dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	permissions, err := uc.scopePermissions(ctx, user, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	plainKey := entity.APIKeyPrefix + token
+
+	apiKey := &entity.APIKey{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        req.Name,
+		Prefix:      plainKey[:apiKeyPrefixLength],
+		KeyHash:     entity.HashAPIKey(plainKey),
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Permissions: permissions,
+	}
+
+	if err := uc.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "api_key", "api_key:create", apiKey.ID.String(), map[string]string{
+		"user_id": userID.String(),
+		"name":    apiKey.Name,
+		"prefix":  apiKey.Prefix,
+	})
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *uc.toAPIKeyResponse(apiKey),
+		Key:            plainKey,
+	}, nil
+}
+
+// ListAPIKeys lists the API keys owned by a user
+func (uc *APIKeyUseCase) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]dto.APIKeyResponse, error) {
+	keys, err := uc.apiKeyRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		responses[i] = *uc.toAPIKeyResponse(key)
+	}
+
+	return responses, nil
+}
+
+// GetAPIKey retrieves an API key owned by a user
+func (uc *APIKeyUseCase) GetAPIKey(ctx context.Context, userID, id uuid.UUID) (*dto.APIKeyResponse, error) {
+	key, err := uc.getOwnedKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toAPIKeyResponse(key), nil
+}
+
+// UpdateAPIKey renames an API key owned by a user
+func (uc *APIKeyUseCase) UpdateAPIKey(ctx context.Context, userID, id uuid.UUID, req dto.UpdateAPIKeyRequest) (*dto.APIKeyResponse, error) {
+	key, err := uc.getOwnedKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Name = req.Name
+	key.UpdatedAt = time.Now()
+	if err := uc.apiKeyRepo.Update(ctx, key); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "api_key", "api_key:update", key.ID.String(), map[string]string{
+		"user_id": userID.String(),
+		"name":    key.Name,
+	})
+
+	return uc.toAPIKeyResponse(key), nil
+}
+
+// DeleteAPIKey revokes an API key owned by a user
+func (uc *APIKeyUseCase) DeleteAPIKey(ctx context.Context, userID, id uuid.UUID) error {
+	key, err := uc.getOwnedKey(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.apiKeyRepo.Delete(ctx, key.ID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "api_key", "api_key:delete", key.ID.String(), map[string]string{
+		"user_id": userID.String(),
+		"prefix":  key.Prefix,
+	})
+
+	return nil
+}
+
+// getOwnedKey loads a key and hides keys owned by other users behind ErrAPIKeyNotFound
+func (uc *APIKeyUseCase) getOwnedKey(ctx context.Context, userID, id uuid.UUID) (*entity.APIKey, error) {
+	key, err := uc.apiKeyRepo.GetByID(ctx, id)
+	if err != nil || key.UserID != userID {
+		return nil, domainErrors.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// scopePermissions resolves the requested permission names against the user's roles.
+// Names the user only holds through a pattern are loaded from the permission catalog.
+func (uc *APIKeyUseCase) scopePermissions(ctx context.Context, user *entity.User, names []string) ([]entity.Permission, error) {
+	held := user.PermissionSet()
+	assigned := make(map[string]entity.Permission)
+	for _, role := range user.Roles {
+		for _, perm := range role.EffectivePermissions() {
+			assigned[perm.Name] = perm
+		}
+	}
+
+	permissions := make([]entity.Permission, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		if !held.Has(name) {
+			return nil, domainErrors.ErrPermissionDenied
+		}
+
+		perm, ok := assigned[name]
+		if !ok {
+			found, err := uc.permissionRepo.GetByName(ctx, name)
+			if err != nil {
+				return nil, domainErrors.ErrPermissionDenied
+			}
+			perm = *found
+		}
+
+		seen[name] = true
+		permissions = append(permissions, perm)
+	}
+
+	return permissions, nil
+}
+
+// toAPIKeyResponse converts API key entity to response DTO
+func (uc *APIKeyUseCase) toAPIKeyResponse(key *entity.APIKey) *dto.APIKeyResponse {
+	permissions := make([]string, len(key.Permissions))
+	for i, perm := range key.Permissions {
+		permissions[i] = perm.Name
+	}
+
+	resp := &dto.APIKeyResponse{
+		ID:          key.ID.String(),
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: permissions,
+		CreatedAt:   key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.ExpiresAt != nil {
+		resp.ExpiresAt = key.ExpiresAt.Format(time.RFC3339)
+	}
+	if key.LastUsedAt != nil {
+		resp.LastUsedAt = key.LastUsedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
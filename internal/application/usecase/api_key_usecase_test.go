@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func TestAPIKeyUseCase_CreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+	user := &entity.User{
+		ID:       userID,
+		Email:    "owner@example.com",
+		IsActive: true,
+		Roles: []entity.Role{
+			{
+				Name: "operator",
+				Permissions: []entity.Permission{
+					{ID: uuid.New(), Name: "user:read"},
+					{ID: uuid.New(), Name: "user:update"},
+				},
+			},
+		},
+	}
+	wildcardUser := &entity.User{
+		ID:       userID,
+		Email:    "owner@example.com",
+		IsActive: true,
+		Roles: []entity.Role{
+			{Name: "user-admin", Permissions: []entity.Permission{{ID: uuid.New(), Name: "user:*"}}},
+		},
+	}
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		req           dto.CreateAPIKeyRequest
+		setupMocks    func(*mocks.MockAPIKeyRepository, *mocks.MockUserRepository, *mocks.MockPermissionRepository)
+		expectedError error
+	}{
+		{
+			name: "success - scoped to held permissions",
+			req:  dto.CreateAPIKeyRequest{Name: "ci", Permissions: []string{"user:read", "user:read"}},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository, userRepo *mocks.MockUserRepository, permissionRepo *mocks.MockPermissionRepository) {
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+				apiKeyRepo.On("Create", mock.Anything, mock.MatchedBy(func(key *entity.APIKey) bool {
+					return key.UserID == userID &&
+						len(key.Permissions) == 1 &&
+						key.Permissions[0].Name == "user:read" &&
+						strings.HasPrefix(key.Prefix, entity.APIKeyPrefix) &&
+						len(key.KeyHash) == 64
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "success - permission held through a wildcard",
+			req:  dto.CreateAPIKeyRequest{Name: "ci", Permissions: []string{"user:read"}},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository, userRepo *mocks.MockUserRepository, permissionRepo *mocks.MockPermissionRepository) {
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(wildcardUser, nil)
+				permissionRepo.On("GetByName", mock.Anything, "user:read").Return(&entity.Permission{ID: uuid.New(), Name: "user:read"}, nil)
+				apiKeyRepo.On("Create", mock.Anything, mock.MatchedBy(func(key *entity.APIKey) bool {
+					return len(key.Permissions) == 1 && key.Permissions[0].Name == "user:read"
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - wildcard broader than held permissions",
+			req:  dto.CreateAPIKeyRequest{Name: "ci", Permissions: []string{"user:*"}},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository, userRepo *mocks.MockUserRepository, permissionRepo *mocks.MockPermissionRepository) {
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+			},
+			expectedError: domainErrors.ErrPermissionDenied,
+		},
+		{
+			name: "error - permission not held",
+			req:  dto.CreateAPIKeyRequest{Name: "ci", Permissions: []string{"role:delete"}},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository, userRepo *mocks.MockUserRepository, permissionRepo *mocks.MockPermissionRepository) {
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+			},
+			expectedError: domainErrors.ErrPermissionDenied,
+		},
+		{
+			name: "error - expiry in the past",
+			req:  dto.CreateAPIKeyRequest{Name: "ci", ExpiresAt: &past},
+			setupMocks: func(apiKeyRepo *mocks.MockAPIKeyRepository, userRepo *mocks.MockUserRepository, permissionRepo *mocks.MockPermissionRepository) {
+			},
+			expectedError: domainErrors.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := new(mocks.MockAPIKeyRepository)
+			userRepo := new(mocks.MockUserRepository)
+			permissionRepo := new(mocks.MockPermissionRepository)
+			tt.setupMocks(apiKeyRepo, userRepo, permissionRepo)
+
+			uc := NewAPIKeyUseCase(apiKeyRepo, userRepo, permissionRepo, &noopAuditLogger{})
+			resp, err := uc.CreateAPIKey(context.Background(), userID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, strings.HasPrefix(resp.Key, resp.Prefix))
+				assert.Equal(t, []string{"user:read"}, resp.Permissions)
+			}
+
+			apiKeyRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			permissionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyUseCase_OwnerScoping(t *testing.T) {
+	ownerID := uuid.New()
+	key := &entity.APIKey{ID: uuid.New(), UserID: ownerID, Name: "ci", Prefix: "pat_abcdefgh"}
+
+	apiKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyRepo.On("GetByID", mock.Anything, key.ID).Return(key, nil)
+	apiKeyRepo.On("Delete", mock.Anything, key.ID).Return(nil).Once()
+
+	uc := NewAPIKeyUseCase(apiKeyRepo, new(mocks.MockUserRepository), new(mocks.MockPermissionRepository), &noopAuditLogger{})
+
+	// Another user's key looks like a missing key
+	_, err := uc.GetAPIKey(context.Background(), uuid.New(), key.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+	err = uc.DeleteAPIKey(context.Background(), uuid.New(), key.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrAPIKeyNotFound)
+
+	assert.NoError(t, uc.DeleteAPIKey(context.Background(), ownerID, key.ID))
+	apiKeyRepo.AssertExpectations(t)
+}
@@ -6,27 +6,31 @@ import (
 	"time"
 
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
 // AuditLogUseCase handles read-only audit log operations
 type AuditLogUseCase struct {
-	repo repository.AuditLogRepository
+	repo    repository.AuditLogRepository
+	cursors *appService.CursorCodec
 }
 
 // NewAuditLogUseCase creates a new audit log use case
-func NewAuditLogUseCase(repo repository.AuditLogRepository) *AuditLogUseCase {
-	return &AuditLogUseCase{repo: repo}
+func NewAuditLogUseCase(repo repository.AuditLogRepository, cursors *appService.CursorCodec) *AuditLogUseCase {
+	return &AuditLogUseCase{repo: repo, cursors: cursors}
 }
 
 // ListAuditLogs retrieves a paginated list of audit logs
-func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int, resource, action, actorEmail string) (*dto.ListAuditLogsResponse, error) {
+func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int, resource, action, actorEmail, dormitoryID string) (*dto.ListAuditLogsResponse, error) {
 	filter := repository.AuditLogFilter{
-		Page:       page,
-		PageSize:   pageSize,
-		Resource:   resource,
-		Action:     action,
-		ActorEmail: actorEmail,
+		Page:        page,
+		PageSize:    pageSize,
+		Resource:    resource,
+		Action:      action,
+		ActorEmail:  actorEmail,
+		DormitoryID: dormitoryID,
 	}
 
 	logs, total, err := uc.repo.List(ctx, filter)
@@ -36,32 +40,7 @@ func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int
 
 	items := make([]dto.AuditLogResponse, 0, len(logs))
 	for _, l := range logs {
-		var actorIDStr string
-		if l.ActorID != nil {
-			actorIDStr = l.ActorID.String()
-		}
-
-		var roles []string
-		if l.ActorRoles != "" {
-			_ = json.Unmarshal([]byte(l.ActorRoles), &roles)
-		}
-
-		items = append(items, dto.AuditLogResponse{
-			ID:            l.ID.String(),
-			ActorID:       actorIDStr,
-			ActorEmail:    l.ActorEmail,
-			ActorRoles:    roles,
-			Action:        l.Action,
-			Resource:      l.Resource,
-			TargetID:      l.TargetID,
-			RequestPath:   l.RequestPath,
-			RequestMethod: l.RequestMethod,
-			StatusCode:    l.StatusCode,
-			IPAddress:     l.IPAddress,
-			UserAgent:     l.UserAgent,
-			Metadata:      l.Metadata,
-			CreatedAt:     l.CreatedAt.Format(time.RFC3339),
-		})
+		items = append(items, toAuditLogResponse(l))
 	}
 
 	totalPages := int(total) / pageSize
@@ -77,3 +56,75 @@ func (uc *AuditLogUseCase) ListAuditLogs(ctx context.Context, page, pageSize int
 		TotalPages: totalPages,
 	}, nil
 }
+
+// ListAuditLogsByCursor retrieves a cursor-paginated list of audit logs, newest first
+func (uc *AuditLogUseCase) ListAuditLogsByCursor(ctx context.Context, query dto.CursorQuery, resource, action, actorEmail, dormitoryID string) (*dto.CursorAuditLogsResponse, error) {
+	after, err := decodeKeyset(uc.cursors, cursorScopeAuditLogs, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := normalizeCursorLimit(query.Limit)
+	filter := repository.AuditLogFilter{
+		Resource:    resource,
+		Action:      action,
+		ActorEmail:  actorEmail,
+		DormitoryID: dormitoryID,
+	}
+
+	logs, total, err := uc.repo.ListAfter(ctx, filter, repository.KeysetPage{After: after, Limit: limit + 1, WithCount: query.WithCount})
+	if err != nil {
+		return nil, err
+	}
+
+	var next interface{}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[limit-1]
+		next = repository.Keyset{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeAuditLogs, query, limit, next, total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		items = append(items, toAuditLogResponse(l))
+	}
+
+	return &dto.CursorAuditLogsResponse{
+		Logs:             items,
+		CursorPagination: pagination,
+	}, nil
+}
+
+func toAuditLogResponse(l *entity.AuditLog) dto.AuditLogResponse {
+	var actorIDStr string
+	if l.ActorID != nil {
+		actorIDStr = l.ActorID.String()
+	}
+
+	var roles []string
+	if l.ActorRoles != "" {
+		_ = json.Unmarshal([]byte(l.ActorRoles), &roles)
+	}
+
+	return dto.AuditLogResponse{
+		ID:            l.ID.String(),
+		ActorID:       actorIDStr,
+		ActorEmail:    l.ActorEmail,
+		ActorRoles:    roles,
+		Action:        l.Action,
+		Resource:      l.Resource,
+		TargetID:      l.TargetID,
+		RequestPath:   l.RequestPath,
+		RequestMethod: l.RequestMethod,
+		StatusCode:    l.StatusCode,
+		IPAddress:     l.IPAddress,
+		UserAgent:     l.UserAgent,
+		Metadata:      l.Metadata,
+		CreatedAt:     l.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,12 +2,16 @@ package usecase
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
@@ -34,6 +38,9 @@ func (r *inMemoryAuditLogRepo) List(ctx context.Context, filter repository.Audit
 		if filter.ActorEmail != "" && l.ActorEmail != filter.ActorEmail {
 			continue
 		}
+		if filter.DormitoryID != "" && (l.Resource != "dormitory" || l.TargetID != filter.DormitoryID) {
+			continue
+		}
 		filtered = append(filtered, l)
 	}
 
@@ -56,9 +63,41 @@ func (r *inMemoryAuditLogRepo) List(ctx context.Context, filter repository.Audit
 	return filtered[offset:end], total, nil
 }
 
+func (r *inMemoryAuditLogRepo) ListAfter(ctx context.Context, filter repository.AuditLogFilter, page repository.KeysetPage) ([]*entity.AuditLog, int64, error) {
+	matching, total, err := r.List(ctx, repository.AuditLogFilter{Resource: filter.Resource, Action: filter.Action, ActorEmail: filter.ActorEmail, DormitoryID: filter.DormitoryID, PageSize: len(r.logs) + 1})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Newest first, ties broken by the higher ID
+	sort.Slice(matching, func(i, j int) bool {
+		if !matching[i].CreatedAt.Equal(matching[j].CreatedAt) {
+			return matching[i].CreatedAt.After(matching[j].CreatedAt)
+		}
+		return matching[i].ID.String() > matching[j].ID.String()
+	})
+
+	logs := make([]*entity.AuditLog, 0, page.Limit)
+	for _, l := range matching {
+		if page.After != nil && !(l.CreatedAt.Before(page.After.CreatedAt) ||
+			(l.CreatedAt.Equal(page.After.CreatedAt) && l.ID.String() < page.After.ID.String())) {
+			continue
+		}
+		if len(logs) == page.Limit {
+			break
+		}
+		logs = append(logs, l)
+	}
+
+	if !page.WithCount {
+		total = 0
+	}
+	return logs, total, nil
+}
+
 func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	repo := &inMemoryAuditLogRepo{}
-	uc := NewAuditLogUseCase(repo)
+	uc := NewAuditLogUseCase(repo, testCursors)
 
 	now := time.Now()
 	// seed some logs
@@ -86,7 +125,7 @@ func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	resp, err := uc.ListAuditLogs(ctx, 1, 10, "user", "user:create", "admin@example.com")
+	resp, err := uc.ListAuditLogs(ctx, 1, 10, "user", "user:create", "admin@example.com", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, int64(1), resp.Total)
@@ -97,3 +136,69 @@ func TestAuditLogUseCase_ListAuditLogs(t *testing.T) {
 	assert.Equal(t, "user:create", logResp.Action)
 	assert.Equal(t, "admin@example.com", logResp.ActorEmail)
 }
+
+func TestAuditLogUseCase_ListAuditLogsByCursor(t *testing.T) {
+	repo := &inMemoryAuditLogRepo{}
+	uc := NewAuditLogUseCase(repo, testCursors)
+
+	// Two logs share a timestamp, so the ID has to break the tie
+	now := time.Now()
+	for _, age := range []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, 3 * time.Minute} {
+		repo.logs = append(repo.logs, &entity.AuditLog{
+			ID:        uuid.New(),
+			Action:    "user:create",
+			Resource:  "user",
+			CreatedAt: now.Add(-age),
+		})
+	}
+	repo.logs = append(repo.logs, &entity.AuditLog{ID: uuid.New(), Action: "role:create", Resource: "role", CreatedAt: now})
+
+	ctx := context.Background()
+	var seen []string
+	query := dto.CursorQuery{Limit: 2, WithCount: true}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "cursor pagination did not terminate")
+
+		resp, err := uc.ListAuditLogsByCursor(ctx, query, "user", "", "", "")
+		require.NoError(t, err)
+		require.NotNil(t, resp.Total)
+		assert.Equal(t, int64(5), *resp.Total)
+		for _, l := range resp.Logs {
+			seen = append(seen, l.ID)
+		}
+
+		if resp.NextCursor == "" {
+			assert.Len(t, resp.Logs, 1)
+			break
+		}
+		assert.Len(t, resp.Logs, 2)
+		query.Cursor = resp.NextCursor
+	}
+
+	// Every matching log exactly once, newest first
+	expected, _, _ := repo.ListAfter(ctx, repository.AuditLogFilter{Resource: "user"}, repository.KeysetPage{Limit: 10})
+	require.Len(t, seen, 5)
+	for i, l := range expected {
+		assert.Equal(t, l.ID.String(), seen[i])
+	}
+
+	// The count is only computed on request
+	resp, err := uc.ListAuditLogsByCursor(ctx, dto.CursorQuery{Limit: 10}, "user", "", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, resp.Total)
+	assert.Empty(t, resp.NextCursor)
+}
+
+func TestAuditLogUseCase_ListAuditLogsByCursor_InvalidCursor(t *testing.T) {
+	uc := NewAuditLogUseCase(&inMemoryAuditLogRepo{}, testCursors)
+	ctx := context.Background()
+
+	_, err := uc.ListAuditLogsByCursor(ctx, dto.CursorQuery{Cursor: "forged"}, "", "", "", "")
+	assert.Equal(t, domainErrors.ErrInvalidCursor, err)
+
+	// A cursor issued by another listing is rejected
+	villageCursor, err := testCursors.Encode(cursorScopeVillages, idCursor{ID: 10})
+	require.NoError(t, err)
+	_, err = uc.ListAuditLogsByCursor(ctx, dto.CursorQuery{Cursor: villageCursor}, "", "", "", "")
+	assert.Equal(t, domainErrors.ErrInvalidCursor, err)
+}
@@ -2,38 +2,113 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
+// maxUserAgentLength is the longest user agent stored on a session
+const maxUserAgentLength = 512
+
+// AuthOptions configures optional authentication behaviour
+type AuthOptions struct {
+	// RequireEmailVerification blocks login until the user has verified their email
+	RequireEmailVerification  bool
+	PasswordResetTokenTTL     time.Duration
+	EmailVerificationTokenTTL time.Duration
+	// AppBaseURL is the frontend URL used to build links in emails
+	AppBaseURL string
+}
+
+// DefaultAuthOptions returns the default authentication options
+func DefaultAuthOptions() AuthOptions {
+	return AuthOptions{
+		RequireEmailVerification:  false,
+		PasswordResetTokenTTL:     time.Hour,
+		EmailVerificationTokenTTL: 48 * time.Hour,
+		AppBaseURL:                "http://localhost:3000",
+	}
+}
+
 // AuthUseCase handles authentication use cases
 type AuthUseCase struct {
-	userRepo    repository.UserRepository
-	tokenService service.TokenService
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	sessionRepo      repository.SessionRepository
+	userTokenRepo    repository.UserTokenRepository
+	mfaRepo          repository.MFARepository
+	tokenService     service.TokenService
+	tokenDenylist    service.TokenDenylist
+	otpService       service.OTPService
+	mailer           service.Mailer
+	auditLogger      appService.AuditLogger
+	loginThrottle    appService.LoginThrottle
+	txManager        repository.TransactionManager
+	options          AuthOptions
 }
 
 // NewAuthUseCase creates a new auth use case
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	sessionRepo repository.SessionRepository,
+	userTokenRepo repository.UserTokenRepository,
+	mfaRepo repository.MFARepository,
 	tokenService service.TokenService,
+	tokenDenylist service.TokenDenylist,
+	otpService service.OTPService,
+	mailer service.Mailer,
+	auditLogger appService.AuditLogger,
+	loginThrottle appService.LoginThrottle,
+	txManager repository.TransactionManager,
+	options AuthOptions,
 ) *AuthUseCase {
 	return &AuthUseCase{
-		userRepo:    userRepo,
-		tokenService: tokenService,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessionRepo:      sessionRepo,
+		userTokenRepo:    userTokenRepo,
+		mfaRepo:          mfaRepo,
+		tokenService:     tokenService,
+		tokenDenylist:    tokenDenylist,
+		otpService:       otpService,
+		mailer:           mailer,
+		auditLogger:      auditLogger,
+		loginThrottle:    loginThrottle,
+		txManager:        txManager,
+		options:          options,
 	}
 }
 
-// Register handles user registration
+// Register handles user registration.
+// When email verification is required, the user gets no session until the email is verified.
 func (uc *AuthUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error) {
+	// Refuse while the client is throttled
+	if err := uc.loginThrottle.Check(ctx, ""); err != nil {
+		return nil, throttleError(err)
+	}
+
 	// Check if user already exists
 	existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
+		// Probing for registered emails counts against the client IP
+		if _, err := uc.loginThrottle.RecordFailure(ctx, ""); err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
 		return nil, domainErrors.ErrUserAlreadyExists
 	}
 
@@ -58,6 +133,11 @@ func (uc *AuthUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	// Send verification email (best-effort, the user can ask for it again)
+	if err := uc.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("Sending verification email to user %s failed: %v", user.ID, err)
+	}
+
 	// Get user with roles for token generation
 	userWithRoles, err := uc.userRepo.GetWithRoles(ctx, user.ID)
 	if err != nil {
@@ -70,40 +150,40 @@ func (uc *AuthUseCase) Register(ctx context.Context, req dto.RegisterRequest) (*
 		roles = append(roles, role.Name)
 	}
 
-	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Email, roles)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
-
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
+	// No session until the email is verified (when required)
+	if uc.options.RequireEmailVerification {
+		return &dto.AuthResponse{
+			User: &dto.UserDTO{
+				ID:    user.ID.String(),
+				Email: user.Email,
+				Name:  user.Name,
+				Roles: roles,
+			},
+			EmailVerificationRequired: true,
+		}, nil
 	}
 
-	return &dto.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
-		User: dto.UserDTO{
-			ID:    user.ID.String(),
-			Email: user.Email,
-			Name:  user.Name,
-			Roles: roles,
-		},
-	}, nil
+	return uc.startSession(ctx, user, roles)
 }
 
-// Login handles user login
+// Login handles user login.
+// Users with two-factor authentication (enrolled, or required by one of their roles)
+// only receive an mfa_token here; tokens are issued by VerifyMFA.
 func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error) {
+	// Refuse while the account or client is locked out
+	if err := uc.loginThrottle.Check(ctx, req.Email); err != nil {
+		return nil, throttleError(err)
+	}
+
 	// Get user by email
 	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, domainErrors.ErrInvalidCredentials
+		return nil, uc.loginFailed(ctx, req.Email)
 	}
 
 	// Check if user exists
 	if user == nil {
-		return nil, domainErrors.ErrInvalidCredentials
+		return nil, uc.loginFailed(ctx, req.Email)
 	}
 
 	// Check if user is active
@@ -113,7 +193,19 @@ func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.Au
 
 	// Verify password
 	if !user.CheckPassword(req.Password) {
-		return nil, domainErrors.ErrInvalidCredentials
+		return nil, uc.loginFailed(ctx, req.Email)
+	}
+
+	return uc.completeLogin(ctx, user)
+}
+
+// completeLogin finishes a login after the first factor succeeded: it either asks
+// for the second factor or clears failed attempts and issues tokens.
+// Every login path goes through it, so it also enforces email verification.
+func (uc *AuthUseCase) completeLogin(ctx context.Context, user *entity.User) (*dto.AuthResponse, error) {
+	// Check if email is verified (when required)
+	if uc.options.RequireEmailVerification && !user.IsEmailVerified() {
+		return nil, domainErrors.ErrEmailNotVerified
 	}
 
 	// Get user with roles
@@ -122,36 +214,41 @@ func (uc *AuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.Au
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	// Generate tokens
-	roles := make([]string, 0)
-	for _, role := range userWithRoles.Roles {
-		roles = append(roles, role.Name)
-	}
-
-	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Email, roles)
+	// Require the second factor before issuing tokens
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
+	mfaEnabled := mfa != nil && mfa.IsEnabled()
+	if mfaEnabled || userWithRoles.RequiresMFA() {
+		mfaToken, err := uc.tokenService.GenerateMFAToken(user.ID)
+		if err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		return &dto.AuthResponse{
+			MFARequired:           true,
+			MFAToken:              mfaToken,
+			MFAEnrollmentRequired: !mfaEnabled,
+		}, nil
+	}
 
-	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
-	if err != nil {
+	// Login succeeded, clear failed attempts
+	if err := uc.loginThrottle.Reset(ctx, user.Email); err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	return &dto.AuthResponse{
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
-		User: dto.UserDTO{
-			ID:    user.ID.String(),
-			Email: user.Email,
-			Name:  user.Name,
-			Roles: roles,
-		},
-	}, nil
+	// Generate tokens
+	roles := make([]string, 0)
+	for _, role := range userWithRoles.Roles {
+		roles = append(roles, role.Name)
+	}
+
+	return uc.startSession(ctx, user, roles)
 }
 
-// RefreshToken handles token refresh
+// RefreshToken handles token refresh.
+// The presented refresh token is rotated: it is revoked and replaced by a new one
+// in the same family. Presenting an already rotated token revokes the whole family.
 func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
 	// Validate refresh token
 	claims, err := uc.tokenService.ValidateToken(req.RefreshToken)
@@ -159,6 +256,28 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenReq
 		return nil, domainErrors.ErrInvalidToken
 	}
 
+	// Look up the stored token
+	storedToken, err := uc.refreshTokenRepo.GetByTokenHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return nil, domainErrors.ErrTokenNotFound
+	}
+
+	if storedToken.UserID != claims.UserID {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	// Reuse of a rotated token: assume it was stolen and revoke the whole family
+	if storedToken.IsRevoked() {
+		if err := uc.refreshTokenRepo.RevokeFamily(ctx, storedToken.FamilyID); err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		return nil, domainErrors.ErrRefreshTokenReused
+	}
+
+	if storedToken.IsExpired(time.Now()) {
+		return nil, domainErrors.ErrTokenExpired
+	}
+
 	// Get user
 	user, err := uc.userRepo.GetWithRoles(ctx, claims.UserID)
 	if err != nil {
@@ -170,28 +289,327 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenReq
 		return nil, domainErrors.ErrUserInactive
 	}
 
-	// Generate new access token
+	// Check if email is verified (when required)
+	if uc.options.RequireEmailVerification && !user.IsEmailVerified() {
+		return nil, domainErrors.ErrEmailNotVerified
+	}
+
 	roles := make([]string, 0)
 	for _, role := range user.Roles {
 		roles = append(roles, role.Name)
 	}
 
+	// Rotate: revoke the presented token and store its replacement in one
+	// transaction, so a failed issue does not leave the session without a token
+	newTokenID := uuid.New()
+	var resp *dto.AuthResponse
+	err = withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		if err := uc.refreshTokenRepo.Revoke(ctx, storedToken.ID, &newTokenID); err != nil {
+			if err == domainErrors.ErrTokenNotFound {
+				return err
+			}
+			return domainErrors.ErrInternalServer
+		}
+
+		var err error
+		resp, err = uc.issueTokens(ctx, user, roles, storedToken.FamilyID, newTokenID)
+		return err
+	})
+	if err == domainErrors.ErrTokenNotFound {
+		// Losing the race to a concurrent refresh counts as reuse. The family is
+		// revoked after the rollback so that the revocation is kept.
+		if err := uc.refreshTokenRepo.RevokeFamily(ctx, storedToken.FamilyID); err != nil {
+			return nil, domainErrors.ErrInternalServer
+		}
+		return nil, domainErrors.ErrRefreshTokenReused
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Record activity on the session (best-effort)
+	ipAddress, _ := ctx.Value(appService.CtxKeyIPAddress).(string)
+	_ = uc.sessionRepo.Touch(ctx, storedToken.FamilyID, ipAddress, time.Now())
+
+	return resp, nil
+}
+
+// Logout revokes the refresh token family the given token belongs to
+func (uc *AuthUseCase) Logout(ctx context.Context, req dto.LogoutRequest) error {
+	storedToken, err := uc.refreshTokenRepo.GetByTokenHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return domainErrors.ErrTokenNotFound
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, storedToken.FamilyID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	return nil
+}
+
+// LogoutAll revokes every refresh token of the user and the access token used for the request
+func (uc *AuthUseCase) LogoutAll(ctx context.Context, userID uuid.UUID, currentTokenID string) error {
+	if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	if currentTokenID != "" {
+		expiresAt := time.Now().Add(uc.tokenService.AccessTokenExpiry())
+		if err := uc.tokenDenylist.Revoke(ctx, currentTokenID, expiresAt); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+	}
+
+	return nil
+}
+
+// RevokeAccessToken denylists an access token by its jti so it is rejected immediately
+func (uc *AuthUseCase) RevokeAccessToken(ctx context.Context, req dto.RevokeTokenRequest) error {
+	// A token never outlives the access token expiry, so that is how long the entry is needed
+	expiresAt := time.Now().Add(uc.tokenService.AccessTokenExpiry())
+	if err := uc.tokenDenylist.Revoke(ctx, req.TokenID, expiresAt); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:revoke_token", req.TokenID, nil)
+
+	return nil
+}
+
+// ForgotPassword sends a password reset link to the user.
+// It succeeds for unknown emails too, so callers cannot probe which accounts exist.
+func (uc *AuthUseCase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil || user == nil || !user.IsActive {
+		return nil
+	}
+
+	// Only the most recent reset link stays valid
+	if err := uc.userTokenRepo.InvalidateForUser(ctx, user.ID, entity.UserTokenPurposePasswordReset); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	token, err := uc.createUserToken(ctx, user.ID, entity.UserTokenPurposePasswordReset, uc.options.PasswordResetTokenTTL)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", uc.options.AppBaseURL, url.QueryEscape(token))
+	message := service.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password. It expires in %s.\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+			user.Name, uc.options.PasswordResetTokenTTL, link,
+		),
+	}
+	// A delivery failure is not reported, it would reveal that the account exists
+	if err := uc.mailer.Send(ctx, message); err != nil {
+		log.Printf("Sending password reset email to user %s failed: %v", user.ID, err)
+		return nil
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:forgot_password", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password using a password reset token
+func (uc *AuthUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	userToken, err := uc.consumeUserToken(ctx, entity.UserTokenPurposePasswordReset, req.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userToken.UserID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	user.Password = req.NewPassword
+	if err := user.HashPassword(); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// The reset link was delivered to the mailbox, which also proves ownership
+	now := time.Now()
+	if !user.IsEmailVerified() {
+		user.EmailVerifiedAt = &now
+	}
+	user.UpdatedAt = now
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Sign out existing sessions
+	if err := uc.refreshTokenRepo.RevokeAllForUser(ctx, user.ID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:reset_password", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return nil
+}
+
+// VerifyEmail marks the user's email as verified using an email verification token
+func (uc *AuthUseCase) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	userToken, err := uc.consumeUserToken(ctx, entity.UserTokenPurposeEmailVerification, req.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userToken.UserID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:verify_email", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return nil
+}
+
+// ResendVerification sends a new email verification link to an unverified user.
+// Like ForgotPassword it succeeds for unknown or already verified emails.
+func (uc *AuthUseCase) ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error {
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil || user == nil || !user.IsActive || user.IsEmailVerified() {
+		return nil
+	}
+
+	// Only the most recent verification link stays valid
+	if err := uc.userTokenRepo.InvalidateForUser(ctx, user.ID, entity.UserTokenPurposeEmailVerification); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	if err := uc.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("Sending verification email to user %s failed: %v", user.ID, err)
+		return nil
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:resend_verification", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return nil
+}
+
+// JWKS returns the public keys that verify issued tokens
+func (uc *AuthUseCase) JWKS() service.JSONWebKeySet {
+	return uc.tokenService.JWKS()
+}
+
+// loginFailed records a failed login attempt and returns ErrInvalidCredentials
+func (uc *AuthUseCase) loginFailed(ctx context.Context, email string) error {
+	locked, err := uc.loginThrottle.RecordFailure(ctx, email)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:login_failed", "", map[string]string{
+		"email": email,
+	})
+	if locked {
+		_ = uc.auditLogger.Log(ctx, "auth", "auth:account_locked", "", map[string]string{
+			"email": email,
+		})
+	}
+
+	return domainErrors.ErrInvalidCredentials
+}
+
+// throttleError maps store failures from LoginThrottle.Check to ErrInternalServer
+func throttleError(err error) error {
+	var retryErr *domainErrors.RetryAfterError
+	if errors.As(err, &retryErr) {
+		return err
+	}
+	return domainErrors.ErrInternalServer
+}
+
+// startSession records a login session for the client and issues tokens in a new refresh token family
+func (uc *AuthUseCase) startSession(ctx context.Context, user *entity.User, roles []string) (*dto.AuthResponse, error) {
+	ipAddress, _ := ctx.Value(appService.CtxKeyIPAddress).(string)
+	userAgent, _ := ctx.Value(appService.CtxKeyUserAgent).(string)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	now := time.Now()
+	session := &entity.Session{
+		ID:         uuid.New(),
+		UserID:     user.ID,
+		FamilyID:   uuid.New(),
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := uc.sessionRepo.Create(ctx, session); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	return uc.issueTokens(ctx, user, roles, session.FamilyID, uuid.New())
+}
+
+// issueTokens generates an access and refresh token pair and stores the refresh token
+func (uc *AuthUseCase) issueTokens(
+	ctx context.Context,
+	user *entity.User,
+	roles []string,
+	familyID uuid.UUID,
+	tokenID uuid.UUID,
+) (*dto.AuthResponse, error) {
 	accessToken, err := uc.tokenService.GenerateAccessToken(user.ID, user.Email, roles)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	// Generate new refresh token
 	refreshToken, err := uc.tokenService.GenerateRefreshToken(user.ID)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
+	now := time.Now()
+	storedToken := &entity.RefreshToken{
+		ID:        tokenID,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: now.Add(uc.tokenService.RefreshTokenExpiry()),
+		CreatedAt: now,
+	}
+	if err := uc.refreshTokenRepo.Create(ctx, storedToken); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
 	return &dto.AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
-		ExpiresAt:    time.Now().Add(15 * time.Minute).Format(time.RFC3339),
-		User: dto.UserDTO{
+		ExpiresAt:    now.Add(15 * time.Minute).Format(time.RFC3339),
+		User: &dto.UserDTO{
 			ID:    user.ID.String(),
 			Email: user.Email,
 			Name:  user.Name,
@@ -199,3 +617,81 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshTokenReq
 		},
 	}, nil
 }
+
+// sendEmailVerification creates an email verification token and mails the link to the user
+func (uc *AuthUseCase) sendEmailVerification(ctx context.Context, user *entity.User) error {
+	token, err := uc.createUserToken(ctx, user.ID, entity.UserTokenPurposeEmailVerification, uc.options.EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", uc.options.AppBaseURL, url.QueryEscape(token))
+	return uc.mailer.Send(ctx, service.MailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease verify your email address using the link below. It expires in %s.\n\n%s\n",
+			user.Name, uc.options.EmailVerificationTokenTTL, link,
+		),
+	})
+}
+
+// createUserToken generates a single-use token, stores its hash and returns the plain token
+func (uc *AuthUseCase) createUserToken(ctx context.Context, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	token, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	userToken := &entity.UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := uc.userTokenRepo.Create(ctx, userToken); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeUserToken validates a single-use token and marks it as used
+func (uc *AuthUseCase) consumeUserToken(ctx context.Context, purpose, token string) (*entity.UserToken, error) {
+	userToken, err := uc.userTokenRepo.GetByTokenHash(ctx, purpose, hashToken(token))
+	if err != nil {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	if userToken.UsedAt != nil {
+		return nil, domainErrors.ErrInvalidToken
+	}
+	if !userToken.IsUsable(time.Now()) {
+		return nil, domainErrors.ErrTokenExpired
+	}
+
+	// Fails if a concurrent request used the token first
+	if err := uc.userTokenRepo.MarkUsed(ctx, userToken.ID); err != nil {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	return userToken, nil
+}
+
+// generateToken returns a random URL-safe token
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex-encoded SHA-256 hash of a token, used for storage lookups
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,355 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// recoveryCodeCount is the number of recovery codes generated on enrollment
+const recoveryCodeCount = 10
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// SetupMFA starts TOTP enrollment during login, for users whose role requires MFA
+// but who have not enrolled yet. The enrollment is completed by VerifyMFA.
+func (uc *AuthUseCase) SetupMFA(ctx context.Context, req dto.MFATokenRequest) (*dto.MFAEnrollResponse, error) {
+	claims, err := uc.validateMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	resp, err := uc.startMFAEnrollment(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	resp.MFAToken = req.MFAToken
+
+	return resp, nil
+}
+
+// VerifyMFA completes a two-step login with a TOTP or recovery code.
+// If the user is still enrolling, the TOTP code confirms the enrollment and
+// the recovery codes are returned together with the tokens.
+func (uc *AuthUseCase) VerifyMFA(ctx context.Context, req dto.MFAVerifyRequest) (*dto.AuthResponse, error) {
+	claims, err := uc.validateMFAToken(ctx, req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetWithRoles(ctx, claims.UserID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	if !user.IsActive {
+		return nil, domainErrors.ErrUserInactive
+	}
+
+	// Code guesses count towards the same lockout as password guesses
+	if err := uc.loginThrottle.Check(ctx, user.Email); err != nil {
+		return nil, throttleError(err)
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if mfa == nil {
+		return nil, domainErrors.ErrMFANotEnabled
+	}
+
+	var recoveryCodes []string
+	if mfa.IsEnabled() {
+		err = uc.verifyMFACode(ctx, mfa, req.Code)
+	} else {
+		recoveryCodes, err = uc.confirmMFAEnrollment(ctx, user, mfa, req.Code)
+	}
+	if err == domainErrors.ErrInvalidMFACode {
+		return nil, uc.mfaFailed(ctx, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Login succeeded, clear failed attempts
+	if err := uc.loginThrottle.Reset(ctx, user.Email); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// The mfa_token is single-use
+	expiresAt := time.Now().Add(uc.tokenService.MFATokenExpiry())
+	if err := uc.tokenDenylist.Revoke(ctx, claims.TokenID, expiresAt); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	roles := make([]string, 0)
+	for _, role := range user.Roles {
+		roles = append(roles, role.Name)
+	}
+
+	resp, err := uc.startSession(ctx, user, roles)
+	if err != nil {
+		return nil, err
+	}
+	resp.RecoveryCodes = recoveryCodes
+
+	return resp, nil
+}
+
+// EnrollMFA starts TOTP enrollment for the current user.
+// Calling it again before confirming replaces the pending secret.
+func (uc *AuthUseCase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*dto.MFAEnrollResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	return uc.startMFAEnrollment(ctx, user)
+}
+
+// ConfirmMFA enables MFA for the current user with a code from the authenticator app
+func (uc *AuthUseCase) ConfirmMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if mfa == nil {
+		return nil, domainErrors.ErrMFANotEnabled
+	}
+	if mfa.IsEnabled() {
+		return nil, domainErrors.ErrMFAAlreadyEnabled
+	}
+
+	recoveryCodes, err := uc.confirmMFAEnrollment(ctx, user, mfa, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// DisableMFA turns off MFA for the current user after checking a TOTP or recovery code.
+// It is refused while one of the user's roles requires MFA.
+func (uc *AuthUseCase) DisableMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) error {
+	user, err := uc.userRepo.GetWithRoles(ctx, userID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	if user.RequiresMFA() {
+		return domainErrors.ErrMFAMandatory
+	}
+
+	mfa, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	if mfa == nil || !mfa.IsEnabled() {
+		return domainErrors.ErrMFANotEnabled
+	}
+
+	if err := uc.verifyMFACode(ctx, mfa, req.Code); err != nil {
+		return err
+	}
+
+	if err := uc.mfaRepo.Delete(ctx, user.ID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:mfa_disable", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return nil
+}
+
+// mfaFailed records a failed second-factor attempt and returns ErrInvalidMFACode
+func (uc *AuthUseCase) mfaFailed(ctx context.Context, user *entity.User) error {
+	locked, err := uc.loginThrottle.RecordFailure(ctx, user.Email)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:mfa_failed", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+	if locked {
+		_ = uc.auditLogger.Log(ctx, "auth", "auth:account_locked", user.ID.String(), map[string]string{
+			"email": user.Email,
+		})
+	}
+
+	return domainErrors.ErrInvalidMFACode
+}
+
+// validateMFAToken checks that the token is an unused mfa_pending token
+func (uc *AuthUseCase) validateMFAToken(ctx context.Context, token string) (*service.TokenClaims, error) {
+	claims, err := uc.tokenService.ValidateToken(token)
+	if err != nil {
+		if err == domainErrors.ErrTokenExpired {
+			return nil, err
+		}
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	if claims.Type != service.TokenTypeMFAPending {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	revoked, err := uc.tokenDenylist.IsRevoked(ctx, claims.TokenID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if revoked {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// startMFAEnrollment stores a new unconfirmed TOTP secret for the user
+func (uc *AuthUseCase) startMFAEnrollment(ctx context.Context, user *entity.User) (*dto.MFAEnrollResponse, error) {
+	existing, err := uc.mfaRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if existing != nil && existing.IsEnabled() {
+		return nil, domainErrors.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := uc.otpService.GenerateSecret()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	now := time.Now()
+	mfa := &entity.UserMFA{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:mfa_enroll", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return &dto.MFAEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: uc.otpService.ProvisioningURI(secret, user.Email),
+	}, nil
+}
+
+// confirmMFAEnrollment enables a pending enrollment and returns fresh recovery codes
+func (uc *AuthUseCase) confirmMFAEnrollment(ctx context.Context, user *entity.User, mfa *entity.UserMFA, code string) ([]string, error) {
+	step, ok := uc.otpService.Validate(mfa.Secret, code, time.Now())
+	if !ok {
+		return nil, domainErrors.ErrInvalidMFACode
+	}
+
+	now := time.Now()
+	mfa.ConfirmedAt = &now
+	mfa.LastUsedStep = step
+	mfa.UpdatedAt = now
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	recoveryCodes, storedCodes, err := generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if err := uc.mfaRepo.ReplaceRecoveryCodes(ctx, user.ID, storedCodes); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:mfa_enable", user.ID.String(), map[string]string{
+		"email": user.Email,
+	})
+
+	return recoveryCodes, nil
+}
+
+// verifyMFACode accepts a TOTP code or an unused recovery code
+func (uc *AuthUseCase) verifyMFACode(ctx context.Context, mfa *entity.UserMFA, code string) error {
+	if step, ok := uc.otpService.Validate(mfa.Secret, code, time.Now()); ok {
+		// Each TOTP code is accepted only once
+		if step <= mfa.LastUsedStep {
+			return domainErrors.ErrInvalidMFACode
+		}
+		mfa.LastUsedStep = step
+		mfa.UpdatedAt = time.Now()
+		if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+		return nil
+	}
+
+	if err := uc.mfaRepo.UseRecoveryCode(ctx, mfa.UserID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		return domainErrors.ErrInvalidMFACode
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:mfa_recovery_code_used", mfa.UserID.String(), nil)
+
+	return nil
+}
+
+// generateRecoveryCodes returns the plain recovery codes and their hashed records
+func generateRecoveryCodes(userID uuid.UUID) ([]string, []entity.MFARecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	stored := make([]entity.MFARecoveryCode, 0, recoveryCodeCount)
+	now := time.Now()
+
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b)) // 8 characters
+		code := raw[:4] + "-" + raw[4:]
+
+		codes = append(codes, code)
+		stored = append(stored, entity.MFARecoveryCode{
+			ID:        uuid.New(),
+			UserID:    userID,
+			CodeHash:  hashToken(normalizeRecoveryCode(code)),
+			CreatedAt: now,
+		})
+	}
+
+	return codes, stored, nil
+}
+
+// normalizeRecoveryCode ignores case, dashes and spaces in user input
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
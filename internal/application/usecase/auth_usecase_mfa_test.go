@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+func TestAuthUseCase_VerifyMFA(t *testing.T) {
+	userID := uuid.New()
+	confirmedAt := time.Now().Add(-time.Hour)
+
+	mfaClaims := &service.TokenClaims{
+		TokenID: "mfa-jti",
+		Type:    service.TokenTypeMFAPending,
+		UserID:  userID,
+	}
+	user := &entity.User{
+		ID:       userID,
+		Email:    "user@example.com",
+		IsActive: true,
+		Roles:    []entity.Role{{ID: uuid.New(), Name: "user"}},
+	}
+
+	// expectTokens sets up the expectations for a completed login
+	expectTokens := func(m *authMocks) {
+		m.loginThrottle.On("Reset", mock.Anything, "user@example.com").Return(nil)
+		m.tokenService.On("MFATokenExpiry").Return(5 * time.Minute)
+		m.tokenDenylist.On("Revoke", mock.Anything, "mfa-jti", mock.Anything).Return(nil)
+		m.tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{"user"}).Return("access_token", nil)
+		m.tokenService.On("GenerateRefreshToken", userID).Return("refresh_token", nil)
+		m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+		m.sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.refreshTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	}
+
+	tests := []struct {
+		name              string
+		req               dto.MFAVerifyRequest
+		setupMocks        func(m *authMocks)
+		expectedError     error
+		expectedRecovered bool
+	}{
+		{
+			name: "success - valid TOTP code",
+			req:  dto.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "mfa_token").Return(mfaClaims, nil)
+				m.tokenDenylist.On("IsRevoked", mock.Anything, "mfa-jti").Return(false, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID:       userID,
+					Secret:       "SECRET",
+					ConfirmedAt:  &confirmedAt,
+					LastUsedStep: 100,
+				}, nil)
+				m.otpService.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(101), true)
+				m.mfaRepo.On("Save", mock.Anything, mock.MatchedBy(func(mfa *entity.UserMFA) bool {
+					return mfa.LastUsedStep == 101
+				})).Return(nil)
+				expectTokens(m)
+			},
+		},
+		{
+			name: "failure - replayed TOTP code",
+			req:  dto.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "mfa_token").Return(mfaClaims, nil)
+				m.tokenDenylist.On("IsRevoked", mock.Anything, "mfa-jti").Return(false, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID:       userID,
+					Secret:       "SECRET",
+					ConfirmedAt:  &confirmedAt,
+					LastUsedStep: 101,
+				}, nil)
+				m.otpService.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(101), true)
+				m.loginThrottle.On("RecordFailure", mock.Anything, "user@example.com").Return(false, nil)
+			},
+			expectedError: domainErrors.ErrInvalidMFACode,
+		},
+		{
+			name: "success - recovery code",
+			req:  dto.MFAVerifyRequest{MFAToken: "mfa_token", Code: "ABCD-EFGH"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "mfa_token").Return(mfaClaims, nil)
+				m.tokenDenylist.On("IsRevoked", mock.Anything, "mfa-jti").Return(false, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID:      userID,
+					Secret:      "SECRET",
+					ConfirmedAt: &confirmedAt,
+				}, nil)
+				m.otpService.On("Validate", "SECRET", "ABCD-EFGH", mock.Anything).Return(int64(0), false)
+				m.mfaRepo.On("UseRecoveryCode", mock.Anything, userID, hashToken("abcdefgh")).Return(nil)
+				expectTokens(m)
+			},
+		},
+		{
+			name: "success - confirms pending enrollment and returns recovery codes",
+			req:  dto.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "mfa_token").Return(mfaClaims, nil)
+				m.tokenDenylist.On("IsRevoked", mock.Anything, "mfa-jti").Return(false, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID: userID,
+					Secret: "SECRET",
+				}, nil)
+				m.otpService.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(101), true)
+				m.mfaRepo.On("Save", mock.Anything, mock.MatchedBy(func(mfa *entity.UserMFA) bool {
+					return mfa.IsEnabled()
+				})).Return(nil)
+				m.mfaRepo.On("ReplaceRecoveryCodes", mock.Anything, userID, mock.MatchedBy(func(codes []entity.MFARecoveryCode) bool {
+					return len(codes) == recoveryCodeCount
+				})).Return(nil)
+				expectTokens(m)
+			},
+			expectedRecovered: true,
+		},
+		{
+			name: "failure - access token cannot be used as mfa token",
+			req:  dto.MFAVerifyRequest{MFAToken: "access_token", Code: "123456"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "access_token").Return(&service.TokenClaims{
+					Type:   service.TokenTypeAccess,
+					UserID: userID,
+				}, nil)
+			},
+			expectedError: domainErrors.ErrInvalidToken,
+		},
+		{
+			name: "failure - mfa token already used",
+			req:  dto.MFAVerifyRequest{MFAToken: "mfa_token", Code: "123456"},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "mfa_token").Return(mfaClaims, nil)
+				m.tokenDenylist.On("IsRevoked", mock.Anything, "mfa-jti").Return(true, nil)
+			},
+			expectedError: domainErrors.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			tt.setupMocks(m)
+
+			authUseCase := m.newUseCase(DefaultAuthOptions())
+			resp, err := authUseCase.VerifyMFA(context.Background(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "access_token", resp.AccessToken)
+				assert.False(t, resp.MFARequired)
+				if tt.expectedRecovered {
+					assert.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+				} else {
+					assert.Empty(t, resp.RecoveryCodes)
+				}
+			}
+
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_EnrollMFA(t *testing.T) {
+	userID := uuid.New()
+	user := &entity.User{ID: userID, Email: "user@example.com"}
+
+	t.Run("success - returns provisioning URI", func(t *testing.T) {
+		m := newAuthMocks()
+		m.userRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(nil, nil)
+		m.otpService.On("GenerateSecret").Return("SECRET", nil)
+		m.mfaRepo.On("Save", mock.Anything, mock.MatchedBy(func(mfa *entity.UserMFA) bool {
+			return mfa.UserID == userID && mfa.Secret == "SECRET" && !mfa.IsEnabled()
+		})).Return(nil)
+		m.otpService.On("ProvisioningURI", "SECRET", "user@example.com").Return("otpauth://totp/x")
+
+		resp, err := m.newUseCase(DefaultAuthOptions()).EnrollMFA(context.Background(), userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SECRET", resp.Secret)
+		assert.Equal(t, "otpauth://totp/x", resp.ProvisioningURI)
+		m.assertExpectations(t)
+	})
+
+	t.Run("failure - already enabled", func(t *testing.T) {
+		m := newAuthMocks()
+		confirmedAt := time.Now()
+		m.userRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+			UserID:      userID,
+			ConfirmedAt: &confirmedAt,
+		}, nil)
+
+		resp, err := m.newUseCase(DefaultAuthOptions()).EnrollMFA(context.Background(), userID)
+
+		assert.Equal(t, domainErrors.ErrMFAAlreadyEnabled, err)
+		assert.Nil(t, resp)
+		m.assertExpectations(t)
+	})
+}
+
+func TestAuthUseCase_DisableMFA(t *testing.T) {
+	userID := uuid.New()
+	confirmedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(m *authMocks)
+		expectedError error
+	}{
+		{
+			name: "success - disables with valid code",
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID:      userID,
+					Secret:      "SECRET",
+					ConfirmedAt: &confirmedAt,
+				}, nil)
+				m.otpService.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(101), true)
+				m.mfaRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+				m.mfaRepo.On("Delete", mock.Anything, userID).Return(nil)
+			},
+		},
+		{
+			name: "failure - role requires MFA",
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{
+					ID:    userID,
+					Roles: []entity.Role{{Name: "admin", RequireMFA: true}},
+				}, nil)
+			},
+			expectedError: domainErrors.ErrMFAMandatory,
+		},
+		{
+			name: "failure - not enabled",
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(nil, nil)
+			},
+			expectedError: domainErrors.ErrMFANotEnabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			tt.setupMocks(m)
+
+			err := m.newUseCase(DefaultAuthOptions()).DisableMFA(context.Background(), userID, dto.MFACodeRequest{Code: "123456"})
+
+			assert.Equal(t, tt.expectedError, err)
+			m.assertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,17 +11,80 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
+// authMocks bundles the mocked dependencies of AuthUseCase
+type authMocks struct {
+	userRepo         *mocks.MockUserRepository
+	refreshTokenRepo *mocks.MockRefreshTokenRepository
+	sessionRepo      *mocks.MockSessionRepository
+	userTokenRepo    *mocks.MockUserTokenRepository
+	mfaRepo          *mocks.MockMFARepository
+	tokenService     *mocks.MockTokenService
+	tokenDenylist    *mocks.MockTokenDenylist
+	otpService       *mocks.MockOTPService
+	mailer           *mocks.MockMailer
+	loginThrottle    *mocks.MockLoginThrottle
+	txManager        *inlineTxManager
+}
+
+func newAuthMocks() *authMocks {
+	return &authMocks{
+		userRepo:         new(mocks.MockUserRepository),
+		refreshTokenRepo: new(mocks.MockRefreshTokenRepository),
+		sessionRepo:      new(mocks.MockSessionRepository),
+		userTokenRepo:    new(mocks.MockUserTokenRepository),
+		mfaRepo:          new(mocks.MockMFARepository),
+		tokenService:     new(mocks.MockTokenService),
+		tokenDenylist:    new(mocks.MockTokenDenylist),
+		otpService:       new(mocks.MockOTPService),
+		mailer:           new(mocks.MockMailer),
+		loginThrottle:    new(mocks.MockLoginThrottle),
+		txManager:        &inlineTxManager{},
+	}
+}
+
+func (m *authMocks) newUseCase(options AuthOptions) *AuthUseCase {
+	return NewAuthUseCase(
+		m.userRepo,
+		m.refreshTokenRepo,
+		m.sessionRepo,
+		m.userTokenRepo,
+		m.mfaRepo,
+		m.tokenService,
+		m.tokenDenylist,
+		m.otpService,
+		m.mailer,
+		&noopAuditLogger{},
+		m.loginThrottle,
+		m.txManager,
+		options,
+	)
+}
+
+func (m *authMocks) assertExpectations(t *testing.T) {
+	m.userRepo.AssertExpectations(t)
+	m.refreshTokenRepo.AssertExpectations(t)
+	m.sessionRepo.AssertExpectations(t)
+	m.userTokenRepo.AssertExpectations(t)
+	m.mfaRepo.AssertExpectations(t)
+	m.tokenService.AssertExpectations(t)
+	m.tokenDenylist.AssertExpectations(t)
+	m.otpService.AssertExpectations(t)
+	m.mailer.AssertExpectations(t)
+	m.loginThrottle.AssertExpectations(t)
+}
+
 func TestAuthUseCase_Register(t *testing.T) {
 	tests := []struct {
 		name          string
 		req           dto.RegisterRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
+		setupMocks    func(m *authMocks)
 		expectedError error
 	}{
 		{
@@ -30,17 +94,27 @@ func TestAuthUseCase_Register(t *testing.T) {
 				Password: "password123",
 				Name:     "New User",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(m *authMocks) {
+				m.loginThrottle.On("Check", mock.Anything, "").Return(nil)
+
 				// User doesn't exist
-				userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
+				m.userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
 
 				// Create user
-				userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+				m.userRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
 					return u.Email == "newuser@example.com" && u.Name == "New User"
 				})).Return(nil)
 
+				// Verification email
+				m.userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(ut *entity.UserToken) bool {
+					return ut.Purpose == entity.UserTokenPurposeEmailVerification
+				})).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.MatchedBy(func(msg service.MailMessage) bool {
+					return msg.To == "newuser@example.com"
+				})).Return(nil)
+
 				// Get user with roles (user ID akan di-generate di dalam use case)
-				userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{
+				m.userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{
 					ID:       uuid.New(),
 					Email:    "newuser@example.com",
 					Name:     "New User",
@@ -48,9 +122,45 @@ func TestAuthUseCase_Register(t *testing.T) {
 					Roles:    []entity.Role{},
 				}, nil)
 
+				m.sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
 				// Generate tokens (tidak mengikat ke UUID tertentu)
-				tokenService.On("GenerateAccessToken", mock.Anything, "newuser@example.com", []string{}).Return("access_token", nil)
-				tokenService.On("GenerateRefreshToken", mock.Anything).Return("refresh_token", nil)
+				m.tokenService.On("GenerateAccessToken", mock.Anything, "newuser@example.com", []string{}).Return("access_token", nil)
+				m.tokenService.On("GenerateRefreshToken", mock.Anything).Return("refresh_token", nil)
+				m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				m.refreshTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					return rt.TokenHash == hashToken("refresh_token")
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "success - verification email failure does not fail registration",
+			req: dto.RegisterRequest{
+				Email:    "newuser@example.com",
+				Password: "password123",
+				Name:     "New User",
+			},
+			setupMocks: func(m *authMocks) {
+				m.loginThrottle.On("Check", mock.Anything, "").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
+				m.userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+				// The mail server is down; the user can ask for a new link later
+				m.userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp unavailable"))
+
+				m.userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{
+					ID:       uuid.New(),
+					Email:    "newuser@example.com",
+					Name:     "New User",
+					IsActive: true,
+				}, nil)
+				m.sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.tokenService.On("GenerateAccessToken", mock.Anything, "newuser@example.com", []string{}).Return("access_token", nil)
+				m.tokenService.On("GenerateRefreshToken", mock.Anything).Return("refresh_token", nil)
+				m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				m.refreshTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -61,11 +171,13 @@ func TestAuthUseCase_Register(t *testing.T) {
 				Password: "password123",
 				Name:     "Existing User",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				userRepo.On("GetByEmail", mock.Anything, "existing@example.com").Return(&entity.User{
+			setupMocks: func(m *authMocks) {
+				m.loginThrottle.On("Check", mock.Anything, "").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "existing@example.com").Return(&entity.User{
 					ID:    uuid.New(),
 					Email: "existing@example.com",
 				}, nil)
+				m.loginThrottle.On("RecordFailure", mock.Anything, "").Return(false, nil)
 			},
 			expectedError: domainErrors.ErrUserAlreadyExists,
 		},
@@ -73,11 +185,10 @@ func TestAuthUseCase_Register(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userRepo := new(mocks.MockUserRepository)
-			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			m := newAuthMocks()
+			tt.setupMocks(m)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
+			authUseCase := m.newUseCase(DefaultAuthOptions())
 			resp, err := authUseCase.Register(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -92,8 +203,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 				assert.Equal(t, tt.req.Email, resp.User.Email)
 			}
 
-			userRepo.AssertExpectations(t)
-			tokenService.AssertExpectations(t)
+			m.assertExpectations(t)
 		})
 	}
 }
@@ -112,11 +222,15 @@ func TestAuthUseCase_Login(t *testing.T) {
 	require.NoError(t, testUser.HashPassword())
 	hashedPassword := testUser.Password
 
+	lockedErr := &domainErrors.RetryAfterError{Err: domainErrors.ErrAccountLocked, RetryAfter: time.Minute}
+
 	tests := []struct {
-		name          string
-		req           dto.LoginRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
-		expectedError error
+		name               string
+		req                dto.LoginRequest
+		setupMocks         func(m *authMocks)
+		expectedError      error
+		expectedMFA        bool
+		expectedEnrollment bool
 	}{
 		{
 			name: "success - login with correct credentials",
@@ -124,7 +238,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Email:    "user@example.com",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(m *authMocks) {
 				user := &entity.User{
 					ID:       userID,
 					Email:    "user@example.com",
@@ -132,7 +246,8 @@ func TestAuthUseCase_Login(t *testing.T) {
 					Name:     "Test User",
 					IsActive: true,
 				}
-				userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
 
 				userWithRoles := &entity.User{
 					ID:       userID,
@@ -142,10 +257,23 @@ func TestAuthUseCase_Login(t *testing.T) {
 					IsActive: true,
 					Roles:    []entity.Role{{ID: uuid.New(), Name: "user"}},
 				}
-				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(nil, nil)
+				m.loginThrottle.On("Reset", mock.Anything, "user@example.com").Return(nil)
 
-				tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{"user"}).Return("access_token", nil)
-				tokenService.On("GenerateRefreshToken", userID).Return("refresh_token", nil)
+				// The login starts a session owning a new refresh token family
+				var sessionFamilyID uuid.UUID
+				m.sessionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *entity.Session) bool {
+					sessionFamilyID = s.FamilyID
+					return s.UserID == userID && s.IPAddress == "203.0.113.7" && s.UserAgent == "test-agent"
+				})).Return(nil)
+
+				m.tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{"user"}).Return("access_token", nil)
+				m.tokenService.On("GenerateRefreshToken", userID).Return("refresh_token", nil)
+				m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				m.refreshTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					return rt.UserID == userID && rt.TokenHash == hashToken("refresh_token") && rt.FamilyID == sessionFamilyID
+				})).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -155,8 +283,10 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Email:    "notfound@example.com",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				userRepo.On("GetByEmail", mock.Anything, "notfound@example.com").Return(nil, domainErrors.ErrUserNotFound)
+			setupMocks: func(m *authMocks) {
+				m.loginThrottle.On("Check", mock.Anything, "notfound@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "notfound@example.com").Return(nil, domainErrors.ErrUserNotFound)
+				m.loginThrottle.On("RecordFailure", mock.Anything, "notfound@example.com").Return(false, nil)
 			},
 			expectedError: domainErrors.ErrInvalidCredentials,
 		},
@@ -166,14 +296,15 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Email:    "inactive@example.com",
 				Password: "password123",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(m *authMocks) {
 				user := &entity.User{
 					ID:       userID,
 					Email:    "inactive@example.com",
 					Password: hashedPassword,
 					IsActive: false,
 				}
-				userRepo.On("GetByEmail", mock.Anything, "inactive@example.com").Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "inactive@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "inactive@example.com").Return(user, nil)
 			},
 			expectedError: domainErrors.ErrUserInactive,
 		},
@@ -183,64 +314,171 @@ func TestAuthUseCase_Login(t *testing.T) {
 				Email:    "user@example.com",
 				Password: "wrongpassword",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
+			setupMocks: func(m *authMocks) {
 				user := &entity.User{
 					ID:       userID,
 					Email:    "user@example.com",
 					Password: hashedPassword,
 					IsActive: true,
 				}
-				userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.loginThrottle.On("RecordFailure", mock.Anything, "user@example.com").Return(false, nil)
 			},
 			expectedError: domainErrors.ErrInvalidCredentials,
 		},
+		{
+			name: "failure - wrong password locks account",
+			req: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "wrongpassword",
+			},
+			setupMocks: func(m *authMocks) {
+				user := &entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					Password: hashedPassword,
+					IsActive: true,
+				}
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.loginThrottle.On("RecordFailure", mock.Anything, "user@example.com").Return(true, nil)
+			},
+			expectedError: domainErrors.ErrInvalidCredentials,
+		},
+		{
+			name: "failure - account locked",
+			req: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(m *authMocks) {
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(lockedErr)
+			},
+			expectedError: lockedErr,
+		},
+		{
+			name: "success - MFA enabled returns mfa token",
+			req: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(m *authMocks) {
+				user := &entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					Password: hashedPassword,
+					IsActive: true,
+				}
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(user, nil)
+
+				confirmedAt := time.Now()
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(&entity.UserMFA{
+					UserID:      userID,
+					ConfirmedAt: &confirmedAt,
+				}, nil)
+				m.tokenService.On("GenerateMFAToken", userID).Return("mfa_token", nil)
+			},
+			expectedMFA: true,
+		},
+		{
+			name: "success - role requiring MFA forces enrollment",
+			req: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(m *authMocks) {
+				user := &entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					Password: hashedPassword,
+					IsActive: true,
+				}
+				m.loginThrottle.On("Check", mock.Anything, "user@example.com").Return(nil)
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(user, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+					Roles:    []entity.Role{{ID: uuid.New(), Name: "admin", RequireMFA: true}},
+				}, nil)
+				m.mfaRepo.On("GetByUserID", mock.Anything, userID).Return(nil, nil)
+				m.tokenService.On("GenerateMFAToken", userID).Return("mfa_token", nil)
+			},
+			expectedMFA:        true,
+			expectedEnrollment: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userRepo := new(mocks.MockUserRepository)
-			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			m := newAuthMocks()
+			tt.setupMocks(m)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
-			resp, err := authUseCase.Login(context.Background(), tt.req)
+			// Client info is put in the context by the audit context middleware
+			ctx := context.WithValue(context.Background(), appService.CtxKeyIPAddress, "203.0.113.7")
+			ctx = context.WithValue(ctx, appService.CtxKeyUserAgent, "test-agent")
+
+			authUseCase := m.newUseCase(DefaultAuthOptions())
+			resp, err := authUseCase.Login(ctx, tt.req)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
 				assert.Equal(t, tt.expectedError, err)
 				assert.Nil(t, resp)
+			} else if tt.expectedMFA {
+				assert.NoError(t, err)
+				assert.True(t, resp.MFARequired)
+				assert.Equal(t, "mfa_token", resp.MFAToken)
+				assert.Equal(t, tt.expectedEnrollment, resp.MFAEnrollmentRequired)
+				assert.Empty(t, resp.AccessToken)
+				assert.Empty(t, resp.RefreshToken)
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, resp)
 				assert.NotEmpty(t, resp.AccessToken)
 			}
 
-			userRepo.AssertExpectations(t)
-			tokenService.AssertExpectations(t)
+			m.assertExpectations(t)
 		})
 	}
 }
 
 func TestAuthUseCase_RefreshToken(t *testing.T) {
 	userID := uuid.New()
+	familyID := uuid.New()
+	storedTokenID := uuid.New()
+
+	validClaims := &service.TokenClaims{
+		UserID: userID,
+		Exp:    time.Now().Add(time.Hour).Unix(),
+	}
+	activeToken := func() *entity.RefreshToken {
+		return &entity.RefreshToken{
+			ID:        storedTokenID,
+			UserID:    userID,
+			FamilyID:  familyID,
+			TokenHash: hashToken("valid_refresh_token"),
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
 
 	tests := []struct {
 		name          string
 		req           dto.RefreshTokenRequest
-		setupMocks    func(*mocks.MockUserRepository, *mocks.MockTokenService)
+		setupMocks    func(m *authMocks)
 		expectedError error
 	}{
 		{
-			name: "success - refresh token",
+			name: "success - rotate refresh token",
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(activeToken(), nil)
 
 				userWithRoles := &entity.User{
 					ID:       userID,
@@ -249,10 +487,24 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 					IsActive: true,
 					Roles:    []entity.Role{{ID: uuid.New(), Name: "user"}},
 				}
-				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
 
-				tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{"user"}).Return("new_access_token", nil)
-				tokenService.On("GenerateRefreshToken", userID).Return("new_refresh_token", nil)
+				var newTokenID uuid.UUID
+				m.refreshTokenRepo.On("Revoke", mock.Anything, storedTokenID, mock.MatchedBy(func(id *uuid.UUID) bool {
+					newTokenID = *id
+					return id != nil
+				})).Return(nil)
+
+				m.sessionRepo.On("Touch", mock.Anything, familyID, mock.Anything, mock.Anything).Return(nil)
+
+				m.tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{"user"}).Return("new_access_token", nil)
+				m.tokenService.On("GenerateRefreshToken", userID).Return("new_refresh_token", nil)
+				m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				m.refreshTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(rt *entity.RefreshToken) bool {
+					// New token stays in the same family and is the replacement of the old one
+					return rt.FamilyID == familyID && rt.ID == newTokenID &&
+						rt.TokenHash == hashToken("new_refresh_token")
+				})).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -261,23 +513,80 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "invalid_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				tokenService.On("ValidateToken", "invalid_token").Return(nil, domainErrors.ErrInvalidToken)
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "invalid_token").Return(nil, domainErrors.ErrInvalidToken)
 			},
 			expectedError: domainErrors.ErrInvalidToken,
 		},
+		{
+			name: "failure - token not stored",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(nil, domainErrors.ErrTokenNotFound)
+			},
+			expectedError: domainErrors.ErrTokenNotFound,
+		},
+		{
+			name: "failure - reused token revokes family",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+
+				revokedAt := time.Now().Add(-time.Minute)
+				revoked := activeToken()
+				revoked.RevokedAt = &revokedAt
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(revoked, nil)
+				m.refreshTokenRepo.On("RevokeFamily", mock.Anything, familyID).Return(nil)
+			},
+			expectedError: domainErrors.ErrRefreshTokenReused,
+		},
+		{
+			name: "failure - concurrent rotation revokes family",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(activeToken(), nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, IsActive: true}, nil)
+
+				// Another request rotated the token between the lookup and the update
+				m.refreshTokenRepo.On("Revoke", mock.Anything, storedTokenID, mock.Anything).Return(domainErrors.ErrTokenNotFound)
+				m.refreshTokenRepo.On("RevokeFamily", mock.Anything, familyID).Return(nil)
+			},
+			expectedError: domainErrors.ErrRefreshTokenReused,
+		},
+		{
+			name: "failure - storing the new token rolls back the revocation",
+			req: dto.RefreshTokenRequest{
+				RefreshToken: "valid_refresh_token",
+			},
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(activeToken(), nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "user@example.com", IsActive: true}, nil)
+				m.refreshTokenRepo.On("Revoke", mock.Anything, storedTokenID, mock.Anything).Return(nil)
+				m.tokenService.On("GenerateAccessToken", userID, "user@example.com", []string{}).Return("new_access_token", nil)
+				m.tokenService.On("GenerateRefreshToken", userID).Return("new_refresh_token", nil)
+				m.tokenService.On("RefreshTokenExpiry").Return(168 * time.Hour)
+				m.refreshTokenRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+			},
+			expectedError: domainErrors.ErrInternalServer,
+		},
 		{
 			name: "failure - user not found",
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
-				userRepo.On("GetWithRoles", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(activeToken(), nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
 			},
 			expectedError: domainErrors.ErrUserNotFound,
 		},
@@ -286,19 +595,16 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 			req: dto.RefreshTokenRequest{
 				RefreshToken: "valid_refresh_token",
 			},
-			setupMocks: func(userRepo *mocks.MockUserRepository, tokenService *mocks.MockTokenService) {
-				claims := &service.TokenClaims{
-					UserID: userID,
-					Exp:    time.Now().Add(time.Hour).Unix(),
-				}
-				tokenService.On("ValidateToken", "valid_refresh_token").Return(claims, nil)
+			setupMocks: func(m *authMocks) {
+				m.tokenService.On("ValidateToken", "valid_refresh_token").Return(validClaims, nil)
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(activeToken(), nil)
 
 				userWithRoles := &entity.User{
 					ID:       userID,
 					Email:    "user@example.com",
 					IsActive: false,
 				}
-				userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
+				m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(userWithRoles, nil)
 			},
 			expectedError: domainErrors.ErrUserInactive,
 		},
@@ -306,11 +612,10 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			userRepo := new(mocks.MockUserRepository)
-			tokenService := new(mocks.MockTokenService)
-			tt.setupMocks(userRepo, tokenService)
+			m := newAuthMocks()
+			tt.setupMocks(m)
 
-			authUseCase := NewAuthUseCase(userRepo, tokenService)
+			authUseCase := m.newUseCase(DefaultAuthOptions())
 			resp, err := authUseCase.RefreshToken(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -321,10 +626,427 @@ func TestAuthUseCase_RefreshToken(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, resp)
 				assert.NotEmpty(t, resp.AccessToken)
+				assert.Equal(t, "new_refresh_token", resp.RefreshToken)
+			}
+
+			// The revocation is undone whenever its replacement is not stored
+			if m.txManager.calls > 0 {
+				assert.Equal(t, tt.expectedError != nil, m.txManager.rolledBack == 1)
 			}
 
-			userRepo.AssertExpectations(t)
-			tokenService.AssertExpectations(t)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	familyID := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           dto.LogoutRequest
+		setupMocks    func(m *authMocks)
+		expectedError error
+	}{
+		{
+			name: "success - revoke token family",
+			req:  dto.LogoutRequest{RefreshToken: "refresh_token"},
+			setupMocks: func(m *authMocks) {
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("refresh_token")).Return(&entity.RefreshToken{
+					ID:       uuid.New(),
+					FamilyID: familyID,
+				}, nil)
+				m.refreshTokenRepo.On("RevokeFamily", mock.Anything, familyID).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "failure - token not found",
+			req:  dto.LogoutRequest{RefreshToken: "unknown_token"},
+			setupMocks: func(m *authMocks) {
+				m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("unknown_token")).Return(nil, domainErrors.ErrTokenNotFound)
+			},
+			expectedError: domainErrors.ErrTokenNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			tt.setupMocks(m)
+
+			authUseCase := m.newUseCase(DefaultAuthOptions())
+			err := authUseCase.Logout(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedError, err)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_LogoutAll(t *testing.T) {
+	userID := uuid.New()
+
+	m := newAuthMocks()
+	m.refreshTokenRepo.On("RevokeAllForUser", mock.Anything, userID).Return(nil)
+	m.tokenService.On("AccessTokenExpiry").Return(15 * time.Minute)
+	m.tokenDenylist.On("Revoke", mock.Anything, "current-jti", mock.AnythingOfType("time.Time")).Return(nil)
+
+	authUseCase := m.newUseCase(DefaultAuthOptions())
+	err := authUseCase.LogoutAll(context.Background(), userID, "current-jti")
+
+	assert.NoError(t, err)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_RevokeAccessToken(t *testing.T) {
+	m := newAuthMocks()
+	m.tokenService.On("AccessTokenExpiry").Return(15 * time.Minute)
+	m.tokenDenylist.On("Revoke", mock.Anything, "some-jti", mock.MatchedBy(func(expiresAt time.Time) bool {
+		return expiresAt.After(time.Now().Add(14 * time.Minute))
+	})).Return(nil)
+
+	authUseCase := m.newUseCase(DefaultAuthOptions())
+	err := authUseCase.RevokeAccessToken(context.Background(), dto.RevokeTokenRequest{TokenID: "some-jti"})
+
+	assert.NoError(t, err)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_Login_RequireEmailVerification(t *testing.T) {
+	user := &entity.User{
+		ID:       uuid.New(),
+		Email:    "unverified@example.com",
+		Password: "password123",
+		IsActive: true,
+	}
+	require.NoError(t, user.HashPassword())
+
+	m := newAuthMocks()
+	m.loginThrottle.On("Check", mock.Anything, "unverified@example.com").Return(nil)
+	m.userRepo.On("GetByEmail", mock.Anything, "unverified@example.com").Return(user, nil)
+
+	options := DefaultAuthOptions()
+	options.RequireEmailVerification = true
+
+	resp, err := m.newUseCase(options).Login(context.Background(), dto.LoginRequest{
+		Email:    "unverified@example.com",
+		Password: "password123",
+	})
+
+	assert.Equal(t, domainErrors.ErrEmailNotVerified, err)
+	assert.Nil(t, resp)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_Register_RequireEmailVerification(t *testing.T) {
+	m := newAuthMocks()
+	m.loginThrottle.On("Check", mock.Anything, "").Return(nil)
+	m.userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
+	m.userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+	m.userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{
+		ID:       uuid.New(),
+		Email:    "newuser@example.com",
+		IsActive: true,
+		Roles:    []entity.Role{{Name: "user"}},
+	}, nil)
+
+	options := DefaultAuthOptions()
+	options.RequireEmailVerification = true
+
+	resp, err := m.newUseCase(options).Register(context.Background(), dto.RegisterRequest{
+		Email:    "newuser@example.com",
+		Password: "password123",
+		Name:     "New User",
+	})
+
+	// No session, access token or refresh token is created
+	require.NoError(t, err)
+	assert.True(t, resp.EmailVerificationRequired)
+	assert.Empty(t, resp.AccessToken)
+	assert.Empty(t, resp.RefreshToken)
+	assert.Equal(t, "newuser@example.com", resp.User.Email)
+	assert.Equal(t, []string{"user"}, resp.User.Roles)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_RefreshToken_RequireEmailVerification(t *testing.T) {
+	userID := uuid.New()
+	m := newAuthMocks()
+	m.tokenService.On("ValidateToken", "valid_refresh_token").Return(&service.TokenClaims{UserID: userID}, nil)
+	m.refreshTokenRepo.On("GetByTokenHash", mock.Anything, hashToken("valid_refresh_token")).Return(&entity.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	m.userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, IsActive: true}, nil)
+
+	options := DefaultAuthOptions()
+	options.RequireEmailVerification = true
+
+	// Tokens issued before the option was turned on cannot be refreshed either
+	resp, err := m.newUseCase(options).RefreshToken(context.Background(), dto.RefreshTokenRequest{RefreshToken: "valid_refresh_token"})
+
+	assert.Equal(t, domainErrors.ErrEmailNotVerified, err)
+	assert.Nil(t, resp)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_CompleteLogin_RequireEmailVerification(t *testing.T) {
+	// External logins (OIDC) skip the password check but not the verification gate
+	m := newAuthMocks()
+	options := DefaultAuthOptions()
+	options.RequireEmailVerification = true
+
+	resp, err := m.newUseCase(options).completeLogin(context.Background(), &entity.User{
+		ID:       uuid.New(),
+		Email:    "linked@example.com",
+		IsActive: true,
+	})
+
+	assert.Equal(t, domainErrors.ErrEmailNotVerified, err)
+	assert.Nil(t, resp)
+	m.assertExpectations(t)
+}
+
+func TestAuthUseCase_ForgotPassword(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           dto.ForgotPasswordRequest
+		setupMocks    func(m *authMocks)
+		expectedError error
+	}{
+		{
+			name: "success - sends reset link",
+			req:  dto.ForgotPasswordRequest{Email: "user@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+				}, nil)
+				m.userTokenRepo.On("InvalidateForUser", mock.Anything, userID, entity.UserTokenPurposePasswordReset).Return(nil)
+				m.userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(ut *entity.UserToken) bool {
+					return ut.UserID == userID && ut.Purpose == entity.UserTokenPurposePasswordReset && ut.TokenHash != ""
+				})).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.MatchedBy(func(msg service.MailMessage) bool {
+					return msg.To == "user@example.com"
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "success - mail failure is not reported",
+			req:  dto.ForgotPasswordRequest{Email: "user@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+				}, nil)
+				m.userTokenRepo.On("InvalidateForUser", mock.Anything, userID, entity.UserTokenPurposePasswordReset).Return(nil)
+				m.userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp unavailable"))
+			},
+			expectedError: nil,
+		},
+		{
+			name: "success - unknown email does not send anything",
+			req:  dto.ForgotPasswordRequest{Email: "unknown@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, domainErrors.ErrUserNotFound)
+			},
+			expectedError: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			tt.setupMocks(m)
+
+			err := m.newUseCase(DefaultAuthOptions()).ForgotPassword(context.Background(), tt.req)
+
+			assert.Equal(t, tt.expectedError, err)
+			m.assertExpectations(t)
 		})
 	}
 }
+
+func TestAuthUseCase_ResendVerification(t *testing.T) {
+	userID := uuid.New()
+	verifiedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name       string
+		req        dto.ResendVerificationRequest
+		setupMocks func(m *authMocks)
+	}{
+		{
+			name: "sends a new link to an unverified user",
+			req:  dto.ResendVerificationRequest{Email: "user@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+				}, nil)
+				m.userTokenRepo.On("InvalidateForUser", mock.Anything, userID, entity.UserTokenPurposeEmailVerification).Return(nil)
+				m.userTokenRepo.On("Create", mock.Anything, mock.MatchedBy(func(ut *entity.UserToken) bool {
+					return ut.UserID == userID && ut.Purpose == entity.UserTokenPurposeEmailVerification
+				})).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.MatchedBy(func(msg service.MailMessage) bool {
+					return msg.To == "user@example.com"
+				})).Return(nil)
+			},
+		},
+		{
+			name: "mail failure is not reported",
+			req:  dto.ResendVerificationRequest{Email: "user@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "user@example.com").Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+				}, nil)
+				m.userTokenRepo.On("InvalidateForUser", mock.Anything, userID, entity.UserTokenPurposeEmailVerification).Return(nil)
+				m.userTokenRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp unavailable"))
+			},
+		},
+		{
+			name: "already verified user does not get a link",
+			req:  dto.ResendVerificationRequest{Email: "verified@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "verified@example.com").Return(&entity.User{
+					ID:              userID,
+					Email:           "verified@example.com",
+					IsActive:        true,
+					EmailVerifiedAt: &verifiedAt,
+				}, nil)
+			},
+		},
+		{
+			name: "unknown email does not send anything",
+			req:  dto.ResendVerificationRequest{Email: "unknown@example.com"},
+			setupMocks: func(m *authMocks) {
+				m.userRepo.On("GetByEmail", mock.Anything, "unknown@example.com").Return(nil, domainErrors.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			tt.setupMocks(m)
+
+			err := m.newUseCase(DefaultAuthOptions()).ResendVerification(context.Background(), tt.req)
+
+			assert.NoError(t, err)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_ResetPassword(t *testing.T) {
+	userID := uuid.New()
+	tokenID := uuid.New()
+	usedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		userToken     *entity.UserToken
+		setupMocks    func(m *authMocks)
+		expectedError error
+	}{
+		{
+			name: "success - password updated and sessions revoked",
+			userToken: &entity.UserToken{
+				ID:        tokenID,
+				UserID:    userID,
+				Purpose:   entity.UserTokenPurposePasswordReset,
+				ExpiresAt: time.Now().Add(time.Hour),
+			},
+			setupMocks: func(m *authMocks) {
+				m.userTokenRepo.On("MarkUsed", mock.Anything, tokenID).Return(nil)
+				m.userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{
+					ID:       userID,
+					Email:    "user@example.com",
+					IsActive: true,
+				}, nil)
+				m.userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.CheckPassword("new-password") && u.IsEmailVerified()
+				})).Return(nil)
+				m.refreshTokenRepo.On("RevokeAllForUser", mock.Anything, userID).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "failure - token already used",
+			userToken: &entity.UserToken{
+				ID:        tokenID,
+				UserID:    userID,
+				Purpose:   entity.UserTokenPurposePasswordReset,
+				ExpiresAt: time.Now().Add(time.Hour),
+				UsedAt:    &usedAt,
+			},
+			setupMocks:    func(m *authMocks) {},
+			expectedError: domainErrors.ErrInvalidToken,
+		},
+		{
+			name: "failure - token expired",
+			userToken: &entity.UserToken{
+				ID:        tokenID,
+				UserID:    userID,
+				Purpose:   entity.UserTokenPurposePasswordReset,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			},
+			setupMocks:    func(m *authMocks) {},
+			expectedError: domainErrors.ErrTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks()
+			m.userTokenRepo.On("GetByTokenHash", mock.Anything, entity.UserTokenPurposePasswordReset, hashToken("reset-token")).Return(tt.userToken, nil)
+			tt.setupMocks(m)
+
+			err := m.newUseCase(DefaultAuthOptions()).ResetPassword(context.Background(), dto.ResetPasswordRequest{
+				Token:       "reset-token",
+				NewPassword: "new-password",
+			})
+
+			assert.Equal(t, tt.expectedError, err)
+			m.assertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUseCase_VerifyEmail(t *testing.T) {
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	m := newAuthMocks()
+	m.userTokenRepo.On("GetByTokenHash", mock.Anything, entity.UserTokenPurposeEmailVerification, hashToken("verify-token")).Return(&entity.UserToken{
+		ID:        tokenID,
+		UserID:    userID,
+		Purpose:   entity.UserTokenPurposeEmailVerification,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	m.userTokenRepo.On("MarkUsed", mock.Anything, tokenID).Return(nil)
+	m.userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "user@example.com"}, nil)
+	m.userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+		return u.IsEmailVerified()
+	})).Return(nil)
+
+	err := m.newUseCase(DefaultAuthOptions()).VerifyEmail(context.Background(), dto.VerifyEmailRequest{Token: "verify-token"})
+
+	assert.NoError(t, err)
+	m.assertExpectations(t)
+}
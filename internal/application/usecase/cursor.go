@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// Cursor scopes, one per listing, so a cursor is only accepted by the listing that issued it
+const (
+	cursorScopeUsers       = "users"
+	cursorScopeDormitories = "dormitories"
+	cursorScopeAuditLogs   = "audit_logs"
+	cursorScopeProvinces   = "provinces"
+	cursorScopeRegencies   = "regencies"
+	cursorScopeDistricts   = "districts"
+	cursorScopeVillages    = "villages"
+)
+
+// idCursor is the position stored in cursors of listings ordered by ID
+type idCursor struct {
+	ID int `json:"id"`
+}
+
+// normalizeCursorLimit applies the same bounds as page_size in offset pagination
+func normalizeCursorLimit(limit int) int {
+	if limit < 1 {
+		return 10
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// decodeKeyset returns the position of a (created_at, id) cursor, or nil for the first page
+func decodeKeyset(cursors *appService.CursorCodec, scope, cursor string) (*repository.Keyset, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	var after repository.Keyset
+	if err := cursors.Decode(scope, cursor, &after); err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+// decodeAfterID returns the position of an ID cursor, or 0 for the first page
+func decodeAfterID(cursors *appService.CursorCodec, scope, cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	var after idCursor
+	if err := cursors.Decode(scope, cursor, &after); err != nil {
+		return 0, err
+	}
+	return after.ID, nil
+}
+
+// cursorPagination describes a page fetched with one row beyond the limit; next is the
+// position of the last row returned, or nil when the extra row showed there is no further page
+func cursorPagination(cursors *appService.CursorCodec, scope string, query dto.CursorQuery, limit int, next interface{}, total int64) (dto.CursorPagination, error) {
+	pagination := dto.CursorPagination{Limit: limit}
+
+	if next != nil {
+		cursor, err := cursors.Encode(scope, next)
+		if err != nil {
+			return dto.CursorPagination{}, err
+		}
+		pagination.NextCursor = cursor
+	}
+	if query.WithCount {
+		pagination.Total = &total
+	}
+
+	return pagination, nil
+}
@@ -17,6 +17,7 @@ type DormitoryUseCase struct {
 	dormitoryRepo repository.DormitoryRepository
 	userRepo      repository.UserRepository
 	auditLogger   appService.AuditLogger
+	cursors       *appService.CursorCodec
 }
 
 // NewDormitoryUseCase creates a new dormitory use case
@@ -24,11 +25,13 @@ func NewDormitoryUseCase(
 	dormitoryRepo repository.DormitoryRepository,
 	userRepo repository.UserRepository,
 	auditLogger appService.AuditLogger,
+	cursors *appService.CursorCodec,
 ) *DormitoryUseCase {
 	return &DormitoryUseCase{
 		dormitoryRepo: dormitoryRepo,
 		userRepo:      userRepo,
 		auditLogger:   auditLogger,
+		cursors:       cursors,
 	}
 }
 
@@ -118,8 +121,13 @@ func (uc *DormitoryUseCase) DeleteDormitory(ctx context.Context, id uuid.UUID) e
 	return nil
 }
 
-// ListDormitories retrieves a paginated list of dormitories
-func (uc *DormitoryUseCase) ListDormitories(ctx context.Context, page, pageSize int) (*dto.ListDormitoriesResponse, error) {
+// ListDormitories retrieves a paginated list of dormitories matching the filter
+func (uc *DormitoryUseCase) ListDormitories(ctx context.Context, page, pageSize int, filter dto.ListDormitoriesFilter) (*dto.ListDormitoriesResponse, error) {
+	sort, err := repository.ParseSort(filter.Sort, repository.DormitorySortFields)
+	if err != nil {
+		return nil, err
+	}
+
 	if page < 1 {
 		page = 1
 	}
@@ -132,7 +140,11 @@ func (uc *DormitoryUseCase) ListDormitories(ctx context.Context, page, pageSize
 
 	offset := (page - 1) * pageSize
 
-	dormitories, total, err := uc.dormitoryRepo.List(ctx, pageSize, offset)
+	dormitories, total, err := uc.dormitoryRepo.List(ctx, repository.DormitoryFilter{
+		Search:   filter.Search,
+		IsActive: filter.IsActive,
+		Sort:     sort,
+	}, pageSize, offset)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
@@ -156,9 +168,252 @@ func (uc *DormitoryUseCase) ListDormitories(ctx context.Context, page, pageSize
 	}, nil
 }
 
+// ListDormitoriesByCursor retrieves a cursor-paginated list of dormitories matching the filter, newest first
+func (uc *DormitoryUseCase) ListDormitoriesByCursor(ctx context.Context, query dto.CursorQuery, filter dto.ListDormitoriesFilter) (*dto.CursorDormitoriesResponse, error) {
+	sort, err := repository.ParseSort(filter.Sort, repository.DormitorySortFields)
+	if err != nil {
+		return nil, err
+	}
+	if sort != (repository.Sort{}) && sort != (repository.Sort{Field: "created_at", Desc: true}) {
+		return nil, domainErrors.ErrCursorSortNotUsable
+	}
+
+	after, err := decodeKeyset(uc.cursors, cursorScopeDormitories, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := normalizeCursorLimit(query.Limit)
+	dormitories, total, err := uc.dormitoryRepo.ListAfter(ctx, repository.DormitoryFilter{
+		Search:   filter.Search,
+		IsActive: filter.IsActive,
+	}, repository.KeysetPage{After: after, Limit: limit + 1, WithCount: query.WithCount})
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	var next interface{}
+	if len(dormitories) > limit {
+		dormitories = dormitories[:limit]
+		last := dormitories[limit-1]
+		next = repository.Keyset{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeDormitories, query, limit, next, total)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	dormitoryResponses := make([]dto.DormitoryResponse, 0, len(dormitories))
+	for _, dormitory := range dormitories {
+		dormitoryResponses = append(dormitoryResponses, *uc.toDormitoryResponse(dormitory))
+	}
+
+	return &dto.CursorDormitoriesResponse{
+		Dormitories:      dormitoryResponses,
+		CursorPagination: pagination,
+	}, nil
+}
+
+// ListDeletedDormitories retrieves a paginated list of soft-deleted dormitories
+func (uc *DormitoryUseCase) ListDeletedDormitories(ctx context.Context, page, pageSize int) (*dto.ListDormitoriesResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	dormitories, total, err := uc.dormitoryRepo.ListDeleted(ctx, pageSize, offset)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	dormitoryResponses := make([]dto.DormitoryResponse, 0, len(dormitories))
+	for _, dormitory := range dormitories {
+		dormitoryResponses = append(dormitoryResponses, *uc.toDormitoryResponse(dormitory))
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return &dto.ListDormitoriesResponse{
+		Dormitories: dormitoryResponses,
+		Total:       total,
+		Page:        page,
+		PageSize:    pageSize,
+		TotalPages:  totalPages,
+	}, nil
+}
+
+// RestoreDormitory brings back a soft-deleted dormitory together with its members
+func (uc *DormitoryUseCase) RestoreDormitory(ctx context.Context, id uuid.UUID) (*dto.DormitoryResponse, error) {
+	dormitory, err := uc.dormitoryRepo.GetDeletedByID(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrDormitoryNotFound
+	}
+
+	if err := uc.dormitoryRepo.Restore(ctx, id); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:restore", id.String(), map[string]string{
+		"name":        dormitory.Name,
+		"description": dormitory.Description,
+	})
+
+	restored, err := uc.dormitoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	return uc.toDormitoryResponse(restored), nil
+}
+
+// AddMember adds a user to a dormitory, giving them access to it
+func (uc *DormitoryUseCase) AddMember(ctx context.Context, dormitoryID uuid.UUID, req dto.AddDormitoryMemberRequest) (*dto.DormitoryMemberResponse, error) {
+	dormitory, err := uc.dormitoryRepo.GetByID(ctx, dormitoryID)
+	if err != nil {
+		return nil, domainErrors.ErrDormitoryNotFound
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	member, err := uc.dormitoryRepo.IsMember(ctx, userID, dormitoryID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if member {
+		return nil, domainErrors.ErrDormitoryMemberExists
+	}
+
+	if err := uc.dormitoryRepo.AssignToUser(ctx, userID, dormitoryID); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:add_member", dormitory.ID.String(), map[string]string{
+		"user_id":    user.ID.String(),
+		"user_email": user.Email,
+	})
+
+	return uc.toDormitoryMemberResponse(user), nil
+}
+
+// RemoveMember removes a user from a dormitory
+func (uc *DormitoryUseCase) RemoveMember(ctx context.Context, dormitoryID, userID uuid.UUID) error {
+	dormitory, err := uc.dormitoryRepo.GetByID(ctx, dormitoryID)
+	if err != nil {
+		return domainErrors.ErrDormitoryNotFound
+	}
+
+	member, err := uc.dormitoryRepo.IsMember(ctx, userID, dormitoryID)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	if !member {
+		return domainErrors.ErrDormitoryMemberNotFound
+	}
+
+	if err := uc.dormitoryRepo.RemoveFromUser(ctx, userID, dormitoryID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "dormitory", "dorm:remove_member", dormitory.ID.String(), map[string]string{
+		"user_id": userID.String(),
+	})
+
+	return nil
+}
+
+// ListMembers retrieves a paginated list of the users assigned to a dormitory
+func (uc *DormitoryUseCase) ListMembers(ctx context.Context, dormitoryID uuid.UUID, page, pageSize int) (*dto.ListDormitoryMembersResponse, error) {
+	if _, err := uc.dormitoryRepo.GetByID(ctx, dormitoryID); err != nil {
+		return nil, domainErrors.ErrDormitoryNotFound
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	users, total, err := uc.dormitoryRepo.ListMembers(ctx, dormitoryID, pageSize, offset)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	members := make([]dto.DormitoryMemberResponse, 0, len(users))
+	for _, user := range users {
+		members = append(members, *uc.toDormitoryMemberResponse(user))
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return &dto.ListDormitoryMembersResponse{
+		Members:    members,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ListUserDormitories retrieves the dormitories a user is assigned to
+func (uc *DormitoryUseCase) ListUserDormitories(ctx context.Context, userID uuid.UUID) ([]dto.DormitoryResponse, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	dormitories, err := uc.dormitoryRepo.GetUserDormitories(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.DormitoryResponse, 0, len(dormitories))
+	for _, dormitory := range dormitories {
+		responses = append(responses, *uc.toDormitoryResponse(dormitory))
+	}
+	return responses, nil
+}
+
+// toDormitoryMemberResponse converts entity.User to dto.DormitoryMemberResponse
+func (uc *DormitoryUseCase) toDormitoryMemberResponse(user *entity.User) *dto.DormitoryMemberResponse {
+	return &dto.DormitoryMemberResponse{
+		ID:       user.ID.String(),
+		Email:    user.Email,
+		Name:     user.Name,
+		IsActive: user.IsActive,
+	}
+}
+
 // toDormitoryResponse converts entity.Dormitory to dto.DormitoryResponse
 func (uc *DormitoryUseCase) toDormitoryResponse(dormitory *entity.Dormitory) *dto.DormitoryResponse {
-	return &dto.DormitoryResponse{
+	resp := &dto.DormitoryResponse{
 		ID:          dormitory.ID.String(),
 		Name:        dormitory.Name,
 		Description: dormitory.Description,
@@ -166,4 +421,8 @@ func (uc *DormitoryUseCase) toDormitoryResponse(dormitory *entity.Dormitory) *dt
 		CreatedAt:   dormitory.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   dormitory.UpdatedAt.Format(time.RFC3339),
 	}
+	if dormitory.DeletedAt.Valid {
+		resp.DeletedAt = dormitory.DeletedAt.Time.Format(time.RFC3339)
+	}
+	return resp
 }
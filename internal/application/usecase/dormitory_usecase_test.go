@@ -11,6 +11,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
 func TestDormitoryUseCase_CreateDormitory(t *testing.T) {
@@ -42,7 +43,7 @@ func TestDormitoryUseCase_CreateDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
 			resp, err := dormUseCase.CreateDormitory(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -99,7 +100,7 @@ func TestDormitoryUseCase_GetDormitoryByID(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
 			resp, err := dormUseCase.GetDormitoryByID(context.Background(), tt.dormitoryID)
 
 			if tt.expectedError != nil {
@@ -162,7 +163,7 @@ func TestDormitoryUseCase_UpdateDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
 			resp, err := dormUseCase.UpdateDormitory(context.Background(), tt.dormitoryID, tt.req)
 
 			if tt.expectedError != nil {
@@ -216,7 +217,7 @@ func TestDormitoryUseCase_DeleteDormitory(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
 			err := dormUseCase.DeleteDormitory(context.Background(), tt.dormitoryID)
 
 			if tt.expectedError != nil {
@@ -248,7 +249,7 @@ func TestDormitoryUseCase_ListDormitories(t *testing.T) {
 					{ID: uuid.New(), Name: "Dormitory 1"},
 					{ID: uuid.New(), Name: "Dormitory 2"},
 				}
-				dormRepo.On("List", mock.Anything, 10, 0).Return(dormitories, int64(2), nil)
+				dormRepo.On("List", mock.Anything, repository.DormitoryFilter{}, 10, 0).Return(dormitories, int64(2), nil)
 			},
 			expectedError: nil,
 		},
@@ -261,8 +262,8 @@ func TestDormitoryUseCase_ListDormitories(t *testing.T) {
 			tt.setupMocks(dormRepo)
 
 			auditLogger := &noopAuditLogger{}
-			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger)
-			resp, err := dormUseCase.ListDormitories(context.Background(), tt.page, tt.pageSize)
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
+			resp, err := dormUseCase.ListDormitories(context.Background(), tt.page, tt.pageSize, dto.ListDormitoriesFilter{})
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -277,3 +278,179 @@ func TestDormitoryUseCase_ListDormitories(t *testing.T) {
 		})
 	}
 }
+
+func TestDormitoryUseCase_AddMember(t *testing.T) {
+	dormitoryID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		userID        string
+		setupMocks    func(*mocks.MockDormitoryRepository, *mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:   "success - add member",
+			userID: userID.String(),
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository, userRepo *mocks.MockUserRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "member@example.com"}, nil)
+				dormRepo.On("IsMember", mock.Anything, userID, dormitoryID).Return(false, nil)
+				dormRepo.On("AssignToUser", mock.Anything, userID, dormitoryID).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "error - already a member",
+			userID: userID.String(),
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository, userRepo *mocks.MockUserRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				dormRepo.On("IsMember", mock.Anything, userID, dormitoryID).Return(true, nil)
+			},
+			expectedError: domainErrors.ErrDormitoryMemberExists,
+		},
+		{
+			name:   "error - user not found",
+			userID: userID.String(),
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository, userRepo *mocks.MockUserRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				userRepo.On("GetByID", mock.Anything, userID).Return(nil, domainErrors.ErrUserNotFound)
+			},
+			expectedError: domainErrors.ErrUserNotFound,
+		},
+		{
+			name:   "error - dormitory not found",
+			userID: userID.String(),
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository, userRepo *mocks.MockUserRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(nil, domainErrors.ErrDormitoryNotFound)
+			},
+			expectedError: domainErrors.ErrDormitoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dormRepo := new(mocks.MockDormitoryRepository)
+			userRepo := new(mocks.MockUserRepository)
+			tt.setupMocks(dormRepo, userRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			dormUseCase := NewDormitoryUseCase(dormRepo, userRepo, auditLogger, testCursors)
+			resp, err := dormUseCase.AddMember(context.Background(), dormitoryID, dto.AddDormitoryMemberRequest{UserID: tt.userID})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "member@example.com", resp.Email)
+				assert.Equal(t, []string{"dorm:add_member"}, auditLogger.actions)
+			}
+
+			dormRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDormitoryUseCase_RemoveMember(t *testing.T) {
+	dormitoryID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockDormitoryRepository)
+		expectedError error
+	}{
+		{
+			name: "success - remove member",
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				dormRepo.On("IsMember", mock.Anything, userID, dormitoryID).Return(true, nil)
+				dormRepo.On("RemoveFromUser", mock.Anything, userID, dormitoryID).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - not a member",
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository) {
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				dormRepo.On("IsMember", mock.Anything, userID, dormitoryID).Return(false, nil)
+			},
+			expectedError: domainErrors.ErrDormitoryMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dormRepo := new(mocks.MockDormitoryRepository)
+			tt.setupMocks(dormRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			dormUseCase := NewDormitoryUseCase(dormRepo, new(mocks.MockUserRepository), auditLogger, testCursors)
+			err := dormUseCase.RemoveMember(context.Background(), dormitoryID, userID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"dorm:remove_member"}, auditLogger.actions)
+			}
+
+			dormRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDormitoryUseCase_RestoreDormitory(t *testing.T) {
+	dormitoryID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockDormitoryRepository)
+		expectedError error
+	}{
+		{
+			name: "success - restore dormitory",
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository) {
+				dormRepo.On("GetDeletedByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID, Name: "Asrama A"}, nil)
+				dormRepo.On("Restore", mock.Anything, dormitoryID).Return(nil)
+				dormRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID, Name: "Asrama A"}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - not deleted",
+			setupMocks: func(dormRepo *mocks.MockDormitoryRepository) {
+				dormRepo.On("GetDeletedByID", mock.Anything, dormitoryID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrDormitoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dormRepo := new(mocks.MockDormitoryRepository)
+			tt.setupMocks(dormRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			dormUseCase := NewDormitoryUseCase(dormRepo, new(mocks.MockUserRepository), auditLogger, testCursors)
+			resp, err := dormUseCase.RestoreDormitory(context.Background(), dormitoryID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Asrama A", resp.Name)
+				assert.Equal(t, []string{"dorm:restore"}, auditLogger.actions)
+			}
+
+			dormRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
@@ -13,6 +15,7 @@ type LocationUseCase struct {
 	regencyRepo  repository.RegencyRepository
 	districtRepo repository.DistrictRepository
 	villageRepo  repository.VillageRepository
+	cursors      *appService.CursorCodec
 }
 
 func NewLocationUseCase(
@@ -20,12 +23,14 @@ func NewLocationUseCase(
 	regencyRepo repository.RegencyRepository,
 	districtRepo repository.DistrictRepository,
 	villageRepo repository.VillageRepository,
+	cursors *appService.CursorCodec,
 ) *LocationUseCase {
 	return &LocationUseCase{
 		provinceRepo: provinceRepo,
 		regencyRepo:  regencyRepo,
 		districtRepo: districtRepo,
 		villageRepo:  villageRepo,
+		cursors:      cursors,
 	}
 }
 
@@ -41,6 +46,17 @@ func computeTotalPages(total int64, pageSize int) int {
 	return totalPages
 }
 
+// idPage prepares an ID-ordered page fetching one row beyond the limit, which tells whether a next page exists
+func (uc *LocationUseCase) idPage(scope string, query dto.CursorQuery) (repository.IDPage, int, error) {
+	afterID, err := decodeAfterID(uc.cursors, scope, query.Cursor)
+	if err != nil {
+		return repository.IDPage{}, 0, err
+	}
+
+	limit := normalizeCursorLimit(query.Limit)
+	return repository.IDPage{AfterID: afterID, Limit: limit + 1, WithCount: query.WithCount}, limit, nil
+}
+
 // Provinces
 
 func (uc *LocationUseCase) ListProvinces(ctx context.Context, page, pageSize int, search string) (*dto.PaginatedProvinceResponse, error) {
@@ -51,11 +67,7 @@ func (uc *LocationUseCase) ListProvinces(ctx context.Context, page, pageSize int
 
 	items := make([]dto.ProvinceResponse, 0, len(provinces))
 	for _, p := range provinces {
-		items = append(items, dto.ProvinceResponse{
-			ID:   p.ID,
-			Name: p.Name,
-			Code: p.Code,
-		})
+		items = append(items, toProvinceResponse(p))
 	}
 
 	return &dto.PaginatedProvinceResponse{
@@ -67,12 +79,48 @@ func (uc *LocationUseCase) ListProvinces(ctx context.Context, page, pageSize int
 	}, nil
 }
 
+// ListProvincesByCursor lists provinces in ID order from a cursor
+func (uc *LocationUseCase) ListProvincesByCursor(ctx context.Context, query dto.CursorQuery, search string) (*dto.CursorProvinceResponse, error) {
+	page, limit, err := uc.idPage(cursorScopeProvinces, query)
+	if err != nil {
+		return nil, err
+	}
+
+	provinces, total, err := uc.provinceRepo.ListAfter(ctx, page, search)
+	if err != nil {
+		return nil, err
+	}
+
+	var next interface{}
+	if len(provinces) > limit {
+		provinces = provinces[:limit]
+		next = idCursor{ID: provinces[limit-1].ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeProvinces, query, limit, next, total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.ProvinceResponse, 0, len(provinces))
+	for _, p := range provinces {
+		items = append(items, toProvinceResponse(p))
+	}
+
+	return &dto.CursorProvinceResponse{Items: items, CursorPagination: pagination}, nil
+}
+
 func (uc *LocationUseCase) GetProvinceByID(ctx context.Context, id int) (*dto.ProvinceResponse, error) {
 	p, err := uc.provinceRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.ProvinceResponse{ID: p.ID, Name: p.Name, Code: p.Code}, nil
+	resp := toProvinceResponse(p)
+	return &resp, nil
+}
+
+func toProvinceResponse(p *entity.Province) dto.ProvinceResponse {
+	return dto.ProvinceResponse{ID: p.ID, Name: p.Name, Code: p.Code}
 }
 
 // Regencies
@@ -85,14 +133,7 @@ func (uc *LocationUseCase) ListRegencies(ctx context.Context, page, pageSize int
 
 	items := make([]dto.RegencyResponse, 0, len(regencies))
 	for _, r := range regencies {
-		items = append(items, dto.RegencyResponse{
-			ID:         r.ID,
-			Type:       r.Type,
-			Name:       r.Name,
-			Code:       r.Code,
-			FullCode:   r.FullCode,
-			ProvinceID: r.ProvinceID,
-		})
+		items = append(items, toRegencyResponse(r))
 	}
 
 	return &dto.PaginatedRegencyResponse{
@@ -104,19 +145,55 @@ func (uc *LocationUseCase) ListRegencies(ctx context.Context, page, pageSize int
 	}, nil
 }
 
+// ListRegenciesByCursor lists regencies in ID order from a cursor
+func (uc *LocationUseCase) ListRegenciesByCursor(ctx context.Context, query dto.CursorQuery, provinceID *int, search string) (*dto.CursorRegencyResponse, error) {
+	page, limit, err := uc.idPage(cursorScopeRegencies, query)
+	if err != nil {
+		return nil, err
+	}
+
+	regencies, total, err := uc.regencyRepo.ListAfter(ctx, page, provinceID, search)
+	if err != nil {
+		return nil, err
+	}
+
+	var next interface{}
+	if len(regencies) > limit {
+		regencies = regencies[:limit]
+		next = idCursor{ID: regencies[limit-1].ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeRegencies, query, limit, next, total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.RegencyResponse, 0, len(regencies))
+	for _, r := range regencies {
+		items = append(items, toRegencyResponse(r))
+	}
+
+	return &dto.CursorRegencyResponse{Items: items, CursorPagination: pagination}, nil
+}
+
 func (uc *LocationUseCase) GetRegencyByID(ctx context.Context, id int) (*dto.RegencyResponse, error) {
 	r, err := uc.regencyRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.RegencyResponse{
+	resp := toRegencyResponse(r)
+	return &resp, nil
+}
+
+func toRegencyResponse(r *entity.Regency) dto.RegencyResponse {
+	return dto.RegencyResponse{
 		ID:         r.ID,
 		Type:       r.Type,
 		Name:       r.Name,
 		Code:       r.Code,
 		FullCode:   r.FullCode,
 		ProvinceID: r.ProvinceID,
-	}, nil
+	}
 }
 
 // Districts
@@ -129,13 +206,7 @@ func (uc *LocationUseCase) ListDistricts(ctx context.Context, page, pageSize int
 
 	items := make([]dto.DistrictResponse, 0, len(districts))
 	for _, d := range districts {
-		items = append(items, dto.DistrictResponse{
-			ID:        d.ID,
-			Name:      d.Name,
-			Code:      d.Code,
-			FullCode:  d.FullCode,
-			RegencyID: d.RegencyID,
-		})
+		items = append(items, toDistrictResponse(d))
 	}
 
 	return &dto.PaginatedDistrictResponse{
@@ -147,18 +218,54 @@ func (uc *LocationUseCase) ListDistricts(ctx context.Context, page, pageSize int
 	}, nil
 }
 
+// ListDistrictsByCursor lists districts in ID order from a cursor
+func (uc *LocationUseCase) ListDistrictsByCursor(ctx context.Context, query dto.CursorQuery, regencyID *int, search string) (*dto.CursorDistrictResponse, error) {
+	page, limit, err := uc.idPage(cursorScopeDistricts, query)
+	if err != nil {
+		return nil, err
+	}
+
+	districts, total, err := uc.districtRepo.ListAfter(ctx, page, regencyID, search)
+	if err != nil {
+		return nil, err
+	}
+
+	var next interface{}
+	if len(districts) > limit {
+		districts = districts[:limit]
+		next = idCursor{ID: districts[limit-1].ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeDistricts, query, limit, next, total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.DistrictResponse, 0, len(districts))
+	for _, d := range districts {
+		items = append(items, toDistrictResponse(d))
+	}
+
+	return &dto.CursorDistrictResponse{Items: items, CursorPagination: pagination}, nil
+}
+
 func (uc *LocationUseCase) GetDistrictByID(ctx context.Context, id int) (*dto.DistrictResponse, error) {
 	d, err := uc.districtRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.DistrictResponse{
+	resp := toDistrictResponse(d)
+	return &resp, nil
+}
+
+func toDistrictResponse(d *entity.District) dto.DistrictResponse {
+	return dto.DistrictResponse{
 		ID:        d.ID,
 		Name:      d.Name,
 		Code:      d.Code,
 		FullCode:  d.FullCode,
 		RegencyID: d.RegencyID,
-	}, nil
+	}
 }
 
 // Villages
@@ -171,14 +278,7 @@ func (uc *LocationUseCase) ListVillages(ctx context.Context, page, pageSize int,
 
 	items := make([]dto.VillageResponse, 0, len(villages))
 	for _, v := range villages {
-		items = append(items, dto.VillageResponse{
-			ID:         v.ID,
-			Name:       v.Name,
-			Code:       v.Code,
-			FullCode:   v.FullCode,
-			PosCode:    v.PosCode,
-			DistrictID: v.DistrictID,
-		})
+		items = append(items, toVillageResponse(v))
 	}
 
 	return &dto.PaginatedVillageResponse{
@@ -190,17 +290,53 @@ func (uc *LocationUseCase) ListVillages(ctx context.Context, page, pageSize int,
 	}, nil
 }
 
+// ListVillagesByCursor lists villages in ID order from a cursor
+func (uc *LocationUseCase) ListVillagesByCursor(ctx context.Context, query dto.CursorQuery, districtID *int, search string) (*dto.CursorVillageResponse, error) {
+	page, limit, err := uc.idPage(cursorScopeVillages, query)
+	if err != nil {
+		return nil, err
+	}
+
+	villages, total, err := uc.villageRepo.ListAfter(ctx, page, districtID, search)
+	if err != nil {
+		return nil, err
+	}
+
+	var next interface{}
+	if len(villages) > limit {
+		villages = villages[:limit]
+		next = idCursor{ID: villages[limit-1].ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeVillages, query, limit, next, total)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.VillageResponse, 0, len(villages))
+	for _, v := range villages {
+		items = append(items, toVillageResponse(v))
+	}
+
+	return &dto.CursorVillageResponse{Items: items, CursorPagination: pagination}, nil
+}
+
 func (uc *LocationUseCase) GetVillageByID(ctx context.Context, id int) (*dto.VillageResponse, error) {
 	v, err := uc.villageRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &dto.VillageResponse{
+	resp := toVillageResponse(v)
+	return &resp, nil
+}
+
+func toVillageResponse(v *entity.Village) dto.VillageResponse {
+	return dto.VillageResponse{
 		ID:         v.ID,
 		Name:       v.Name,
 		Code:       v.Code,
 		FullCode:   v.FullCode,
 		PosCode:    v.PosCode,
 		DistrictID: v.DistrictID,
-	}, nil
+	}
 }
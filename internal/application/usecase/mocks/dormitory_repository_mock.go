@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -40,8 +41,8 @@ func (m *MockDormitoryRepository) Delete(ctx context.Context, id uuid.UUID) erro
 	return args.Error(0)
 }
 
-func (m *MockDormitoryRepository) List(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockDormitoryRepository) List(ctx context.Context, filter repository.DormitoryFilter, limit, offset int) ([]*entity.Dormitory, int64, error) {
+	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -65,3 +66,50 @@ func (m *MockDormitoryRepository) GetUserDormitories(ctx context.Context, userID
 	}
 	return args.Get(0).([]*entity.Dormitory), args.Error(1)
 }
+
+func (m *MockDormitoryRepository) IsMember(ctx context.Context, userID, dormitoryID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, dormitoryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockDormitoryRepository) ListMembers(ctx context.Context, dormitoryID uuid.UUID, limit, offset int) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, dormitoryID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDormitoryRepository) ListAfter(ctx context.Context, filter repository.DormitoryFilter, page repository.KeysetPage) ([]*entity.Dormitory, int64, error) {
+	args := m.Called(ctx, filter, page)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Dormitory), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDormitoryRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Dormitory), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDormitoryRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Dormitory), args.Error(1)
+}
+
+func (m *MockDormitoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDormitoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+)
+
+// MockLoginThrottle is a mock implementation of LoginThrottle
+type MockLoginThrottle struct {
+	mock.Mock
+}
+
+// Ensure MockLoginThrottle implements appService.LoginThrottle
+var _ appService.LoginThrottle = (*MockLoginThrottle)(nil)
+
+func (m *MockLoginThrottle) Check(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockLoginThrottle) RecordFailure(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLoginThrottle) Reset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
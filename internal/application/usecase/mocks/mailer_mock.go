@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// MockMailer is a mock implementation of Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+// Ensure MockMailer implements service.Mailer
+var _ service.Mailer = (*MockMailer)(nil)
+
+func (m *MockMailer) Send(ctx context.Context, message service.MailMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// MockOTPService is a mock implementation of OTPService
+type MockOTPService struct {
+	mock.Mock
+}
+
+// Ensure MockOTPService implements service.OTPService
+var _ service.OTPService = (*MockOTPService)(nil)
+
+func (m *MockOTPService) GenerateSecret() (string, error) {
+	args := m.Called()
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockOTPService) ProvisioningURI(secret, accountName string) string {
+	args := m.Called(secret, accountName)
+	return args.String(0)
+}
+
+func (m *MockOTPService) Validate(secret, code string, at time.Time) (int64, bool) {
+	args := m.Called(secret, code, at)
+	return args.Get(0).(int64), args.Bool(1)
+}
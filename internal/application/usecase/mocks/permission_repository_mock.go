@@ -0,0 +1,81 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockPermissionRepository is a mock implementation of PermissionRepository
+type MockPermissionRepository struct {
+	mock.Mock
+}
+
+// Ensure MockPermissionRepository implements repository.PermissionRepository
+var _ repository.PermissionRepository = (*MockPermissionRepository)(nil)
+
+func (m *MockPermissionRepository) Create(ctx context.Context, permission *entity.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetBySlug(ctx context.Context, slug string) (*entity.Permission, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) List(ctx context.Context, limit, offset int) ([]*entity.Permission, int64, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Permission), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPermissionRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) ListAll(ctx context.Context) ([]*entity.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Permission), args.Error(1)
+}
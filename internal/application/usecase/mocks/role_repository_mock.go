@@ -56,6 +56,11 @@ func (m *MockRoleRepository) RemovePermission(ctx context.Context, roleID, permi
 	return args.Error(0)
 }
 
+func (m *MockRoleRepository) SyncPermissions(ctx context.Context, roleID uuid.UUID, assign, remove []uuid.UUID) error {
+	args := m.Called(ctx, roleID, assign, remove)
+	return args.Error(0)
+}
+
 func (m *MockRoleRepository) Update(ctx context.Context, role *entity.Role) error {
 	args := m.Called(ctx, role)
 	return args.Error(0)
@@ -66,8 +71,8 @@ func (m *MockRoleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockRoleRepository) List(ctx context.Context, limit, offset int) ([]*entity.Role, int64, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockRoleRepository) List(ctx context.Context, limit, offset int, with ...repository.Relation) ([]*entity.Role, int64, error) {
+	args := m.Called(ctx, limit, offset, with)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
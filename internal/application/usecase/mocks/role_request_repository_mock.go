@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockRoleRequestRepository is a mock implementation of RoleRequestRepository
+type MockRoleRequestRepository struct {
+	mock.Mock
+}
+
+// Ensure MockRoleRequestRepository implements repository.RoleRequestRepository
+var _ repository.RoleRequestRepository = (*MockRoleRequestRepository)(nil)
+
+func (m *MockRoleRequestRepository) Create(ctx context.Context, request *entity.RoleRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockRoleRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RoleRequest), args.Error(1)
+}
+
+func (m *MockRoleRequestRepository) List(ctx context.Context, status entity.RoleRequestStatus, limit, offset int) ([]*entity.RoleRequest, int64, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.RoleRequest), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoleRequestRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleRequest, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.RoleRequest), args.Error(1)
+}
+
+func (m *MockRoleRequestRepository) HasPending(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, roleID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRoleRequestRepository) Review(ctx context.Context, request *entity.RoleRequest) (bool, error) {
+	args := m.Called(ctx, request)
+	return args.Bool(0), args.Error(1)
+}
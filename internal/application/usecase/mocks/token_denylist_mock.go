@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// MockTokenDenylist is a mock implementation of TokenDenylist
+type MockTokenDenylist struct {
+	mock.Mock
+}
+
+// Ensure MockTokenDenylist implements service.TokenDenylist
+var _ service.TokenDenylist = (*MockTokenDenylist)(nil)
+
+func (m *MockTokenDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := m.Called(ctx, tokenID, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	args := m.Called(ctx, tokenID)
+	return args.Bool(0), args.Error(1)
+}
@@ -1,6 +1,8 @@
 package mocks
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/domain/service"
@@ -24,6 +26,11 @@ func (m *MockTokenService) GenerateRefreshToken(userID uuid.UUID) (string, error
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockTokenService) GenerateMFAToken(userID uuid.UUID) (string, error) {
+	args := m.Called(userID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockTokenService) ValidateToken(tokenString string) (*service.TokenClaims, error) {
 	args := m.Called(tokenString)
 	if args.Get(0) == nil {
@@ -36,3 +43,23 @@ func (m *MockTokenService) RefreshAccessToken(refreshToken string) (string, erro
 	args := m.Called(refreshToken)
 	return args.String(0), args.Error(1)
 }
+
+func (m *MockTokenService) AccessTokenExpiry() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockTokenService) RefreshTokenExpiry() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockTokenService) MFATokenExpiry() time.Duration {
+	args := m.Called()
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockTokenService) JWKS() service.JSONWebKeySet {
+	args := m.Called()
+	return args.Get(0).(service.JSONWebKeySet)
+}
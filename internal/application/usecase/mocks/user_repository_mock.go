@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -48,8 +49,8 @@ func (m *MockUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) List(ctx context.Context, limit, offset int) ([]*entity.User, int64, error) {
-	args := m.Called(ctx, limit, offset)
+func (m *MockUserRepository) List(ctx context.Context, filter repository.UserFilter, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, filter, limit, offset, with)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
@@ -72,12 +73,77 @@ func (m *MockUserRepository) GetWithRolesAndDormitories(ctx context.Context, id
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
-func (m *MockUserRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID) error {
-	args := m.Called(ctx, userID, roleID)
+func (m *MockUserRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID, expiresAt *time.Time) error {
+	args := m.Called(ctx, userID, roleID, expiresAt)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) HasPermanentRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, roleID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) RemoveRole(ctx context.Context, userID, roleID uuid.UUID) error {
 	args := m.Called(ctx, userID, roleID)
 	return args.Error(0)
 }
+
+func (m *MockUserRepository) RevokeExpiredRoles(ctx context.Context, before time.Time) ([]*entity.UserRole, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UserRole), args.Error(1)
+}
+
+func (m *MockUserRepository) GetDormitoryRoles(ctx context.Context, userID uuid.UUID) ([]*entity.UserDormitoryRole, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.UserDormitoryRole), args.Error(1)
+}
+
+func (m *MockUserRepository) AssignDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	args := m.Called(ctx, userID, dormitoryID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RemoveDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	args := m.Called(ctx, userID, dormitoryID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ListAfter(ctx context.Context, filter repository.UserFilter, page repository.KeysetPage, with ...repository.Relation) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, filter, page, with)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) ListDeleted(ctx context.Context, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	args := m.Called(ctx, limit, offset, with)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
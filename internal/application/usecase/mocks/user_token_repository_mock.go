@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// MockUserTokenRepository is a mock implementation of UserTokenRepository
+type MockUserTokenRepository struct {
+	mock.Mock
+}
+
+// Ensure MockUserTokenRepository implements repository.UserTokenRepository
+var _ repository.UserTokenRepository = (*MockUserTokenRepository)(nil)
+
+func (m *MockUserTokenRepository) Create(ctx context.Context, token *entity.UserToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*entity.UserToken, error) {
+	args := m.Called(ctx, purpose, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserToken), args.Error(1)
+}
+
+func (m *MockUserTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserTokenRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+// oidcStateTTL is how long a user has to complete the login at the identity provider
+const oidcStateTTL = 10 * time.Minute
+
+// OIDCUseCase handles login through external OpenID Connect identity providers
+type OIDCUseCase struct {
+	providers    map[string]service.IdentityProvider
+	identityRepo repository.LinkedIdentityRepository
+	stateRepo    repository.OIDCStateRepository
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	authUseCase  *AuthUseCase
+	auditLogger  appService.AuditLogger
+}
+
+// NewOIDCUseCase creates a new OIDC use case.
+// Tokens are issued through authUseCase so that two-factor authentication applies to external logins too.
+func NewOIDCUseCase(
+	providers []service.IdentityProvider,
+	identityRepo repository.LinkedIdentityRepository,
+	stateRepo repository.OIDCStateRepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	authUseCase *AuthUseCase,
+	auditLogger appService.AuditLogger,
+) *OIDCUseCase {
+	byName := make(map[string]service.IdentityProvider, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	return &OIDCUseCase{
+		providers:    byName,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		authUseCase:  authUseCase,
+		auditLogger:  auditLogger,
+	}
+}
+
+// ListProviders returns the names of the configured identity providers
+func (uc *OIDCUseCase) ListProviders() *dto.OIDCProvidersResponse {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &dto.OIDCProvidersResponse{Providers: names}
+}
+
+// Authorize starts a login at an identity provider.
+// The state, nonce and PKCE code verifier are kept server side until the callback.
+func (uc *OIDCUseCase) Authorize(ctx context.Context, providerName string) (*dto.OIDCAuthorizeResponse, error) {
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		return nil, domainErrors.ErrIdentityProviderNotFound
+	}
+
+	state, err := generateToken()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	nonce, err := generateToken()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	codeVerifier, err := generateToken()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallengeS256(codeVerifier))
+	if err != nil {
+		log.Printf("OIDC authorize with %s failed: %v", providerName, err)
+		return nil, domainErrors.ErrExternalAuthFailed
+	}
+
+	// Drop abandoned logins (best-effort)
+	now := time.Now()
+	_ = uc.stateRepo.DeleteExpired(ctx, now)
+
+	loginState := &entity.OIDCLoginState{
+		StateHash:    hashToken(state),
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(oidcStateTTL),
+		CreatedAt:    now,
+	}
+	if err := uc.stateRepo.Create(ctx, loginState); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	return &dto.OIDCAuthorizeResponse{
+		AuthorizationURL: authURL,
+		State:            state,
+		ExpiresAt:        loginState.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// Callback completes a login with the code returned by the identity provider.
+// Unknown identities with an email verified by the provider are linked to the local
+// user with the same verified email, or a new user with the default role is provisioned.
+func (uc *OIDCUseCase) Callback(ctx context.Context, providerName string, req dto.OIDCCallbackRequest) (*dto.AuthResponse, error) {
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		return nil, domainErrors.ErrIdentityProviderNotFound
+	}
+
+	// The state is single-use and bound to the provider it was issued for
+	loginState, err := uc.stateRepo.Consume(ctx, hashToken(req.State))
+	if err != nil || loginState.Provider != providerName || loginState.IsExpired(time.Now()) {
+		return nil, domainErrors.ErrInvalidToken
+	}
+
+	identity, err := provider.Exchange(ctx, req.Code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		log.Printf("OIDC code exchange with %s failed: %v", providerName, err)
+		return nil, domainErrors.ErrExternalAuthFailed
+	}
+
+	user, err := uc.resolveUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, domainErrors.ErrUserInactive
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:oidc_login", user.ID.String(), map[string]string{
+		"provider": identity.Provider,
+		"subject":  identity.Subject,
+	})
+
+	return uc.authUseCase.completeLogin(ctx, user)
+}
+
+// resolveUser finds the user for an external identity, linking or provisioning one when needed
+func (uc *OIDCUseCase) resolveUser(ctx context.Context, identity *service.ExternalIdentity) (*entity.User, error) {
+	linked, err := uc.identityRepo.GetByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if linked != nil {
+		user, err := uc.userRepo.GetByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, domainErrors.ErrUserNotFound
+		}
+		_ = uc.identityRepo.UpdateLastLogin(ctx, linked.ID, time.Now())
+		return user, nil
+	}
+
+	if identity.Email == "" {
+		return nil, domainErrors.ErrExternalAuthFailed
+	}
+
+	// An unverified email proves nothing: provisioning under it would let anyone
+	// claim an address before its owner signs up
+	if !identity.EmailVerified {
+		return nil, domainErrors.ErrExternalEmailNotVerified
+	}
+
+	user, _ := uc.userRepo.GetByEmail(ctx, identity.Email)
+	if user != nil {
+		// Both sides must have proven ownership of the email before they are linked
+		if !user.IsEmailVerified() {
+			return nil, domainErrors.ErrUserAlreadyExists
+		}
+		if err := uc.linkIdentity(ctx, user, identity); err != nil {
+			return nil, err
+		}
+
+		// Audit log (best-effort)
+		_ = uc.auditLogger.Log(ctx, "auth", "auth:oidc_link", user.ID.String(), map[string]string{
+			"provider": identity.Provider,
+			"subject":  identity.Subject,
+		})
+		return user, nil
+	}
+
+	user, err = uc.provisionUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.linkIdentity(ctx, user, identity); err != nil {
+		return nil, err
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "auth", "auth:oidc_provision", user.ID.String(), map[string]string{
+		"provider": identity.Provider,
+		"subject":  identity.Subject,
+		"email":    user.Email,
+	})
+
+	return user, nil
+}
+
+// provisionUser creates a user for an external identity with the default role.
+// The random password is never disclosed; the user can set one with the password reset flow.
+func (uc *OIDCUseCase) provisionUser(ctx context.Context, identity *service.ExternalIdentity) (*entity.User, error) {
+	password, err := generateToken()
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	now := time.Now()
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     identity.Email,
+		Password:  password,
+		Name:      name,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+		// Only identities with an email verified by the provider are provisioned
+		EmailVerifiedAt: &now,
+	}
+
+	// Hash password
+	if err := user.HashPassword(); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Assign default role (user role)
+	defaultRole, err := uc.roleRepo.GetBySlug(ctx, entity.DefaultRoleSlug)
+	if err == nil && defaultRole != nil {
+		user.Roles = []entity.Role{*defaultRole}
+	}
+
+	// Save user
+	if err := uc.userRepo.Create(ctx, user); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	return user, nil
+}
+
+// linkIdentity records the external identity for a user
+func (uc *OIDCUseCase) linkIdentity(ctx context.Context, user *entity.User, identity *service.ExternalIdentity) error {
+	now := time.Now()
+	err := uc.identityRepo.Create(ctx, &entity.LinkedIdentity{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	return nil
+}
+
+// codeChallengeS256 derives the PKCE code challenge from a code verifier (RFC 7636)
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
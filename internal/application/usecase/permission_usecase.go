@@ -2,19 +2,245 @@ package usecase
 
 import (
 	"context"
+	"sort"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
-// PermissionUseCase handles read-only permission use cases
+// PermissionUseCase handles permission management use cases
 type PermissionUseCase struct {
-	permissionRepo repository.PermissionRepository
+	permissionRepo     repository.PermissionRepository
+	permissionRegistry *appService.PermissionRegistry
+	auditLogger        appService.AuditLogger
+	txManager          repository.TransactionManager
+}
+
+// PermissionSyncResult reports the outcome of syncing the permission registry with storage
+type PermissionSyncResult struct {
+	// Created lists the registered permissions that were missing from storage
+	Created []string
+	// Orphaned lists the stored permissions that no route requires and no code checks
+	Orphaned []string
 }
 
 // NewPermissionUseCase creates a new permission use case
-func NewPermissionUseCase(permissionRepo repository.PermissionRepository) *PermissionUseCase {
-	return &PermissionUseCase{permissionRepo: permissionRepo}
+func NewPermissionUseCase(
+	permissionRepo repository.PermissionRepository,
+	permissionRegistry *appService.PermissionRegistry,
+	auditLogger appService.AuditLogger,
+	txManager repository.TransactionManager,
+) *PermissionUseCase {
+	return &PermissionUseCase{
+		permissionRepo:     permissionRepo,
+		permissionRegistry: permissionRegistry,
+		auditLogger:        auditLogger,
+		txManager:          txManager,
+	}
+}
+
+// SyncPermissions creates the permissions required by registered routes that
+// are missing from storage and reports stored permissions that cover nothing.
+// Missing permissions are created in one transaction, all or none.
+// Orphans are only reported, never deleted.
+func (uc *PermissionUseCase) SyncPermissions(ctx context.Context) (*PermissionSyncResult, error) {
+	result := &PermissionSyncResult{}
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		stored, err := uc.permissionRepo.ListAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		storedNames := make(map[string]bool, len(stored))
+		for _, permission := range stored {
+			storedNames[permission.Name] = true
+			if !uc.permissionRegistry.Covers(permission.Name) {
+				result.Orphaned = append(result.Orphaned, permission.Name)
+			}
+		}
+
+		for _, name := range uc.permissionRegistry.Permissions() {
+			if storedNames[name] {
+				continue
+			}
+
+			permission := &entity.Permission{
+				ID:        uuid.New(),
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+			if err := setPermissionName(permission, name, ""); err != nil {
+				return err
+			}
+			if err := uc.permissionRepo.Create(ctx, permission); err != nil {
+				return err
+			}
+			result.Created = append(result.Created, name)
+
+			// Audit log (best-effort, in its own savepoint)
+			auditWithinTx(ctx, uc.txManager, uc.auditLogger, "permission", "permission:create", permission.ID.String(), map[string]string{
+				"name":   permission.Name,
+				"slug":   permission.Slug,
+				"source": "registry",
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing was created
+		result.Created = nil
+		return result, err
+	}
+
+	return result, nil
+}
+
+// GetPermissionCatalog lists every stored or registered permission with the endpoints it unlocks
+func (uc *PermissionUseCase) GetPermissionCatalog(ctx context.Context) ([]dto.PermissionCatalogEntry, error) {
+	stored, err := uc.permissionRepo.ListAll(ctx)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	slugs := make(map[string]string, len(stored))
+	for _, permission := range stored {
+		slugs[permission.Name] = permission.Slug
+	}
+	for _, name := range uc.permissionRegistry.Permissions() {
+		if _, exists := slugs[name]; !exists {
+			slugs[name] = entity.PermissionSlug(name)
+		}
+	}
+
+	names := make([]string, 0, len(slugs))
+	for name := range slugs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]dto.PermissionCatalogEntry, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, dto.PermissionCatalogEntry{
+			Name:      name,
+			Slug:      slugs[name],
+			Endpoints: uc.permissionRegistry.Endpoints(name),
+			Orphaned:  !uc.permissionRegistry.Covers(name),
+		})
+	}
+
+	return entries, nil
+}
+
+// CreatePermission creates a new permission named "resource:action"
+func (uc *PermissionUseCase) CreatePermission(ctx context.Context, req dto.CreatePermissionRequest) (*dto.PermissionResponse, error) {
+	permission := &entity.Permission{
+		ID:        uuid.New(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := setPermissionName(permission, req.Name, req.Slug); err != nil {
+		return nil, err
+	}
+
+	// Check if permission with same name or slug already exists
+	if err := uc.checkPermissionUnique(ctx, permission); err != nil {
+		return nil, err
+	}
+
+	// Save permission
+	if err := uc.permissionRepo.Create(ctx, permission); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "permission", "permission:create", permission.ID.String(), map[string]string{
+		"name": permission.Name,
+		"slug": permission.Slug,
+	})
+
+	return uc.toPermissionResponse(permission), nil
+}
+
+// UpdatePermission renames a permission.
+// Permissions assigned to a protected role cannot be renamed, as that would
+// change the permissions of the role.
+func (uc *PermissionUseCase) UpdatePermission(ctx context.Context, id uuid.UUID, req dto.UpdatePermissionRequest) (*dto.PermissionResponse, error) {
+	// Get existing permission
+	permission, err := uc.permissionRepo.GetWithRoles(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrPermissionNotFound
+	}
+
+	previousName := permission.Name
+	name := permission.Name
+	if req.Name != "" {
+		name = req.Name
+	}
+
+	if name != previousName {
+		if assignedToProtectedRole(permission) {
+			return nil, domainErrors.ErrPermissionInUse
+		}
+		if err := setPermissionName(permission, name, req.Slug); err != nil {
+			return nil, err
+		}
+		if err := uc.checkPermissionUnique(ctx, permission); err != nil {
+			return nil, err
+		}
+	} else if req.Slug != "" && req.Slug != permission.Slug {
+		return nil, domainErrors.ErrPermissionSlugMismatch
+	}
+
+	permission.UpdatedAt = time.Now()
+	// Roles were only loaded for the check above, do not save them along
+	permission.Roles = nil
+
+	// Save updated permission
+	if err := uc.permissionRepo.Update(ctx, permission); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "permission", "permission:update", permission.ID.String(), map[string]string{
+		"name":          permission.Name,
+		"slug":          permission.Slug,
+		"previous_name": previousName,
+	})
+
+	return uc.toPermissionResponse(permission), nil
+}
+
+// DeletePermission deletes a permission and removes it from the roles it is assigned to.
+// Permissions assigned to a protected role cannot be deleted.
+func (uc *PermissionUseCase) DeletePermission(ctx context.Context, id uuid.UUID) error {
+	// Check if permission exists
+	permission, err := uc.permissionRepo.GetWithRoles(ctx, id)
+	if err != nil {
+		return domainErrors.ErrPermissionNotFound
+	}
+
+	// Prevent deletion of permissions protected roles depend on
+	if assignedToProtectedRole(permission) {
+		return domainErrors.ErrPermissionInUse
+	}
+
+	// Delete permission
+	if err := uc.permissionRepo.Delete(ctx, id); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "permission", "permission:delete", id.String(), map[string]string{
+		"name": permission.Name,
+		"slug": permission.Slug,
+	})
+
+	return nil
 }
 
 // ListPermissions retrieves a paginated list of permissions
@@ -38,13 +264,7 @@ func (uc *PermissionUseCase) ListPermissions(ctx context.Context, page, pageSize
 
 	items := make([]dto.PermissionResponse, 0, len(permissions))
 	for _, p := range permissions {
-		items = append(items, dto.PermissionResponse{
-			ID:       p.ID.String(),
-			Name:     p.Name,
-			Slug:     p.Slug,
-			Resource: p.Resource,
-			Action:   p.Action,
-		})
+		items = append(items, *uc.toPermissionResponse(p))
 	}
 
 	totalPages := int(total) / pageSize
@@ -60,3 +280,55 @@ func (uc *PermissionUseCase) ListPermissions(ctx context.Context, page, pageSize
 		TotalPages:  totalPages,
 	}, nil
 }
+
+// checkPermissionUnique checks that no other permission uses the same name or slug
+func (uc *PermissionUseCase) checkPermissionUnique(ctx context.Context, permission *entity.Permission) error {
+	if existing, _ := uc.permissionRepo.GetByName(ctx, permission.Name); existing != nil && existing.ID != permission.ID {
+		return domainErrors.ErrPermissionAlreadyExists
+	}
+	if existing, _ := uc.permissionRepo.GetBySlug(ctx, permission.Slug); existing != nil && existing.ID != permission.ID {
+		return domainErrors.ErrPermissionAlreadyExists
+	}
+	return nil
+}
+
+// setPermissionName validates a "resource:action" name and sets the name, slug,
+// resource and action of the permission. A given slug must match the name.
+func setPermissionName(permission *entity.Permission, name, slug string) error {
+	resource, action, ok := entity.ParsePermissionName(name)
+	if !ok {
+		return domainErrors.ErrInvalidPermissionName
+	}
+
+	expectedSlug := entity.PermissionSlug(name)
+	if slug != "" && slug != expectedSlug {
+		return domainErrors.ErrPermissionSlugMismatch
+	}
+
+	permission.Name = name
+	permission.Slug = expectedSlug
+	permission.Resource = resource
+	permission.Action = action
+	return nil
+}
+
+// assignedToProtectedRole checks if a permission loaded with its roles belongs to a protected role
+func assignedToProtectedRole(permission *entity.Permission) bool {
+	for _, role := range permission.Roles {
+		if role.IsProtected {
+			return true
+		}
+	}
+	return false
+}
+
+// toPermissionResponse converts entity.Permission to dto.PermissionResponse
+func (uc *PermissionUseCase) toPermissionResponse(permission *entity.Permission) *dto.PermissionResponse {
+	return &dto.PermissionResponse{
+		ID:       permission.ID.String(),
+		Name:     permission.Name,
+		Slug:     permission.Slug,
+		Resource: permission.Resource,
+		Action:   permission.Action,
+	}
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+var errRecordNotFound = errors.New("record not found")
+
+func TestPermissionUseCase_CreatePermission(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           dto.CreatePermissionRequest
+		setupMocks    func(*mocks.MockPermissionRepository)
+		expectedError error
+		expectedSlug  string
+	}{
+		{
+			name: "success - slug derived from name",
+			req:  dto.CreatePermissionRequest{Name: "room:access_all"},
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByName", mock.Anything, "room:access_all").Return(nil, errRecordNotFound)
+				permissionRepo.On("GetBySlug", mock.Anything, "room-access-all").Return(nil, errRecordNotFound)
+				permissionRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Permission) bool {
+					return p.Resource == "room" && p.Action == "access_all"
+				})).Return(nil)
+			},
+			expectedError: nil,
+			expectedSlug:  "room-access-all",
+		},
+		{
+			name:          "error - name not in resource:action format",
+			req:           dto.CreatePermissionRequest{Name: "Room Read"},
+			setupMocks:    func(permissionRepo *mocks.MockPermissionRepository) {},
+			expectedError: domainErrors.ErrInvalidPermissionName,
+		},
+		{
+			name:          "error - slug does not match name",
+			req:           dto.CreatePermissionRequest{Name: "room:read", Slug: "room-view"},
+			setupMocks:    func(permissionRepo *mocks.MockPermissionRepository) {},
+			expectedError: domainErrors.ErrPermissionSlugMismatch,
+		},
+		{
+			name: "error - name already exists",
+			req:  dto.CreatePermissionRequest{Name: "room:read", Slug: "room-read"},
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByName", mock.Anything, "room:read").Return(&entity.Permission{ID: uuid.New(), Name: "room:read"}, nil)
+			},
+			expectedError: domainErrors.ErrPermissionAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionRepo := new(mocks.MockPermissionRepository)
+			auditLogger := &recordingAuditLogger{}
+			tt.setupMocks(permissionRepo)
+
+			uc := NewPermissionUseCase(permissionRepo, appService.NewPermissionRegistry(), auditLogger, &inlineTxManager{})
+			resp, err := uc.CreatePermission(context.Background(), tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSlug, resp.Slug)
+				assert.Equal(t, []string{"permission:create"}, auditLogger.actions)
+			}
+			permissionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPermissionUseCase_UpdatePermission(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		req           dto.UpdatePermissionRequest
+		roles         []entity.Role
+		setupMocks    func(*mocks.MockPermissionRepository)
+		expectedError error
+	}{
+		{
+			name:  "success - rename updates slug, resource and action",
+			req:   dto.UpdatePermissionRequest{Name: "room:update"},
+			roles: []entity.Role{{Slug: "warden"}},
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByName", mock.Anything, "room:update").Return(nil, errRecordNotFound)
+				permissionRepo.On("GetBySlug", mock.Anything, "room-update").Return(nil, errRecordNotFound)
+				permissionRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *entity.Permission) bool {
+					return p.Slug == "room-update" && p.Action == "update" && p.Roles == nil
+				})).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "error - rename permission of protected role",
+			req:           dto.UpdatePermissionRequest{Name: "room:update"},
+			roles:         []entity.Role{{Slug: "admin", IsProtected: true}},
+			setupMocks:    func(permissionRepo *mocks.MockPermissionRepository) {},
+			expectedError: domainErrors.ErrPermissionInUse,
+		},
+		{
+			name:          "error - slug does not match current name",
+			req:           dto.UpdatePermissionRequest{Slug: "room-view"},
+			setupMocks:    func(permissionRepo *mocks.MockPermissionRepository) {},
+			expectedError: domainErrors.ErrPermissionSlugMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionRepo := new(mocks.MockPermissionRepository)
+			auditLogger := &recordingAuditLogger{}
+			permissionRepo.On("GetWithRoles", mock.Anything, id).Return(&entity.Permission{
+				ID:       id,
+				Name:     "room:read",
+				Slug:     "room-read",
+				Resource: "room",
+				Action:   "read",
+				Roles:    tt.roles,
+			}, nil)
+			tt.setupMocks(permissionRepo)
+
+			uc := NewPermissionUseCase(permissionRepo, appService.NewPermissionRegistry(), auditLogger, &inlineTxManager{})
+			resp, err := uc.UpdatePermission(context.Background(), id, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "room:update", resp.Name)
+				assert.Equal(t, []string{"permission:update"}, auditLogger.actions)
+			}
+			permissionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPermissionUseCase_DeletePermission(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name            string
+		setupMocks      func(*mocks.MockPermissionRepository)
+		expectedError   error
+		expectedActions []string
+	}{
+		{
+			name: "success - permission of unprotected roles",
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetWithRoles", mock.Anything, id).Return(&entity.Permission{ID: id, Name: "room:read", Roles: []entity.Role{{Slug: "warden"}}}, nil)
+				permissionRepo.On("Delete", mock.Anything, id).Return(nil)
+			},
+			expectedError:   nil,
+			expectedActions: []string{"permission:delete"},
+		},
+		{
+			name: "error - permission of protected role",
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetWithRoles", mock.Anything, id).Return(&entity.Permission{ID: id, Name: "user:read", Roles: []entity.Role{{Slug: "admin", IsProtected: true}}}, nil)
+			},
+			expectedError: domainErrors.ErrPermissionInUse,
+		},
+		{
+			name: "error - permission not found",
+			setupMocks: func(permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetWithRoles", mock.Anything, id).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrPermissionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			permissionRepo := new(mocks.MockPermissionRepository)
+			auditLogger := &recordingAuditLogger{}
+			tt.setupMocks(permissionRepo)
+
+			uc := NewPermissionUseCase(permissionRepo, appService.NewPermissionRegistry(), auditLogger, &inlineTxManager{})
+			err := uc.DeletePermission(context.Background(), id)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedActions, auditLogger.actions)
+			permissionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPermissionUseCase_SyncPermissions(t *testing.T) {
+	registry := appService.NewPermissionRegistry()
+	registry.RegisterRoute("user:read", "GET", "/api/users/:id/dormitory-roles")
+	registry.RegisterRoute("user:update", "PUT", "/api/users/:id")
+	registry.RegisterRoute("room:read", "GET", "/api/rooms")
+
+	permissionRepo := new(mocks.MockPermissionRepository)
+	permissionRepo.On("ListAll", mock.Anything).Return([]*entity.Permission{
+		{ID: uuid.New(), Name: "user:read"},
+		{ID: uuid.New(), Name: "user:*"},
+		{ID: uuid.New(), Name: "report:export"},
+	}, nil)
+	permissionRepo.On("Create", mock.Anything, mock.MatchedBy(func(p *entity.Permission) bool {
+		return p.Slug == "user-update" || p.Slug == "room-read"
+	})).Return(nil).Twice()
+	auditLogger := &recordingAuditLogger{}
+
+	uc := NewPermissionUseCase(permissionRepo, registry, auditLogger, &inlineTxManager{})
+	result, err := uc.SyncPermissions(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"room:read", "user:update"}, result.Created)
+	assert.Equal(t, []string{"report:export"}, result.Orphaned)
+	assert.Equal(t, []string{"permission:create", "permission:create"}, auditLogger.actions)
+	permissionRepo.AssertExpectations(t)
+}
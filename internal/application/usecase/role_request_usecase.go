@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// RoleRequestUseCase handles the request/approve workflow for role grants
+type RoleRequestUseCase struct {
+	roleRequestRepo repository.RoleRequestRepository
+	userRepo        repository.UserRepository
+	roleRepo        repository.RoleRepository
+	auditLogger     appService.AuditLogger
+	txManager       repository.TransactionManager
+}
+
+// NewRoleRequestUseCase creates a new role request use case
+func NewRoleRequestUseCase(
+	roleRequestRepo repository.RoleRequestRepository,
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	auditLogger appService.AuditLogger,
+	txManager repository.TransactionManager,
+) *RoleRequestUseCase {
+	return &RoleRequestUseCase{
+		roleRequestRepo: roleRequestRepo,
+		userRepo:        userRepo,
+		roleRepo:        roleRepo,
+		auditLogger:     auditLogger,
+		txManager:       txManager,
+	}
+}
+
+// RequestRole creates a pending request of a user for a role
+func (uc *RoleRequestUseCase) RequestRole(ctx context.Context, userID uuid.UUID, req dto.RequestRoleRequest) (*dto.RoleRequestResponse, error) {
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		return nil, domainErrors.ErrRoleNotFound
+	}
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
+	if err != nil || !role.IsActive {
+		return nil, domainErrors.ErrRoleNotFound
+	}
+
+	// A permanent grant cannot be improved by a request
+	held, err := uc.userRepo.HasPermanentRole(ctx, userID, roleID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if held {
+		return nil, domainErrors.ErrRoleAlreadyHeld
+	}
+
+	// Only one pending request per role
+	pending, err := uc.roleRequestRepo.HasPending(ctx, userID, roleID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+	if pending {
+		return nil, domainErrors.ErrRoleRequestExists
+	}
+
+	request := &entity.RoleRequest{
+		ID:        uuid.New(),
+		UserID:    userID,
+		RoleID:    roleID,
+		Reason:    req.Reason,
+		Status:    entity.RoleRequestPending,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Role:      *role,
+	}
+	if err := uc.roleRequestRepo.Create(ctx, request); err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "role_request", "role_request:create", request.ID.String(), uc.auditMetadata(request))
+
+	return uc.toRoleRequestResponse(request), nil
+}
+
+// ListMyRoleRequests lists the role requests of a user, newest first
+func (uc *RoleRequestUseCase) ListMyRoleRequests(ctx context.Context, userID uuid.UUID) ([]dto.RoleRequestResponse, error) {
+	requests, err := uc.roleRequestRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.RoleRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, *uc.toRoleRequestResponse(request))
+	}
+	return responses, nil
+}
+
+// ListRoleRequests retrieves a paginated list of role requests, optionally filtered by status
+func (uc *RoleRequestUseCase) ListRoleRequests(ctx context.Context, status string, page, pageSize int) (*dto.ListRoleRequestsResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	requests, total, err := uc.roleRequestRepo.List(ctx, entity.RoleRequestStatus(status), pageSize, offset)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.RoleRequestResponse, 0, len(requests))
+	for _, request := range requests {
+		responses = append(responses, *uc.toRoleRequestResponse(request))
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return &dto.ListRoleRequestsResponse{
+		RoleRequests: responses,
+		Total:        total,
+		Page:         page,
+		PageSize:     pageSize,
+		TotalPages:   totalPages,
+	}, nil
+}
+
+// ApproveRoleRequest approves a pending request and grants the role to the
+// requester, until the requested or overridden expiry if any
+func (uc *RoleRequestUseCase) ApproveRoleRequest(ctx context.Context, id, reviewerID uuid.UUID, req dto.ReviewRoleRequestRequest) (*dto.RoleRequestResponse, error) {
+	request, err := uc.getReviewableRequest(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil {
+		request.ExpiresAt = req.ExpiresAt
+	}
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return nil, domainErrors.ErrBadRequest
+	}
+
+	// The request is only marked approved if the role is granted too
+	err = withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		if err := uc.review(ctx, request, entity.RoleRequestApproved, reviewerID, req.Note, now); err != nil {
+			return err
+		}
+		if err := uc.userRepo.AssignRole(ctx, request.UserID, request.RoleID, request.ExpiresAt); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "role_request", "role_request:approve", request.ID.String(), uc.auditMetadata(request))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toRoleRequestResponse(request), nil
+}
+
+// DenyRoleRequest denies a pending request
+func (uc *RoleRequestUseCase) DenyRoleRequest(ctx context.Context, id, reviewerID uuid.UUID, req dto.ReviewRoleRequestRequest) (*dto.RoleRequestResponse, error) {
+	request, err := uc.getReviewableRequest(ctx, id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.review(ctx, request, entity.RoleRequestDenied, reviewerID, req.Note, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "role_request", "role_request:deny", request.ID.String(), uc.auditMetadata(request))
+
+	return uc.toRoleRequestResponse(request), nil
+}
+
+// getReviewableRequest loads a pending request that the reviewer may review
+func (uc *RoleRequestUseCase) getReviewableRequest(ctx context.Context, id, reviewerID uuid.UUID) (*entity.RoleRequest, error) {
+	request, err := uc.roleRequestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domainErrors.ErrRoleRequestNotFound
+	}
+	if !request.IsPending() {
+		return nil, domainErrors.ErrRoleRequestNotPending
+	}
+	if request.UserID == reviewerID {
+		return nil, domainErrors.ErrOwnRoleRequest
+	}
+	return request, nil
+}
+
+// review records the decision on a request, failing if another reviewer decided first
+func (uc *RoleRequestUseCase) review(ctx context.Context, request *entity.RoleRequest, status entity.RoleRequestStatus, reviewerID uuid.UUID, note string, now time.Time) error {
+	request.Status = status
+	request.ReviewerID = &reviewerID
+	request.ReviewNote = note
+	request.ReviewedAt = &now
+	request.UpdatedAt = now
+
+	reviewed, err := uc.roleRequestRepo.Review(ctx, request)
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	if !reviewed {
+		return domainErrors.ErrRoleRequestNotPending
+	}
+	return nil
+}
+
+// auditMetadata describes a request for the audit log
+func (uc *RoleRequestUseCase) auditMetadata(request *entity.RoleRequest) map[string]string {
+	metadata := map[string]string{
+		"user_id":   request.UserID.String(),
+		"role_id":   request.RoleID.String(),
+		"role_slug": request.Role.Slug,
+		"reason":    request.Reason,
+	}
+	if request.ExpiresAt != nil {
+		metadata["expires_at"] = request.ExpiresAt.Format(time.RFC3339)
+	}
+	if request.ReviewNote != "" {
+		metadata["review_note"] = request.ReviewNote
+	}
+	return metadata
+}
+
+// toRoleRequestResponse converts role request entity to response DTO
+func (uc *RoleRequestUseCase) toRoleRequestResponse(request *entity.RoleRequest) *dto.RoleRequestResponse {
+	resp := &dto.RoleRequestResponse{
+		ID:         request.ID.String(),
+		UserID:     request.UserID.String(),
+		UserEmail:  request.User.Email,
+		RoleID:     request.RoleID.String(),
+		RoleSlug:   request.Role.Slug,
+		Reason:     request.Reason,
+		Status:     string(request.Status),
+		ReviewNote: request.ReviewNote,
+		CreatedAt:  request.CreatedAt.Format(time.RFC3339),
+	}
+	if request.ExpiresAt != nil {
+		resp.ExpiresAt = request.ExpiresAt.Format(time.RFC3339)
+	}
+	if request.ReviewerID != nil {
+		resp.ReviewerID = request.ReviewerID.String()
+	}
+	if request.ReviewedAt != nil {
+		resp.ReviewedAt = request.ReviewedAt.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+func TestRoleRequestUseCase_RequestRole(t *testing.T) {
+	userID := uuid.New()
+	roleID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		req           dto.RequestRoleRequest
+		setupMocks    func(*mocks.MockRoleRequestRepository, *mocks.MockRoleRepository, *mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name: "success",
+			req:  dto.RequestRoleRequest{RoleID: roleID.String(), Reason: "Covering night shifts"},
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID, Slug: "staff", IsActive: true}, nil)
+				userRepo.On("HasPermanentRole", mock.Anything, userID, roleID).Return(false, nil)
+				requestRepo.On("HasPending", mock.Anything, userID, roleID).Return(false, nil)
+				requestRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *entity.RoleRequest) bool {
+					return r.UserID == userID && r.RoleID == roleID && r.IsPending()
+				})).Return(nil)
+			},
+		},
+		{
+			name: "error - pending request exists",
+			req:  dto.RequestRoleRequest{RoleID: roleID.String(), Reason: "Again"},
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID, Slug: "staff", IsActive: true}, nil)
+				userRepo.On("HasPermanentRole", mock.Anything, userID, roleID).Return(false, nil)
+				requestRepo.On("HasPending", mock.Anything, userID, roleID).Return(true, nil)
+			},
+			expectedError: domainErrors.ErrRoleRequestExists,
+		},
+		{
+			name: "error - role already held permanently",
+			req:  dto.RequestRoleRequest{RoleID: roleID.String(), Reason: "Temporary access", ExpiresAt: &future},
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID, Slug: "staff", IsActive: true}, nil)
+				userRepo.On("HasPermanentRole", mock.Anything, userID, roleID).Return(true, nil)
+			},
+			expectedError: domainErrors.ErrRoleAlreadyHeld,
+		},
+		{
+			name: "error - role not found",
+			req:  dto.RequestRoleRequest{RoleID: roleID.String(), Reason: "Missing"},
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, roleRepo *mocks.MockRoleRepository, userRepo *mocks.MockUserRepository) {
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrRoleNotFound,
+		},
+		{
+			name:          "error - expiry in the past",
+			req:           dto.RequestRoleRequest{RoleID: roleID.String(), Reason: "Late", ExpiresAt: &past},
+			setupMocks:    func(*mocks.MockRoleRequestRepository, *mocks.MockRoleRepository, *mocks.MockUserRepository) {},
+			expectedError: domainErrors.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestRepo := new(mocks.MockRoleRequestRepository)
+			roleRepo := new(mocks.MockRoleRepository)
+			userRepo := new(mocks.MockUserRepository)
+			tt.setupMocks(requestRepo, roleRepo, userRepo)
+			auditLogger := &recordingAuditLogger{}
+
+			uc := NewRoleRequestUseCase(requestRepo, userRepo, roleRepo, auditLogger, &inlineTxManager{})
+			resp, err := uc.RequestRole(context.Background(), userID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, string(entity.RoleRequestPending), resp.Status)
+				assert.Equal(t, "staff", resp.RoleSlug)
+				assert.Equal(t, []string{"role_request:create"}, auditLogger.actions)
+			}
+			requestRepo.AssertExpectations(t)
+			roleRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleRequestUseCase_ApproveRoleRequest(t *testing.T) {
+	requestID := uuid.New()
+	userID := uuid.New()
+	reviewerID := uuid.New()
+	roleID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	pending := func() *entity.RoleRequest {
+		return &entity.RoleRequest{
+			ID:     requestID,
+			UserID: userID,
+			RoleID: roleID,
+			Status: entity.RoleRequestPending,
+			Role:   entity.Role{ID: roleID, Slug: "staff"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		reviewerID    uuid.UUID
+		req           dto.ReviewRoleRequestRequest
+		setupMocks    func(*mocks.MockRoleRequestRepository, *mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name:       "success - grants role until the approved expiry",
+			reviewerID: reviewerID,
+			req:        dto.ReviewRoleRequestRequest{Note: "For the semester", ExpiresAt: &expiresAt},
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(pending(), nil)
+				requestRepo.On("Review", mock.Anything, mock.MatchedBy(func(r *entity.RoleRequest) bool {
+					return r.Status == entity.RoleRequestApproved && *r.ReviewerID == reviewerID
+				})).Return(true, nil)
+				userRepo.On("AssignRole", mock.Anything, userID, roleID, &expiresAt).Return(nil)
+			},
+		},
+		{
+			// The review is recorded before the grant fails; both are undone together
+			name:       "error - role grant fails",
+			reviewerID: reviewerID,
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(pending(), nil)
+				requestRepo.On("Review", mock.Anything, mock.Anything).Return(true, nil)
+				userRepo.On("AssignRole", mock.Anything, userID, roleID, (*time.Time)(nil)).Return(errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrInternalServer,
+		},
+		{
+			name:       "error - already reviewed",
+			reviewerID: reviewerID,
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				request := pending()
+				request.Status = entity.RoleRequestDenied
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(request, nil)
+			},
+			expectedError: domainErrors.ErrRoleRequestNotPending,
+		},
+		{
+			name:       "error - reviewed concurrently",
+			reviewerID: reviewerID,
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(pending(), nil)
+				requestRepo.On("Review", mock.Anything, mock.Anything).Return(false, nil)
+			},
+			expectedError: domainErrors.ErrRoleRequestNotPending,
+		},
+		{
+			name:       "error - own request",
+			reviewerID: userID,
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(pending(), nil)
+			},
+			expectedError: domainErrors.ErrOwnRoleRequest,
+		},
+		{
+			name:       "error - not found",
+			reviewerID: reviewerID,
+			setupMocks: func(requestRepo *mocks.MockRoleRequestRepository, userRepo *mocks.MockUserRepository) {
+				requestRepo.On("GetByID", mock.Anything, requestID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrRoleRequestNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestRepo := new(mocks.MockRoleRequestRepository)
+			userRepo := new(mocks.MockUserRepository)
+			tt.setupMocks(requestRepo, userRepo)
+			auditLogger := &recordingAuditLogger{}
+
+			uc := NewRoleRequestUseCase(requestRepo, userRepo, new(mocks.MockRoleRepository), auditLogger, &inlineTxManager{})
+			resp, err := uc.ApproveRoleRequest(context.Background(), requestID, tt.reviewerID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, string(entity.RoleRequestApproved), resp.Status)
+				assert.Equal(t, expiresAt.Format(time.RFC3339), resp.ExpiresAt)
+				assert.Equal(t, []string{"role_request:approve"}, auditLogger.actions)
+			}
+			requestRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleRequestUseCase_DenyRoleRequest(t *testing.T) {
+	requestID := uuid.New()
+	reviewerID := uuid.New()
+	requestRepo := new(mocks.MockRoleRequestRepository)
+	userRepo := new(mocks.MockUserRepository)
+	requestRepo.On("GetByID", mock.Anything, requestID).Return(&entity.RoleRequest{
+		ID:     requestID,
+		UserID: uuid.New(),
+		RoleID: uuid.New(),
+		Status: entity.RoleRequestPending,
+	}, nil)
+	requestRepo.On("Review", mock.Anything, mock.MatchedBy(func(r *entity.RoleRequest) bool {
+		return r.Status == entity.RoleRequestDenied && r.ReviewNote == "Not needed"
+	})).Return(true, nil)
+	auditLogger := &recordingAuditLogger{}
+
+	uc := NewRoleRequestUseCase(requestRepo, userRepo, new(mocks.MockRoleRepository), auditLogger, &inlineTxManager{})
+	resp, err := uc.DenyRoleRequest(context.Background(), requestID, reviewerID, dto.ReviewRoleRequestRequest{Note: "Not needed"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(entity.RoleRequestDenied), resp.Status)
+	assert.Equal(t, []string{"role_request:deny"}, auditLogger.actions)
+	// Denial never grants the role
+	userRepo.AssertNotCalled(t, "AssignRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ type RoleUseCase struct {
 	roleRepo       repository.RoleRepository
 	permissionRepo repository.PermissionRepository
 	auditLogger    appService.AuditLogger
+	txManager      repository.TransactionManager
 }
 
 // NewRoleUseCase creates a new role use case
@@ -25,66 +27,82 @@ func NewRoleUseCase(
 	roleRepo repository.RoleRepository,
 	permissionRepo repository.PermissionRepository,
 	auditLogger appService.AuditLogger,
+	txManager repository.TransactionManager,
 ) *RoleUseCase {
 	return &RoleUseCase{
 		roleRepo:       roleRepo,
 		permissionRepo: permissionRepo,
 		auditLogger:    auditLogger,
+		txManager:      txManager,
 	}
 }
 
-// CreateRole creates a new role
+// CreateRole creates a new role with its permissions in one transaction
 func (uc *RoleUseCase) CreateRole(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
-	// Check if role with same slug already exists
-	existingRole, _ := uc.roleRepo.GetBySlug(ctx, req.Slug)
-	if existingRole != nil {
-		return nil, domainErrors.ErrRoleAlreadyExists
-	}
+	var roleWithPerms *entity.Role
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		// Check if role with same slug already exists
+		existingRole, _ := uc.roleRepo.GetBySlug(ctx, req.Slug)
+		if existingRole != nil {
+			return domainErrors.ErrRoleAlreadyExists
+		}
 
-	// Create role
-	role := &entity.Role{
-		ID:          uuid.New(),
-		Name:        req.Name,
-		Slug:        strings.ToLower(req.Slug),
-		IsActive:    req.IsActive,
-		IsProtected: req.IsProtected,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
+		// Create role
+		roleID := uuid.New()
+		parentID, err := uc.resolveParentRole(ctx, roleID, req.ParentID)
+		if err != nil {
+			return err
+		}
+		role := &entity.Role{
+			ID:          roleID,
+			Name:        req.Name,
+			Slug:        strings.ToLower(req.Slug),
+			IsActive:    req.IsActive,
+			IsProtected: req.IsProtected,
+			RequireMFA:  req.RequireMFA,
+			ParentID:    parentID,
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		}
 
-	// Assign permissions if provided
-	if len(req.PermissionIDs) > 0 {
-		permissions := make([]entity.Permission, 0)
-		for _, permIDStr := range req.PermissionIDs {
-			permID, err := uuid.Parse(permIDStr)
-			if err != nil {
-				continue
-			}
-			permission, err := uc.permissionRepo.GetByID(ctx, permID)
-			if err != nil {
-				continue
+		// Assign permissions if provided
+		if len(req.PermissionIDs) > 0 {
+			permissions := make([]entity.Permission, 0)
+			for _, permIDStr := range req.PermissionIDs {
+				permID, err := uuid.Parse(permIDStr)
+				if err != nil {
+					continue
+				}
+				permission, err := uc.permissionRepo.GetByID(ctx, permID)
+				if err != nil {
+					continue
+				}
+				permissions = append(permissions, *permission)
 			}
-			permissions = append(permissions, *permission)
+			role.Permissions = permissions
 		}
-		role.Permissions = permissions
-	}
 
-	// Save role
-	if err := uc.roleRepo.Create(ctx, role); err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Save role
+		if err := uc.roleRepo.Create(ctx, role); err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Get role with permissions
-	roleWithPerms, err := uc.roleRepo.GetWithPermissions(ctx, role.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Get role with permissions
+		roleWithPerms, err = uc.roleRepo.GetWithPermissions(ctx, role.ID)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "role", "role:create", role.ID.String(), map[string]string{
-		"name": role.Name,
-		"slug": role.Slug,
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "role", "role:create", role.ID.String(), map[string]string{
+			"name": role.Name,
+			"slug": role.Slug,
+		})
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return uc.toRoleResponse(roleWithPerms), nil
 }
@@ -99,48 +117,79 @@ func (uc *RoleUseCase) GetRoleByID(ctx context.Context, id uuid.UUID) (*dto.Role
 	return uc.toRoleResponse(role), nil
 }
 
-// UpdateRole updates a role
+// UpdateRole updates a role in one transaction
 func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uuid.UUID, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
-	// Get existing role
-	role, err := uc.roleRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, domainErrors.ErrRoleNotFound
-	}
+	var roleWithPerms *entity.Role
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		// Get existing role
+		role, err := uc.roleRepo.GetByID(ctx, id)
+		if err != nil {
+			return domainErrors.ErrRoleNotFound
+		}
 
-	// Update fields
-	if req.Name != "" {
-		role.Name = req.Name
-	}
-	if req.Slug != "" {
-		// Check if slug is already taken by another role
-		existingRole, _ := uc.roleRepo.GetBySlug(ctx, req.Slug)
-		if existingRole != nil && existingRole.ID != id {
-			return nil, domainErrors.ErrRoleAlreadyExists
+		// Update fields
+		if req.Name != "" {
+			role.Name = req.Name
+		}
+		if req.Slug != "" {
+			// Check if slug is already taken by another role
+			existingRole, _ := uc.roleRepo.GetBySlug(ctx, req.Slug)
+			if existingRole != nil && existingRole.ID != id {
+				return domainErrors.ErrRoleAlreadyExists
+			}
+			role.Slug = strings.ToLower(req.Slug)
+		}
+		// Protected roles cannot be deactivated or lose mandatory 2FA
+		if req.IsActive != nil && *req.IsActive != role.IsActive {
+			if role.IsProtected {
+				return domainErrors.ErrProtectedRole
+			}
+			role.IsActive = *req.IsActive
+		}
+		if req.RequireMFA != nil && *req.RequireMFA != role.RequireMFA {
+			if role.IsProtected {
+				return domainErrors.ErrProtectedRole
+			}
+			role.RequireMFA = *req.RequireMFA
+		}
+		if req.ParentID != nil {
+			// Changing the parent changes the permissions of the role
+			if role.IsProtected {
+				return domainErrors.ErrProtectedRole
+			}
+			parentID, err := uc.resolveParentRole(ctx, role.ID, *req.ParentID)
+			if err != nil {
+				return err
+			}
+			role.ParentID = parentID
+			role.Parent = nil
 		}
-		role.Slug = strings.ToLower(req.Slug)
-	}
-	if req.IsActive != nil {
-		role.IsActive = *req.IsActive
-	}
 
-	role.UpdatedAt = time.Now()
+		role.UpdatedAt = time.Now()
 
-	// Save updated role
-	if err := uc.roleRepo.Update(ctx, role); err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Save updated role
+		if err := uc.roleRepo.Update(ctx, role); err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Get updated role with permissions
-	roleWithPerms, err := uc.roleRepo.GetWithPermissions(ctx, role.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Get updated role with permissions
+		roleWithPerms, err = uc.roleRepo.GetWithPermissions(ctx, role.ID)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "role", "role:update", role.ID.String(), map[string]string{
-		"name": role.Name,
-		"slug": role.Slug,
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "role", "role:update", role.ID.String(), map[string]string{
+			"name":        role.Name,
+			"slug":        role.Slug,
+			"is_active":   strconv.FormatBool(role.IsActive),
+			"require_mfa": strconv.FormatBool(role.RequireMFA),
+		})
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return uc.toRoleResponse(roleWithPerms), nil
 }
@@ -186,15 +235,14 @@ func (uc *RoleUseCase) ListRoles(ctx context.Context, page, pageSize int) (*dto.
 
 	offset := (page - 1) * pageSize
 
-	roles, total, err := uc.roleRepo.List(ctx, pageSize, offset)
+	roles, total, err := uc.roleRepo.List(ctx, pageSize, offset, repository.RelationPermissions)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
 	roleResponses := make([]dto.RoleResponse, 0, len(roles))
 	for _, role := range roles {
-		roleWithPerms, _ := uc.roleRepo.GetWithPermissions(ctx, role.ID)
-		roleResponses = append(roleResponses, *uc.toRoleResponse(roleWithPerms))
+		roleResponses = append(roleResponses, *uc.toRoleResponse(role))
 	}
 
 	totalPages := int(total) / pageSize
@@ -251,21 +299,151 @@ func (uc *RoleUseCase) RemovePermission(ctx context.Context, roleID uuid.UUID, p
 	return uc.roleRepo.RemovePermission(ctx, roleID, permissionID)
 }
 
+// SyncPermissions makes the permissions assigned directly to a role match the
+// given set. Reading the current assignments, computing the diff and applying it
+// happen in one transaction. Inherited permissions are not affected.
+func (uc *RoleUseCase) SyncPermissions(ctx context.Context, roleID uuid.UUID, permissionIDs []string) (*dto.SyncRolePermissionsResponse, error) {
+	var role *entity.Role
+	added, removed := make([]string, 0), make([]string, 0)
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		var err error
+		role, err = uc.roleRepo.GetWithPermissions(ctx, roleID)
+		if err != nil {
+			return domainErrors.ErrRoleNotFound
+		}
+
+		// Check if role is protected
+		if role.IsProtected {
+			return domainErrors.ErrProtectedRole
+		}
+
+		// Resolve the desired permissions, keeping the request order
+		desired := make(map[uuid.UUID]*entity.Permission, len(permissionIDs))
+		ordered := make([]*entity.Permission, 0, len(permissionIDs))
+		for _, permIDStr := range permissionIDs {
+			permID, err := uuid.Parse(permIDStr)
+			if err != nil {
+				return domainErrors.ErrPermissionNotFound
+			}
+			if _, exists := desired[permID]; exists {
+				continue
+			}
+			permission, err := uc.permissionRepo.GetByID(ctx, permID)
+			if err != nil {
+				return domainErrors.ErrPermissionNotFound
+			}
+			desired[permID] = permission
+			ordered = append(ordered, permission)
+		}
+
+		// Compute the diff against the current assignments
+		current := make(map[uuid.UUID]bool, len(role.Permissions))
+		var assignIDs, removeIDs []uuid.UUID
+		for _, perm := range role.Permissions {
+			current[perm.ID] = true
+			if desired[perm.ID] == nil {
+				removeIDs = append(removeIDs, perm.ID)
+				removed = append(removed, perm.Name)
+			}
+		}
+		for _, permission := range ordered {
+			if !current[permission.ID] {
+				assignIDs = append(assignIDs, permission.ID)
+				added = append(added, permission.Name)
+			}
+		}
+
+		if len(assignIDs) == 0 && len(removeIDs) == 0 {
+			return nil
+		}
+
+		if err := uc.roleRepo.SyncPermissions(ctx, roleID, assignIDs, removeIDs); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "role", "role:sync_permissions", roleID.String(), map[string]string{
+			"name":    role.Name,
+			"slug":    role.Slug,
+			"added":   strings.Join(added, ","),
+			"removed": strings.Join(removed, ","),
+		})
+
+		role, err = uc.roleRepo.GetWithPermissions(ctx, roleID)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SyncRolePermissionsResponse{
+		Role:    *uc.toRoleResponse(role),
+		Added:   added,
+		Removed: removed,
+	}, nil
+}
+
+// resolveParentRole parses the ID of the role that roleID should inherit from
+// and checks that the parent exists and is not roleID or one of its descendants.
+// An empty ID means the role has no parent.
+func (uc *RoleUseCase) resolveParentRole(ctx context.Context, roleID uuid.UUID, parentIDStr string) (*uuid.UUID, error) {
+	if parentIDStr == "" {
+		return nil, nil
+	}
+	parentID, err := uuid.Parse(parentIDStr)
+	if err != nil {
+		return nil, domainErrors.ErrParentRoleNotFound
+	}
+
+	// Walk up from the parent; reaching roleID means the hierarchy would loop
+	visited := make(map[uuid.UUID]bool)
+	for ancestorID := &parentID; ancestorID != nil && !visited[*ancestorID]; {
+		if *ancestorID == roleID {
+			return nil, domainErrors.ErrRoleHierarchyCycle
+		}
+		visited[*ancestorID] = true
+
+		ancestor, err := uc.roleRepo.GetByID(ctx, *ancestorID)
+		if err != nil {
+			if *ancestorID == parentID {
+				return nil, domainErrors.ErrParentRoleNotFound
+			}
+			break
+		}
+		ancestorID = ancestor.ParentID
+	}
+
+	return &parentID, nil
+}
+
 // toRoleResponse converts entity.Role to dto.RoleResponse
 func (uc *RoleUseCase) toRoleResponse(role *entity.Role) *dto.RoleResponse {
 	permissions := make([]string, 0, len(role.Permissions))
 	for _, perm := range role.Permissions {
 		permissions = append(permissions, perm.Name)
 	}
+	inherited := make([]string, 0, len(role.InheritedPermissions))
+	for _, perm := range role.InheritedPermissions {
+		inherited = append(inherited, perm.Name)
+	}
 
-	return &dto.RoleResponse{
-		ID:          role.ID.String(),
-		Name:        role.Name,
-		Slug:        role.Slug,
-		IsActive:    role.IsActive,
-		IsProtected: role.IsProtected,
-		Permissions: permissions,
-		CreatedAt:   role.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   role.UpdatedAt.Format(time.RFC3339),
+	resp := &dto.RoleResponse{
+		ID:                   role.ID.String(),
+		Name:                 role.Name,
+		Slug:                 role.Slug,
+		IsActive:             role.IsActive,
+		IsProtected:          role.IsProtected,
+		RequireMFA:           role.RequireMFA,
+		Permissions:          permissions,
+		InheritedPermissions: inherited,
+		CreatedAt:            role.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            role.UpdatedAt.Format(time.RFC3339),
+	}
+	if role.ParentID != nil {
+		resp.ParentID = role.ParentID.String()
 	}
+	return resp
 }
@@ -0,0 +1,367 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+func TestRoleUseCase_CreateRole_WithParent(t *testing.T) {
+	parentID := uuid.New()
+
+	tests := []struct {
+		name          string
+		parentID      string
+		setupMocks    func(*mocks.MockRoleRepository)
+		expectedError error
+	}{
+		{
+			name:     "success - inherits from parent",
+			parentID: parentID.String(),
+			setupMocks: func(roleRepo *mocks.MockRoleRepository) {
+				roleRepo.On("GetBySlug", mock.Anything, "manager").Return(nil, errRecordNotFound)
+				roleRepo.On("GetByID", mock.Anything, parentID).Return(&entity.Role{ID: parentID, Slug: "staff"}, nil)
+				roleRepo.On("Create", mock.Anything, mock.MatchedBy(func(r *entity.Role) bool {
+					return r.ParentID != nil && *r.ParentID == parentID
+				})).Return(nil)
+				roleRepo.On("GetWithPermissions", mock.Anything, mock.AnythingOfType("uuid.UUID")).Return(&entity.Role{
+					ID:                   uuid.New(),
+					Slug:                 "manager",
+					ParentID:             &parentID,
+					Permissions:          []entity.Permission{{Name: "role:read"}},
+					InheritedPermissions: []entity.Permission{{Name: "dorm:read"}},
+				}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "error - parent not found",
+			parentID: parentID.String(),
+			setupMocks: func(roleRepo *mocks.MockRoleRepository) {
+				roleRepo.On("GetBySlug", mock.Anything, "manager").Return(nil, errRecordNotFound)
+				roleRepo.On("GetByID", mock.Anything, parentID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrParentRoleNotFound,
+		},
+		{
+			name:     "error - invalid parent ID",
+			parentID: "not-a-uuid",
+			setupMocks: func(roleRepo *mocks.MockRoleRepository) {
+				roleRepo.On("GetBySlug", mock.Anything, "manager").Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrParentRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := new(mocks.MockRoleRepository)
+			tt.setupMocks(roleRepo)
+
+			uc := NewRoleUseCase(roleRepo, new(mocks.MockPermissionRepository), &noopAuditLogger{}, &inlineTxManager{})
+			resp, err := uc.CreateRole(context.Background(), dto.CreateRoleRequest{Name: "Manager", Slug: "manager", ParentID: tt.parentID})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, parentID.String(), resp.ParentID)
+				assert.Equal(t, []string{"role:read"}, resp.Permissions)
+				assert.Equal(t, []string{"dorm:read"}, resp.InheritedPermissions)
+			}
+			roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleUseCase_UpdateRole_Parent(t *testing.T) {
+	// grandparent <- parent <- role <- child
+	grandparentID, parentID, roleID, childID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	ptr := func(id uuid.UUID) *string {
+		s := id.String()
+		return &s
+	}
+	empty := ""
+
+	tests := []struct {
+		name          string
+		role          *entity.Role
+		parentID      *string
+		expectedError error
+	}{
+		{
+			name:     "success - change parent",
+			role:     &entity.Role{ID: roleID, Slug: "role", ParentID: &parentID},
+			parentID: ptr(grandparentID),
+		},
+		{
+			name:     "success - remove parent",
+			role:     &entity.Role{ID: roleID, Slug: "role", ParentID: &parentID},
+			parentID: &empty,
+		},
+		{
+			name:          "error - role inherits from itself",
+			role:          &entity.Role{ID: roleID, Slug: "role"},
+			parentID:      ptr(roleID),
+			expectedError: domainErrors.ErrRoleHierarchyCycle,
+		},
+		{
+			name:          "error - role inherits from its descendant",
+			role:          &entity.Role{ID: roleID, Slug: "role", ParentID: &parentID},
+			parentID:      ptr(childID),
+			expectedError: domainErrors.ErrRoleHierarchyCycle,
+		},
+		{
+			name:          "error - protected role",
+			role:          &entity.Role{ID: roleID, Slug: "role", IsProtected: true},
+			parentID:      ptr(parentID),
+			expectedError: domainErrors.ErrProtectedRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := new(mocks.MockRoleRepository)
+			roleRepo.On("GetByID", mock.Anything, roleID).Return(tt.role, nil)
+			roleRepo.On("GetByID", mock.Anything, childID).Return(&entity.Role{ID: childID, ParentID: &roleID}, nil).Maybe()
+			roleRepo.On("GetByID", mock.Anything, parentID).Return(&entity.Role{ID: parentID, ParentID: &grandparentID}, nil).Maybe()
+			roleRepo.On("GetByID", mock.Anything, grandparentID).Return(&entity.Role{ID: grandparentID}, nil).Maybe()
+			if tt.expectedError == nil {
+				roleRepo.On("Update", mock.Anything, tt.role).Return(nil)
+				roleRepo.On("GetWithPermissions", mock.Anything, roleID).Return(tt.role, nil)
+			}
+
+			uc := NewRoleUseCase(roleRepo, new(mocks.MockPermissionRepository), &noopAuditLogger{}, &inlineTxManager{})
+			resp, err := uc.UpdateRole(context.Background(), roleID, dto.UpdateRoleRequest{ParentID: tt.parentID})
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, *tt.parentID, resp.ParentID)
+			}
+			roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// metadataAuditLogger keeps the metadata of the last audit entry
+type metadataAuditLogger struct {
+	metadata map[string]string
+}
+
+func (l *metadataAuditLogger) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	l.metadata = metadata
+	return nil
+}
+
+func TestRoleUseCase_UpdateRole_ProtectedFields(t *testing.T) {
+	roleID := uuid.New()
+	yes, no := true, false
+
+	tests := []struct {
+		name          string
+		role          *entity.Role
+		req           dto.UpdateRoleRequest
+		expectedError error
+	}{
+		{
+			name:          "error - disable 2FA on protected role",
+			role:          &entity.Role{ID: roleID, Slug: "admin", IsActive: true, RequireMFA: true, IsProtected: true},
+			req:           dto.UpdateRoleRequest{RequireMFA: &no},
+			expectedError: domainErrors.ErrProtectedRole,
+		},
+		{
+			name:          "error - deactivate protected role",
+			role:          &entity.Role{ID: roleID, Slug: "super_admin", IsActive: true, RequireMFA: true, IsProtected: true},
+			req:           dto.UpdateRoleRequest{IsActive: &no},
+			expectedError: domainErrors.ErrProtectedRole,
+		},
+		{
+			name: "success - unchanged values on protected role",
+			role: &entity.Role{ID: roleID, Slug: "admin", IsActive: true, RequireMFA: true, IsProtected: true},
+			req:  dto.UpdateRoleRequest{Name: "Administrator", IsActive: &yes, RequireMFA: &yes},
+		},
+		{
+			name: "success - disable 2FA on regular role",
+			role: &entity.Role{ID: roleID, Slug: "staff", IsActive: true, RequireMFA: true},
+			req:  dto.UpdateRoleRequest{RequireMFA: &no},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := new(mocks.MockRoleRepository)
+			roleRepo.On("GetByID", mock.Anything, roleID).Return(tt.role, nil)
+			if tt.expectedError == nil {
+				roleRepo.On("Update", mock.Anything, tt.role).Return(nil)
+				roleRepo.On("GetWithPermissions", mock.Anything, roleID).Return(tt.role, nil)
+			}
+			auditLogger := &metadataAuditLogger{}
+
+			uc := NewRoleUseCase(roleRepo, new(mocks.MockPermissionRepository), auditLogger, &inlineTxManager{})
+			resp, err := uc.UpdateRole(context.Background(), roleID, tt.req)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Nil(t, auditLogger.metadata)
+			} else {
+				assert.NoError(t, err)
+				// The 2FA requirement is recorded with every update
+				assert.Equal(t, strconv.FormatBool(tt.role.RequireMFA), auditLogger.metadata["require_mfa"])
+			}
+			roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoleUseCase_SyncPermissions(t *testing.T) {
+	roleID := uuid.New()
+	userRead := entity.Permission{ID: uuid.New(), Name: "user:read"}
+	dormRead := entity.Permission{ID: uuid.New(), Name: "dorm:read"}
+	roleRead := entity.Permission{ID: uuid.New(), Name: "role:read"}
+
+	tests := []struct {
+		name            string
+		role            *entity.Role
+		permissionIDs   []string
+		setupMocks      func(*mocks.MockRoleRepository, *mocks.MockPermissionRepository)
+		expectedError   error
+		expectedAdded   []string
+		expectedRemoved []string
+		expectedActions []string
+	}{
+		{
+			name:          "success - applies diff",
+			role:          &entity.Role{ID: roleID, Slug: "staff", Permissions: []entity.Permission{userRead, dormRead}},
+			permissionIDs: []string{dormRead.ID.String(), roleRead.ID.String(), roleRead.ID.String()},
+			setupMocks: func(roleRepo *mocks.MockRoleRepository, permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByID", mock.Anything, dormRead.ID).Return(&dormRead, nil)
+				permissionRepo.On("GetByID", mock.Anything, roleRead.ID).Return(&roleRead, nil)
+				roleRepo.On("SyncPermissions", mock.Anything, roleID, []uuid.UUID{roleRead.ID}, []uuid.UUID{userRead.ID}).Return(nil)
+			},
+			expectedAdded:   []string{"role:read"},
+			expectedRemoved: []string{"user:read"},
+			expectedActions: []string{"role:sync_permissions"},
+		},
+		{
+			name:          "success - unchanged set is a no-op",
+			role:          &entity.Role{ID: roleID, Slug: "staff", Permissions: []entity.Permission{userRead}},
+			permissionIDs: []string{userRead.ID.String()},
+			setupMocks: func(roleRepo *mocks.MockRoleRepository, permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByID", mock.Anything, userRead.ID).Return(&userRead, nil)
+			},
+			expectedAdded:   []string{},
+			expectedRemoved: []string{},
+		},
+		{
+			name:          "error - protected role",
+			role:          &entity.Role{ID: roleID, Slug: "admin", IsProtected: true},
+			permissionIDs: []string{},
+			setupMocks:    func(roleRepo *mocks.MockRoleRepository, permissionRepo *mocks.MockPermissionRepository) {},
+			expectedError: domainErrors.ErrProtectedRole,
+		},
+		{
+			name:          "error - permission not found",
+			role:          &entity.Role{ID: roleID, Slug: "staff"},
+			permissionIDs: []string{roleRead.ID.String()},
+			setupMocks: func(roleRepo *mocks.MockRoleRepository, permissionRepo *mocks.MockPermissionRepository) {
+				permissionRepo.On("GetByID", mock.Anything, roleRead.ID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrPermissionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepo := new(mocks.MockRoleRepository)
+			permissionRepo := new(mocks.MockPermissionRepository)
+			auditLogger := &recordingAuditLogger{}
+			roleRepo.On("GetWithPermissions", mock.Anything, roleID).Return(tt.role, nil)
+			tt.setupMocks(roleRepo, permissionRepo)
+
+			uc := NewRoleUseCase(roleRepo, permissionRepo, auditLogger, &inlineTxManager{})
+			resp, err := uc.SyncPermissions(context.Background(), roleID, tt.permissionIDs)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAdded, resp.Added)
+				assert.Equal(t, tt.expectedRemoved, resp.Removed)
+			}
+			assert.Equal(t, tt.expectedActions, auditLogger.actions)
+			roleRepo.AssertExpectations(t)
+			permissionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// inTxKey marks contexts handed out by ctxTxManager
+type inTxKey struct{}
+
+// ctxTxManager runs units of work directly with a context marked as transactional
+type ctxTxManager struct{}
+
+func (ctxTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inTxKey{}, true))
+}
+
+func TestRoleUseCase_SyncPermissions_DiffsInsideTransaction(t *testing.T) {
+	roleID := uuid.New()
+	userRead := entity.Permission{ID: uuid.New(), Name: "user:read"}
+	roleRead := entity.Permission{ID: uuid.New(), Name: "role:read"}
+	inTx := mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(inTxKey{}) == true
+	})
+
+	// A concurrent sync cannot slip in between reading the assignments and applying the diff
+	roleRepo := new(mocks.MockRoleRepository)
+	permissionRepo := new(mocks.MockPermissionRepository)
+	roleRepo.On("GetWithPermissions", inTx, roleID).Return(&entity.Role{ID: roleID, Slug: "staff", Permissions: []entity.Permission{userRead}}, nil)
+	permissionRepo.On("GetByID", inTx, roleRead.ID).Return(&roleRead, nil)
+	roleRepo.On("SyncPermissions", inTx, roleID, []uuid.UUID{roleRead.ID}, []uuid.UUID{userRead.ID}).Return(nil)
+
+	uc := NewRoleUseCase(roleRepo, permissionRepo, &noopAuditLogger{}, ctxTxManager{})
+	resp, err := uc.SyncPermissions(context.Background(), roleID, []string{roleRead.ID.String()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"role:read"}, resp.Added)
+	assert.Equal(t, []string{"user:read"}, resp.Removed)
+	roleRepo.AssertExpectations(t)
+	permissionRepo.AssertExpectations(t)
+}
+
+func TestRoleUseCase_ListRoles(t *testing.T) {
+	roleRepo := new(mocks.MockRoleRepository)
+	roles := []*entity.Role{
+		{ID: uuid.New(), Slug: "staff", Permissions: []entity.Permission{{Name: "user:read"}}},
+		{ID: uuid.New(), Slug: "manager", Permissions: []entity.Permission{{Name: "role:read"}}, InheritedPermissions: []entity.Permission{{Name: "user:read"}}},
+	}
+	// Permissions come preloaded with the page; GetWithPermissions is not expected
+	roleRepo.On("List", mock.Anything, 10, 0, []repository.Relation{repository.RelationPermissions}).Return(roles, int64(2), nil)
+
+	uc := NewRoleUseCase(roleRepo, new(mocks.MockPermissionRepository), &noopAuditLogger{}, &inlineTxManager{})
+	resp, err := uc.ListRoles(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Total)
+	assert.Equal(t, 1, resp.TotalPages)
+	assert.Len(t, resp.Roles, 2)
+	assert.Equal(t, []string{"role:read"}, resp.Roles[1].Permissions)
+	assert.Equal(t, []string{"user:read"}, resp.Roles[1].InheritedPermissions)
+	roleRepo.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// SessionUseCase handles listing and revoking login sessions
+type SessionUseCase struct {
+	sessionRepo      repository.SessionRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	auditLogger      appService.AuditLogger
+}
+
+// NewSessionUseCase creates a new session use case
+func NewSessionUseCase(
+	sessionRepo repository.SessionRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	auditLogger appService.AuditLogger,
+) *SessionUseCase {
+	return &SessionUseCase{
+		sessionRepo:      sessionRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		auditLogger:      auditLogger,
+	}
+}
+
+// ListSessions lists the active sessions of a user, most recently used first
+func (uc *SessionUseCase) ListSessions(ctx context.Context, userID uuid.UUID) ([]dto.SessionResponse, error) {
+	sessions, err := uc.sessionRepo.ListActiveByUser(ctx, userID, time.Now())
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = uc.toSessionResponse(session)
+	}
+
+	return responses, nil
+}
+
+// RevokeSession signs a user out of one session by revoking its refresh token family.
+// Access tokens already issued for the session stay valid until they expire.
+func (uc *SessionUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := uc.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return domainErrors.ErrSessionNotFound
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(ctx, session.FamilyID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "session", "session:revoke", session.ID.String(), map[string]string{
+		"user_id":    userID.String(),
+		"ip_address": session.IPAddress,
+		"user_agent": session.UserAgent,
+	})
+
+	return nil
+}
+
+// toSessionResponse converts session entity to response DTO
+func (uc *SessionUseCase) toSessionResponse(session *entity.Session) dto.SessionResponse {
+	return dto.SessionResponse{
+		ID:         session.ID.String(),
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+		LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+	}
+}
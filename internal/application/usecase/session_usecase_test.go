@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+// recordingAuditLogger remembers the actions it was asked to log
+type recordingAuditLogger struct {
+	actions []string
+}
+
+func (r *recordingAuditLogger) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	r.actions = append(r.actions, action)
+	return nil
+}
+
+func TestSessionUseCase_RevokeSession(t *testing.T) {
+	userID := uuid.New()
+	session := &entity.Session{
+		ID:       uuid.New(),
+		UserID:   userID,
+		FamilyID: uuid.New(),
+	}
+
+	tests := []struct {
+		name            string
+		userID          uuid.UUID
+		setupMocks      func(*mocks.MockSessionRepository, *mocks.MockRefreshTokenRepository)
+		expectedError   error
+		expectedActions []string
+	}{
+		{
+			name:   "success - revokes refresh token family",
+			userID: userID,
+			setupMocks: func(sessionRepo *mocks.MockSessionRepository, refreshTokenRepo *mocks.MockRefreshTokenRepository) {
+				sessionRepo.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+				refreshTokenRepo.On("RevokeFamily", mock.Anything, session.FamilyID).Return(nil)
+			},
+			expectedError:   nil,
+			expectedActions: []string{"session:revoke"},
+		},
+		{
+			name:   "error - session of another user",
+			userID: uuid.New(),
+			setupMocks: func(sessionRepo *mocks.MockSessionRepository, refreshTokenRepo *mocks.MockRefreshTokenRepository) {
+				sessionRepo.On("GetByID", mock.Anything, session.ID).Return(session, nil)
+			},
+			expectedError: domainErrors.ErrSessionNotFound,
+		},
+		{
+			name:   "error - session not found",
+			userID: userID,
+			setupMocks: func(sessionRepo *mocks.MockSessionRepository, refreshTokenRepo *mocks.MockRefreshTokenRepository) {
+				sessionRepo.On("GetByID", mock.Anything, session.ID).Return(nil, errors.New("record not found"))
+			},
+			expectedError: domainErrors.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionRepo := new(mocks.MockSessionRepository)
+			refreshTokenRepo := new(mocks.MockRefreshTokenRepository)
+			auditLogger := &recordingAuditLogger{}
+			tt.setupMocks(sessionRepo, refreshTokenRepo)
+
+			uc := NewSessionUseCase(sessionRepo, refreshTokenRepo, auditLogger)
+			err := uc.RevokeSession(context.Background(), tt.userID, session.ID)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expectedActions, auditLogger.actions)
+			sessionRepo.AssertExpectations(t)
+			refreshTokenRepo.AssertExpectations(t)
+		})
+	}
+}
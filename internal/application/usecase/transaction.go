@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// withinTx runs fn as one unit of work. Errors returned by fn, already mapped to
+// domain errors, are passed through; failing to begin or commit is an internal error.
+func withinTx(ctx context.Context, txManager repository.TransactionManager, fn func(ctx context.Context) error) error {
+	var fnErr error
+	err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+		fnErr = fn(ctx)
+		return fnErr
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return domainErrors.ErrInternalServer
+	}
+	return nil
+}
+
+// auditWithinTx writes a best-effort audit entry inside the unit of work in ctx.
+// The entry gets its own nested WithinTx (a savepoint), so a failed insert is
+// undone on its own instead of aborting the surrounding transaction.
+func auditWithinTx(ctx context.Context, txManager repository.TransactionManager, auditLogger appService.AuditLogger, resource, action, targetID string, metadata map[string]string) {
+	_ = txManager.WithinTx(ctx, func(ctx context.Context) error {
+		return auditLogger.Log(ctx, resource, action, targetID, metadata)
+	})
+}
@@ -14,25 +14,34 @@ import (
 
 // UserUseCase handles user management use cases
 type UserUseCase struct {
-	userRepo    repository.UserRepository
-	roleRepo    repository.RoleRepository
-	auditLogger appService.AuditLogger
+	userRepo      repository.UserRepository
+	roleRepo      repository.RoleRepository
+	dormitoryRepo repository.DormitoryRepository
+	auditLogger   appService.AuditLogger
+	cursors       *appService.CursorCodec
+	txManager     repository.TransactionManager
 }
 
 // NewUserUseCase creates a new user use case
 func NewUserUseCase(
 	userRepo repository.UserRepository,
 	roleRepo repository.RoleRepository,
+	dormitoryRepo repository.DormitoryRepository,
 	auditLogger appService.AuditLogger,
+	cursors *appService.CursorCodec,
+	txManager repository.TransactionManager,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo:    userRepo,
-		roleRepo:    roleRepo,
-		auditLogger: auditLogger,
+		userRepo:      userRepo,
+		roleRepo:      roleRepo,
+		dormitoryRepo: dormitoryRepo,
+		auditLogger:   auditLogger,
+		cursors:       cursors,
+		txManager:     txManager,
 	}
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user with their roles in one transaction
 func (uc *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserRequest) (*dto.UserResponse, error) {
 	// Check if user already exists
 	existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
@@ -56,46 +65,42 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, req dto.CreateUserRequest
 		return nil, domainErrors.ErrInternalServer
 	}
 
-	// Assign roles if provided, otherwise assign default role
-	if len(req.RoleIDs) > 0 {
-		roles := make([]entity.Role, 0)
-		for _, roleIDStr := range req.RoleIDs {
-			roleID, err := uuid.Parse(roleIDStr)
-			if err != nil {
-				continue
+	var userWithRoles *entity.User
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		// Assign roles if provided, otherwise assign default role
+		if len(req.RoleIDs) > 0 {
+			user.Roles = uc.resolveRoles(ctx, req.RoleIDs)
+		} else {
+			// Assign default role (user role)
+			defaultRole, err := uc.roleRepo.GetBySlug(ctx, entity.DefaultRoleSlug)
+			if err == nil && defaultRole != nil {
+				user.Roles = []entity.Role{*defaultRole}
 			}
-			role, err := uc.roleRepo.GetByID(ctx, roleID)
-			if err != nil {
-				continue
-			}
-			roles = append(roles, *role)
 		}
-		user.Roles = roles
-	} else {
-		// Assign default role (user role)
-		defaultRole, err := uc.roleRepo.GetBySlug(ctx, "user")
-		if err == nil && defaultRole != nil {
-			user.Roles = []entity.Role{*defaultRole}
+
+		// Save user
+		if err := uc.userRepo.Create(ctx, user); err != nil {
+			return domainErrors.ErrInternalServer
 		}
-	}
 
-	// Save user
-	if err := uc.userRepo.Create(ctx, user); err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Get user with roles
+		var err error
+		userWithRoles, err = uc.userRepo.GetWithRoles(ctx, user.ID)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Get user with roles
-	userWithRoles, err := uc.userRepo.GetWithRoles(ctx, user.ID)
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "user", "user:create", user.ID.String(), map[string]string{
+			"email": user.Email,
+			"name":  user.Name,
+		})
+		return nil
+	})
 	if err != nil {
-		return nil, domainErrors.ErrInternalServer
+		return nil, err
 	}
 
-	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "user", "user:create", user.ID.String(), map[string]string{
-		"email": user.Email,
-		"name":  user.Name,
-	})
-
 	return uc.toUserResponse(userWithRoles), nil
 }
 
@@ -109,65 +114,60 @@ func (uc *UserUseCase) GetUserByID(ctx context.Context, id uuid.UUID) (*dto.User
 	return uc.toUserResponse(user), nil
 }
 
-// UpdateUser updates a user
+// UpdateUser updates a user and their roles in one transaction
 func (uc *UserUseCase) UpdateUser(ctx context.Context, id uuid.UUID, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
-	// Get existing user
-	user, err := uc.userRepo.GetByID(ctx, id)
-	if err != nil {
-		return nil, domainErrors.ErrUserNotFound
-	}
+	var userWithRoles *entity.User
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		// Get existing user
+		user, err := uc.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return domainErrors.ErrUserNotFound
+		}
 
-	// Update fields
-	if req.Name != "" {
-		user.Name = req.Name
-	}
-	if req.Email != "" {
-		// Check if email is already taken by another user
-		existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
-		if existingUser != nil && existingUser.ID != id {
-			return nil, domainErrors.ErrUserAlreadyExists
+		// Update fields
+		if req.Name != "" {
+			user.Name = req.Name
+		}
+		if req.Email != "" {
+			// Check if email is already taken by another user
+			existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
+			if existingUser != nil && existingUser.ID != id {
+				return domainErrors.ErrUserAlreadyExists
+			}
+			user.Email = req.Email
+		}
+		if req.IsActive != nil {
+			user.IsActive = *req.IsActive
 		}
-		user.Email = req.Email
-	}
-	if req.IsActive != nil {
-		user.IsActive = *req.IsActive
-	}
 
-	user.UpdatedAt = time.Now()
+		user.UpdatedAt = time.Now()
 
-	// Update roles if provided
-	if len(req.RoleIDs) > 0 {
-		roles := make([]entity.Role, 0)
-		for _, roleIDStr := range req.RoleIDs {
-			roleID, err := uuid.Parse(roleIDStr)
-			if err != nil {
-				continue
-			}
-			role, err := uc.roleRepo.GetByID(ctx, roleID)
-			if err != nil {
-				continue
-			}
-			roles = append(roles, *role)
+		// Update roles if provided
+		if len(req.RoleIDs) > 0 {
+			user.Roles = uc.resolveRoles(ctx, req.RoleIDs)
 		}
-		user.Roles = roles
-	}
 
-	// Save updated user
-	if err := uc.userRepo.Update(ctx, user); err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Save updated user
+		if err := uc.userRepo.Update(ctx, user); err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Get updated user with roles
-	userWithRoles, err := uc.userRepo.GetWithRoles(ctx, user.ID)
-	if err != nil {
-		return nil, domainErrors.ErrInternalServer
-	}
+		// Get updated user with roles
+		userWithRoles, err = uc.userRepo.GetWithRoles(ctx, user.ID)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
 
-	// Audit log (best-effort)
-	_ = uc.auditLogger.Log(ctx, "user", "user:update", user.ID.String(), map[string]string{
-		"email": user.Email,
-		"name":  user.Name,
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "user", "user:update", user.ID.String(), map[string]string{
+			"email": user.Email,
+			"name":  user.Name,
+		})
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	return uc.toUserResponse(userWithRoles), nil
 }
@@ -194,8 +194,106 @@ func (uc *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-// ListUsers retrieves a paginated list of users
-func (uc *UserUseCase) ListUsers(ctx context.Context, page, pageSize int) (*dto.ListUsersResponse, error) {
+// ListUsers retrieves a paginated list of users matching the filter
+func (uc *UserUseCase) ListUsers(ctx context.Context, page, pageSize int, filter dto.ListUsersFilter) (*dto.ListUsersResponse, error) {
+	sort, err := repository.ParseSort(filter.Sort, repository.UserSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	offset := (page - 1) * pageSize
+
+	users, total, err := uc.userRepo.List(ctx, repository.UserFilter{
+		Search:      filter.Search,
+		Role:        filter.Role,
+		IsActive:    filter.IsActive,
+		DormitoryID: filter.DormitoryID,
+		Sort:        sort,
+	}, pageSize, offset, repository.RelationRoles)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, *uc.toUserResponse(user))
+	}
+
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize > 0 {
+		totalPages++
+	}
+
+	return &dto.ListUsersResponse{
+		Users:      userResponses,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// ListUsersByCursor retrieves a cursor-paginated list of users matching the filter, newest first
+func (uc *UserUseCase) ListUsersByCursor(ctx context.Context, query dto.CursorQuery, filter dto.ListUsersFilter) (*dto.CursorUsersResponse, error) {
+	sort, err := repository.ParseSort(filter.Sort, repository.UserSortFields)
+	if err != nil {
+		return nil, err
+	}
+	if sort != (repository.Sort{}) && sort != (repository.Sort{Field: "created_at", Desc: true}) {
+		return nil, domainErrors.ErrCursorSortNotUsable
+	}
+
+	after, err := decodeKeyset(uc.cursors, cursorScopeUsers, query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := normalizeCursorLimit(query.Limit)
+	users, total, err := uc.userRepo.ListAfter(ctx, repository.UserFilter{
+		Search:      filter.Search,
+		Role:        filter.Role,
+		IsActive:    filter.IsActive,
+		DormitoryID: filter.DormitoryID,
+	}, repository.KeysetPage{After: after, Limit: limit + 1, WithCount: query.WithCount}, repository.RelationRoles)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	var next interface{}
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[limit-1]
+		next = repository.Keyset{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	pagination, err := cursorPagination(uc.cursors, cursorScopeUsers, query, limit, next, total)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, *uc.toUserResponse(user))
+	}
+
+	return &dto.CursorUsersResponse{
+		Users:            userResponses,
+		CursorPagination: pagination,
+	}, nil
+}
+
+// ListDeletedUsers retrieves a paginated list of soft-deleted users
+func (uc *UserUseCase) ListDeletedUsers(ctx context.Context, page, pageSize int) (*dto.ListUsersResponse, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -208,15 +306,14 @@ func (uc *UserUseCase) ListUsers(ctx context.Context, page, pageSize int) (*dto.
 
 	offset := (page - 1) * pageSize
 
-	users, total, err := uc.userRepo.List(ctx, pageSize, offset)
+	users, total, err := uc.userRepo.ListDeleted(ctx, pageSize, offset, repository.RelationRoles)
 	if err != nil {
 		return nil, domainErrors.ErrInternalServer
 	}
 
 	userResponses := make([]dto.UserResponse, 0, len(users))
 	for _, user := range users {
-		userWithRoles, _ := uc.userRepo.GetWithRoles(ctx, user.ID)
-		userResponses = append(userResponses, *uc.toUserResponse(userWithRoles))
+		userResponses = append(userResponses, *uc.toUserResponse(user))
 	}
 
 	totalPages := int(total) / pageSize
@@ -233,8 +330,50 @@ func (uc *UserUseCase) ListUsers(ctx context.Context, page, pageSize int) (*dto.
 	}, nil
 }
 
+// RestoreUser brings back a soft-deleted user together with their roles and dormitories
+func (uc *UserUseCase) RestoreUser(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error) {
+	var restored *entity.User
+	err := withinTx(ctx, uc.txManager, func(ctx context.Context) error {
+		user, err := uc.userRepo.GetDeletedByID(ctx, id)
+		if err != nil {
+			return domainErrors.ErrUserNotFound
+		}
+
+		// The email may have been registered again while the user was deleted
+		existingUser, _ := uc.userRepo.GetByEmail(ctx, user.Email)
+		if existingUser != nil {
+			return domainErrors.ErrUserAlreadyExists
+		}
+
+		if err := uc.userRepo.Restore(ctx, id); err != nil {
+			return domainErrors.ErrInternalServer
+		}
+
+		// Audit log (best-effort, in its own savepoint)
+		auditWithinTx(ctx, uc.txManager, uc.auditLogger, "user", "user:restore", id.String(), map[string]string{
+			"email": user.Email,
+			"name":  user.Name,
+		})
+
+		restored, err = uc.userRepo.GetWithRoles(ctx, id)
+		if err != nil {
+			return domainErrors.ErrInternalServer
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.toUserResponse(restored), nil
+}
+
 // AssignRoleToUser assigns a role to a user
-func (uc *UserUseCase) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID) error {
+// A grant with expiresAt is revoked automatically once it expires.
+func (uc *UserUseCase) AssignRoleToUser(ctx context.Context, userID, roleID uuid.UUID, expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return domainErrors.ErrBadRequest
+	}
+
 	// Check if user exists
 	_, err := uc.userRepo.GetByID(ctx, userID)
 	if err != nil {
@@ -242,13 +381,27 @@ func (uc *UserUseCase) AssignRoleToUser(ctx context.Context, userID, roleID uuid
 	}
 
 	// Check if role exists
-	_, err = uc.roleRepo.GetByID(ctx, roleID)
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
 
 	// Assign role
-	return uc.userRepo.AssignRole(ctx, userID, roleID)
+	if err := uc.userRepo.AssignRole(ctx, userID, roleID, expiresAt); err != nil {
+		return err
+	}
+
+	// Audit log (best-effort)
+	metadata := map[string]string{
+		"role_id":   roleID.String(),
+		"role_slug": role.Slug,
+	}
+	if expiresAt != nil {
+		metadata["expires_at"] = expiresAt.Format(time.RFC3339)
+	}
+	_ = uc.auditLogger.Log(ctx, "user", "user:assign_role", userID.String(), metadata)
+
+	return nil
 }
 
 // RemoveRoleFromUser removes a role from a user
@@ -260,13 +413,143 @@ func (uc *UserUseCase) RemoveRoleFromUser(ctx context.Context, userID, roleID uu
 	}
 
 	// Check if role exists
-	_, err = uc.roleRepo.GetByID(ctx, roleID)
+	role, err := uc.roleRepo.GetByID(ctx, roleID)
 	if err != nil {
 		return domainErrors.ErrRoleNotFound
 	}
 
 	// Remove role
-	return uc.userRepo.RemoveRole(ctx, userID, roleID)
+	if err := uc.userRepo.RemoveRole(ctx, userID, roleID); err != nil {
+		return err
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "user", "user:remove_role", userID.String(), map[string]string{
+		"role_id":   roleID.String(),
+		"role_slug": role.Slug,
+	})
+
+	return nil
+}
+
+// RevokeExpiredRoles removes the time-bound role grants that have expired and
+// returns how many were revoked. It is run periodically by the role grant sweeper.
+func (uc *UserUseCase) RevokeExpiredRoles(ctx context.Context) (int, error) {
+	expired, err := uc.userRepo.RevokeExpiredRoles(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, grant := range expired {
+		// Audit log (best-effort)
+		_ = uc.auditLogger.Log(ctx, "user", "user:role_expired", grant.UserID.String(), map[string]string{
+			"role_id":    grant.RoleID.String(),
+			"expires_at": grant.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+
+	return len(expired), nil
+}
+
+// ListDormitoryRoles retrieves the roles a user holds within specific dormitories
+func (uc *UserUseCase) ListDormitoryRoles(ctx context.Context, userID uuid.UUID) ([]dto.UserDormitoryRoleResponse, error) {
+	// Check if user exists
+	_, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrUserNotFound
+	}
+
+	scopedRoles, err := uc.userRepo.GetDormitoryRoles(ctx, userID)
+	if err != nil {
+		return nil, domainErrors.ErrInternalServer
+	}
+
+	responses := make([]dto.UserDormitoryRoleResponse, 0, len(scopedRoles))
+	for _, scoped := range scopedRoles {
+		responses = append(responses, dto.UserDormitoryRoleResponse{
+			RoleID:        scoped.RoleID.String(),
+			RoleName:      scoped.Role.Name,
+			DormitoryID:   scoped.DormitoryID.String(),
+			DormitoryName: scoped.Dormitory.Name,
+			CreatedAt:     scoped.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return responses, nil
+}
+
+// AssignDormitoryRole assigns a role to a user within a single dormitory.
+// The role's permissions only apply to requests for that dormitory.
+func (uc *UserUseCase) AssignDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	// Check if user exists
+	_, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	// Check if dormitory exists
+	_, err = uc.dormitoryRepo.GetByID(ctx, dormitoryID)
+	if err != nil {
+		return domainErrors.ErrDormitoryNotFound
+	}
+
+	// Check if role exists
+	_, err = uc.roleRepo.GetByID(ctx, roleID)
+	if err != nil {
+		return domainErrors.ErrRoleNotFound
+	}
+
+	// Assign scoped role
+	if err := uc.userRepo.AssignDormitoryRole(ctx, userID, dormitoryID, roleID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "user", "user:assign_dormitory_role", userID.String(), map[string]string{
+		"role_id":      roleID.String(),
+		"dormitory_id": dormitoryID.String(),
+	})
+
+	return nil
+}
+
+// RemoveDormitoryRole removes a role a user holds within a dormitory
+func (uc *UserUseCase) RemoveDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	// Check if user exists
+	_, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domainErrors.ErrUserNotFound
+	}
+
+	// Remove scoped role
+	if err := uc.userRepo.RemoveDormitoryRole(ctx, userID, dormitoryID, roleID); err != nil {
+		return domainErrors.ErrInternalServer
+	}
+
+	// Audit log (best-effort)
+	_ = uc.auditLogger.Log(ctx, "user", "user:remove_dormitory_role", userID.String(), map[string]string{
+		"role_id":      roleID.String(),
+		"dormitory_id": dormitoryID.String(),
+	})
+
+	return nil
+}
+
+// resolveRoles loads the roles with the given IDs, skipping invalid and unknown ones
+func (uc *UserUseCase) resolveRoles(ctx context.Context, roleIDs []string) []entity.Role {
+	roles := make([]entity.Role, 0, len(roleIDs))
+	for _, roleIDStr := range roleIDs {
+		roleID, err := uuid.Parse(roleIDStr)
+		if err != nil {
+			continue
+		}
+		role, err := uc.roleRepo.GetByID(ctx, roleID)
+		if err != nil {
+			continue
+		}
+		roles = append(roles, *role)
+	}
+	return roles
 }
 
 // toUserResponse converts entity.User to dto.UserResponse
@@ -276,7 +559,7 @@ func (uc *UserUseCase) toUserResponse(user *entity.User) *dto.UserResponse {
 		roles = append(roles, role.Name)
 	}
 
-	return &dto.UserResponse{
+	resp := &dto.UserResponse{
 		ID:        user.ID.String(),
 		Email:     user.Email,
 		Name:      user.Name,
@@ -285,4 +568,8 @@ func (uc *UserUseCase) toUserResponse(user *entity.User) *dto.UserResponse {
 		CreatedAt: user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
 	}
+	if user.DeletedAt.Valid {
+		resp.DeletedAt = user.DeletedAt.Time.Format(time.RFC3339)
+	}
+	return resp
 }
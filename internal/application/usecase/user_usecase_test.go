@@ -2,15 +2,19 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
 )
 
 type noopAuditLogger struct{}
@@ -19,6 +23,23 @@ func (n *noopAuditLogger) Log(ctx context.Context, resource, action, targetID st
 	return nil
 }
 
+var testCursors = appService.NewCursorCodec([]byte("test-cursor-secret"))
+
+// inlineTxManager runs units of work directly, counting those that would have been rolled back
+type inlineTxManager struct {
+	calls      int
+	rolledBack int
+}
+
+func (m *inlineTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	err := fn(ctx)
+	if err != nil {
+		m.rolledBack++
+	}
+	return err
+}
+
 func TestUserUseCase_CreateUser(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -106,7 +127,7 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 			tt.setupMocks(userRepo, roleRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
 			resp, err := userUseCase.CreateUser(context.Background(), tt.req)
 
 			if tt.expectedError != nil {
@@ -126,6 +147,61 @@ func TestUserUseCase_CreateUser(t *testing.T) {
 	}
 }
 
+func TestUserUseCase_CreateUser_RollsBackOnFailure(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
+	roleRepo.On("GetBySlug", mock.Anything, "user").Return(&entity.Role{ID: uuid.New(), Name: "User"}, nil)
+	userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(nil, errRecordNotFound)
+
+	txManager := &inlineTxManager{}
+	userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), &noopAuditLogger{}, testCursors, txManager)
+	resp, err := userUseCase.CreateUser(context.Background(), dto.CreateUserRequest{
+		Email:    "newuser@example.com",
+		Password: "password123",
+		Name:     "New User",
+	})
+
+	// The user was saved before the failure, so the whole unit of work is undone
+	assert.Equal(t, domainErrors.ErrInternalServer, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, 1, txManager.calls)
+	assert.Equal(t, 1, txManager.rolledBack)
+	userRepo.AssertExpectations(t)
+}
+
+// failingAuditLogger fails every write, like an audit insert rejected by the database
+type failingAuditLogger struct{}
+
+func (f *failingAuditLogger) Log(ctx context.Context, resource, action, targetID string, metadata map[string]string) error {
+	return errors.New("audit insert failed")
+}
+
+func TestUserUseCase_CreateUser_AuditFailureKeepsTransaction(t *testing.T) {
+	userRepo := new(mocks.MockUserRepository)
+	roleRepo := new(mocks.MockRoleRepository)
+	userRepo.On("GetByEmail", mock.Anything, "newuser@example.com").Return(nil, domainErrors.ErrUserNotFound)
+	roleRepo.On("GetBySlug", mock.Anything, "user").Return(&entity.Role{ID: uuid.New(), Name: "User"}, nil)
+	userRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{ID: uuid.New(), Email: "newuser@example.com"}, nil)
+
+	txManager := &inlineTxManager{}
+	userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), &failingAuditLogger{}, testCursors, txManager)
+	resp, err := userUseCase.CreateUser(context.Background(), dto.CreateUserRequest{
+		Email:    "newuser@example.com",
+		Password: "password123",
+		Name:     "New User",
+	})
+
+	// Only the nested unit of work around the audit entry is rolled back
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, 2, txManager.calls)
+	assert.Equal(t, 1, txManager.rolledBack)
+	userRepo.AssertExpectations(t)
+}
+
 func TestUserUseCase_GetUserByID(t *testing.T) {
 	userID := uuid.New()
 
@@ -165,7 +241,7 @@ func TestUserUseCase_GetUserByID(t *testing.T) {
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo)
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
 			resp, err := userUseCase.GetUserByID(context.Background(), tt.userID)
 
 			if tt.expectedError != nil {
@@ -255,7 +331,7 @@ func TestUserUseCase_UpdateUser(t *testing.T) {
 			roleRepo := new(mocks.MockRoleRepository)
 			tt.setupMocks(userRepo, roleRepo)
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
 			resp, err := userUseCase.UpdateUser(context.Background(), tt.userID, tt.req)
 
 			if tt.expectedError != nil {
@@ -310,7 +386,7 @@ func TestUserUseCase_DeleteUser(t *testing.T) {
 			tt.setupMocks(userRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
 			err := userUseCase.DeleteUser(context.Background(), tt.userID)
 
 			if tt.expectedError != nil {
@@ -326,10 +402,15 @@ func TestUserUseCase_DeleteUser(t *testing.T) {
 }
 
 func TestUserUseCase_ListUsers(t *testing.T) {
+	isActive := true
+	dormitoryID := uuid.New()
+	withRoles := []repository.Relation{repository.RelationRoles}
+
 	tests := []struct {
 		name          string
 		page          int
 		pageSize      int
+		filter        dto.ListUsersFilter
 		setupMocks    func(*mocks.MockUserRepository)
 		expectedError error
 	}{
@@ -339,14 +420,11 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 			pageSize: 10,
 			setupMocks: func(userRepo *mocks.MockUserRepository) {
 				users := []*entity.User{
-					{ID: uuid.New(), Email: "user1@example.com", Name: "User 1"},
+					{ID: uuid.New(), Email: "user1@example.com", Name: "User 1", Roles: []entity.Role{{Name: "Admin"}}},
 					{ID: uuid.New(), Email: "user2@example.com", Name: "User 2"},
 				}
-				userRepo.On("List", mock.Anything, 10, 0).Return(users, int64(2), nil)
-				userRepo.On("GetWithRoles", mock.Anything, mock.Anything).Return(&entity.User{
-					ID:    uuid.New(),
-					Roles: []entity.Role{},
-				}, nil).Times(2)
+				// Roles come preloaded with the page; no per-user lookup is expected
+				userRepo.On("List", mock.Anything, repository.UserFilter{}, 10, 0, withRoles).Return(users, int64(2), nil)
 			},
 			expectedError: nil,
 		},
@@ -356,7 +434,7 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 			pageSize: 0,
 			setupMocks: func(userRepo *mocks.MockUserRepository) {
 				users := []*entity.User{}
-				userRepo.On("List", mock.Anything, 10, 0).Return(users, int64(0), nil)
+				userRepo.On("List", mock.Anything, repository.UserFilter{}, 10, 0, withRoles).Return(users, int64(0), nil)
 			},
 			expectedError: nil,
 		},
@@ -366,10 +444,34 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 			pageSize: 200,
 			setupMocks: func(userRepo *mocks.MockUserRepository) {
 				users := []*entity.User{}
-				userRepo.On("List", mock.Anything, 100, 0).Return(users, int64(0), nil)
+				userRepo.On("List", mock.Anything, repository.UserFilter{}, 100, 0, withRoles).Return(users, int64(0), nil)
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "success - filter and sort passed to repository",
+			page:     2,
+			pageSize: 5,
+			filter:   dto.ListUsersFilter{Search: "ali", Role: "admin", IsActive: &isActive, DormitoryID: &dormitoryID, Sort: "-name"},
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("List", mock.Anything, repository.UserFilter{
+					Search:      "ali",
+					Role:        "admin",
+					IsActive:    &isActive,
+					DormitoryID: &dormitoryID,
+					Sort:        repository.Sort{Field: "name", Desc: true},
+				}, 5, 5, withRoles).Return([]*entity.User{}, int64(0), nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "error - sort field not allowed",
+			page:          1,
+			pageSize:      10,
+			filter:        dto.ListUsersFilter{Sort: "password"},
+			setupMocks:    func(userRepo *mocks.MockUserRepository) {},
+			expectedError: domainErrors.ErrInvalidSortField,
+		},
 	}
 
 	for _, tt := range tests {
@@ -379,11 +481,11 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 			tt.setupMocks(userRepo)
 
 			auditLogger := &noopAuditLogger{}
-			userUseCase := NewUserUseCase(userRepo, roleRepo, auditLogger)
-			resp, err := userUseCase.ListUsers(context.Background(), tt.page, tt.pageSize)
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
+			resp, err := userUseCase.ListUsers(context.Background(), tt.page, tt.pageSize, tt.filter)
 
 			if tt.expectedError != nil {
-				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError, err)
 				assert.Nil(t, resp)
 			} else {
 				assert.NoError(t, err)
@@ -394,9 +496,209 @@ func TestUserUseCase_ListUsers(t *testing.T) {
 					expectedPage = 1
 				}
 				assert.Equal(t, expectedPage, resp.Page)
+				if len(resp.Users) > 0 {
+					assert.Equal(t, []string{"Admin"}, resp.Users[0].Roles)
+				}
+			}
+
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUseCase_RestoreUser(t *testing.T) {
+	userID := uuid.New()
+	deletedUser := &entity.User{ID: userID, Email: "deleted@example.com", Name: "Deleted"}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockUserRepository)
+		expectedError error
+	}{
+		{
+			name: "success - restore user",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetDeletedByID", mock.Anything, userID).Return(deletedUser, nil)
+				userRepo.On("GetByEmail", mock.Anything, "deleted@example.com").Return(nil, errRecordNotFound)
+				userRepo.On("Restore", mock.Anything, userID).Return(nil)
+				userRepo.On("GetWithRoles", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "deleted@example.com"}, nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - email taken meanwhile",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetDeletedByID", mock.Anything, userID).Return(deletedUser, nil)
+				userRepo.On("GetByEmail", mock.Anything, "deleted@example.com").Return(&entity.User{ID: uuid.New()}, nil)
+			},
+			expectedError: domainErrors.ErrUserAlreadyExists,
+		},
+		{
+			name: "error - not deleted",
+			setupMocks: func(userRepo *mocks.MockUserRepository) {
+				userRepo.On("GetDeletedByID", mock.Anything, userID).Return(nil, errRecordNotFound)
+			},
+			expectedError: domainErrors.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.MockUserRepository)
+			tt.setupMocks(userRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			userUseCase := NewUserUseCase(userRepo, new(mocks.MockRoleRepository), new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
+			resp, err := userUseCase.RestoreUser(context.Background(), userID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Nil(t, resp)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, resp.DeletedAt)
+				assert.Equal(t, []string{"user:restore"}, auditLogger.actions)
 			}
 
 			userRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestUserUseCase_AssignRoleToUser_WithExpiry(t *testing.T) {
+	userID := uuid.New()
+	roleID := uuid.New()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		expiresAt     *time.Time
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRoleRepository)
+		expectedError error
+	}{
+		{
+			name:      "success - time-bound grant",
+			expiresAt: &future,
+			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID, Slug: "staff"}, nil)
+				userRepo.On("AssignRole", mock.Anything, userID, roleID, &future).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:          "error - expiry in the past",
+			expiresAt:     &past,
+			setupMocks:    func(*mocks.MockUserRepository, *mocks.MockRoleRepository) {},
+			expectedError: domainErrors.ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.MockUserRepository)
+			roleRepo := new(mocks.MockRoleRepository)
+			tt.setupMocks(userRepo, roleRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			userUseCase := NewUserUseCase(userRepo, roleRepo, new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
+			err := userUseCase.AssignRoleToUser(context.Background(), userID, roleID, tt.expiresAt)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"user:assign_role"}, auditLogger.actions)
+			}
+
+			userRepo.AssertExpectations(t)
+			roleRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUseCase_RevokeExpiredRoles(t *testing.T) {
+	expiredAt := time.Now().Add(-time.Minute)
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("RevokeExpiredRoles", mock.Anything, mock.AnythingOfType("time.Time")).Return([]*entity.UserRole{
+		{UserID: uuid.New(), RoleID: uuid.New(), ExpiresAt: &expiredAt},
+		{UserID: uuid.New(), RoleID: uuid.New(), ExpiresAt: &expiredAt},
+	}, nil)
+
+	auditLogger := &recordingAuditLogger{}
+	userUseCase := NewUserUseCase(userRepo, new(mocks.MockRoleRepository), new(mocks.MockDormitoryRepository), auditLogger, testCursors, &inlineTxManager{})
+	revoked, err := userUseCase.RevokeExpiredRoles(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	assert.Equal(t, []string{"user:role_expired", "user:role_expired"}, auditLogger.actions)
+	userRepo.AssertExpectations(t)
+}
+
+func TestUserUseCase_AssignDormitoryRole(t *testing.T) {
+	userID := uuid.New()
+	dormitoryID := uuid.New()
+	roleID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockUserRepository, *mocks.MockRoleRepository, *mocks.MockDormitoryRepository)
+		expectedError error
+	}{
+		{
+			name: "success - assign role within dormitory",
+			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository, dormitoryRepo *mocks.MockDormitoryRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				dormitoryRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(&entity.Role{ID: roleID, Slug: "warden"}, nil)
+				userRepo.On("AssignDormitoryRole", mock.Anything, userID, dormitoryID, roleID).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "error - dormitory not found",
+			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository, dormitoryRepo *mocks.MockDormitoryRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				dormitoryRepo.On("GetByID", mock.Anything, dormitoryID).Return(nil, domainErrors.ErrDormitoryNotFound)
+			},
+			expectedError: domainErrors.ErrDormitoryNotFound,
+		},
+		{
+			name: "error - role not found",
+			setupMocks: func(userRepo *mocks.MockUserRepository, roleRepo *mocks.MockRoleRepository, dormitoryRepo *mocks.MockDormitoryRepository) {
+				userRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+				dormitoryRepo.On("GetByID", mock.Anything, dormitoryID).Return(&entity.Dormitory{ID: dormitoryID}, nil)
+				roleRepo.On("GetByID", mock.Anything, roleID).Return(nil, domainErrors.ErrRoleNotFound)
+			},
+			expectedError: domainErrors.ErrRoleNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.MockUserRepository)
+			roleRepo := new(mocks.MockRoleRepository)
+			dormitoryRepo := new(mocks.MockDormitoryRepository)
+			tt.setupMocks(userRepo, roleRepo, dormitoryRepo)
+
+			auditLogger := &recordingAuditLogger{}
+			userUseCase := NewUserUseCase(userRepo, roleRepo, dormitoryRepo, auditLogger, testCursors, &inlineTxManager{})
+			err := userUseCase.AssignDormitoryRole(context.Background(), userID, dormitoryID, roleID)
+
+			if tt.expectedError != nil {
+				assert.Equal(t, tt.expectedError, err)
+				assert.Empty(t, auditLogger.actions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"user:assign_dormitory_role"}, auditLogger.actions)
+			}
+
+			userRepo.AssertExpectations(t)
+			roleRepo.AssertExpectations(t)
+			dormitoryRepo.AssertExpectations(t)
+		})
+	}
+}
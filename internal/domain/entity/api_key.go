@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyPrefix marks personal access tokens so they can be told apart from JWTs
+const APIKeyPrefix = "pat_"
+
+// APIKey represents a long-lived personal access token owned by a user.
+// Only the SHA-256 hash of the key is stored; Prefix keeps enough of the
+// plaintext to let users identify their keys.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	Name       string     `json:"name" gorm:"size:100;not null"`
+	Prefix     string     `json:"prefix" gorm:"size:16;not null"`
+	KeyHash    string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relations
+	Permissions []Permission `gorm:"many2many:api_key_permissions;" json:"permissions,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsExpired checks if the key has an expiry time and is past it
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && now.After(*k.ExpiresAt)
+}
+
+// HasPermission checks if the key's scope includes a specific permission
+func (k *APIKey) HasPermission(permissionName string) bool {
+	for _, perm := range k.Permissions {
+		if MatchPermission(perm.Name, permissionName) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet resolves the permissions in the key's scope
+func (k *APIKey) PermissionSet() *PermissionSet {
+	set := NewPermissionSet()
+	for _, perm := range k.Permissions {
+		set.Add(perm.Name)
+	}
+	return set
+}
+
+// Restrict returns a copy of the user whose roles only grant the permissions
+// in the key's scope, so permission checks work the same as for access tokens.
+// A role keeps the permissions the key covers, plus the key's permissions
+// that the role covers (e.g. "user:read" on a key for a role holding "user:*").
+// Roles scoped to a dormitory are restricted the same way.
+func (k *APIKey) Restrict(user *User) *User {
+	restricted := *user
+	restricted.Roles = make([]Role, len(user.Roles))
+	for i, role := range user.Roles {
+		restricted.Roles[i] = k.restrictRole(role)
+	}
+	restricted.DormitoryRoles = make([]UserDormitoryRole, len(user.DormitoryRoles))
+	for i, scoped := range user.DormitoryRoles {
+		scoped.Role = k.restrictRole(scoped.Role)
+		restricted.DormitoryRoles[i] = scoped
+	}
+	return &restricted
+}
+
+// restrictRole returns a copy of the role that only holds permissions in the key's scope
+func (k *APIKey) restrictRole(role Role) Role {
+	effective := role.EffectivePermissions()
+	permissions := make([]Permission, 0, len(effective))
+	kept := make(map[string]bool, len(effective))
+	for _, perm := range effective {
+		if k.HasPermission(perm.Name) {
+			permissions = append(permissions, perm)
+			kept[perm.Name] = true
+		}
+	}
+	for _, perm := range k.Permissions {
+		if !kept[perm.Name] && role.HasPermission(perm.Name) {
+			permissions = append(permissions, perm)
+			kept[perm.Name] = true
+		}
+	}
+	role.Permissions = permissions
+	role.InheritedPermissions = nil
+	return role
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash of a plaintext key, used for storage lookups
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
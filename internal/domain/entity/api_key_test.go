@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKey_IsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	assert.False(t, (&APIKey{}).IsExpired(now))
+	assert.True(t, (&APIKey{ExpiresAt: &past}).IsExpired(now))
+	assert.False(t, (&APIKey{ExpiresAt: &future}).IsExpired(now))
+}
+
+func TestAPIKey_Restrict(t *testing.T) {
+	user := &User{
+		ID: uuid.New(),
+		Roles: []Role{
+			{
+				Name: "Admin",
+				Slug: "admin",
+				Permissions: []Permission{
+					{ID: uuid.New(), Name: "user:read"},
+					{ID: uuid.New(), Name: "user:delete"},
+				},
+			},
+		},
+	}
+	key := &APIKey{Permissions: []Permission{{Name: "user:read"}}}
+
+	restricted := key.Restrict(user)
+
+	assert.True(t, restricted.HasPermission("user:read"))
+	assert.False(t, restricted.HasPermission("user:delete"))
+	assert.True(t, restricted.HasRole("admin"))
+	// The original user is left untouched
+	assert.True(t, user.HasPermission("user:delete"))
+}
+
+func TestAPIKey_Restrict_Wildcards(t *testing.T) {
+	user := &User{
+		ID: uuid.New(),
+		Roles: []Role{
+			{Name: "user-admin", Permissions: []Permission{{Name: "user:*"}}},
+			{Name: "dorm-admin", Permissions: []Permission{{Name: "dorm:read"}, {Name: "dorm:delete"}}},
+		},
+	}
+
+	// A narrow key on a wildcard role
+	restricted := (&APIKey{Permissions: []Permission{{Name: "user:read"}}}).Restrict(user)
+	assert.True(t, restricted.HasPermission("user:read"))
+	assert.False(t, restricted.HasPermission("user:update"))
+	assert.False(t, restricted.HasPermission("dorm:read"))
+
+	// A wildcard key keeps only what the roles grant
+	restricted = (&APIKey{Permissions: []Permission{{Name: "dorm:*"}}}).Restrict(user)
+	assert.True(t, restricted.HasPermission("dorm:delete"))
+	assert.False(t, restricted.HasPermission("dorm:update"))
+	assert.False(t, restricted.HasPermission("user:read"))
+}
+
+func TestAPIKey_Restrict_DormitoryRoles(t *testing.T) {
+	dormitoryID := uuid.New()
+	user := &User{
+		ID: uuid.New(),
+		DormitoryRoles: []UserDormitoryRole{
+			{
+				DormitoryID: dormitoryID,
+				Role:        Role{Name: "Warden", Slug: "warden", Permissions: []Permission{{Name: "dorm:read"}, {Name: "dorm:delete"}}},
+			},
+		},
+	}
+
+	restricted := (&APIKey{Permissions: []Permission{{Name: "dorm:read"}}}).Restrict(user)
+
+	assert.True(t, restricted.PermissionSetFor(dormitoryID).Has("dorm:read"))
+	assert.False(t, restricted.PermissionSetFor(dormitoryID).Has("dorm:delete"))
+	assert.True(t, restricted.CanAccessDormitory(dormitoryID))
+	// The original user is left untouched
+	assert.True(t, user.PermissionSetFor(dormitoryID).Has("dorm:delete"))
+}
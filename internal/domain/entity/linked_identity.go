@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LinkedIdentity links an account at an external identity provider to a user.
+// Provider and Subject together identify the external account.
+type LinkedIdentity struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	Provider    string     `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_linked_identities_provider_subject"`
+	Subject     string     `json:"subject" gorm:"size:255;not null;uniqueIndex:idx_linked_identities_provider_subject"`
+	Email       string     `json:"email" gorm:"size:255"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (LinkedIdentity) TableName() string {
+	return "linked_identities"
+}
+
+// OIDCLoginState holds the server side of a pending OpenID Connect login:
+// the nonce expected in the ID token and the PKCE code verifier.
+// It is looked up by the hash of the state parameter and used once.
+type OIDCLoginState struct {
+	StateHash    string    `json:"-" gorm:"size:64;primaryKey"`
+	Provider     string    `json:"provider" gorm:"size:50;not null"`
+	Nonce        string    `json:"-" gorm:"size:100;not null"`
+	CodeVerifier string    `json:"-" gorm:"size:100;not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (OIDCLoginState) TableName() string {
+	return "oidc_login_states"
+}
+
+// IsExpired checks if the login state is past its expiry time
+func (s *OIDCLoginState) IsExpired(now time.Time) bool {
+	return now.After(s.ExpiresAt)
+}
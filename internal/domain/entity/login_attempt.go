@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// LoginAttempt tracks recent failed logins for a key, either an account email or a client IP
+type LoginAttempt struct {
+	Key           string     `json:"key" gorm:"size:320;primaryKey"`
+	Failures      int        `json:"failures"`
+	LastFailureAt time.Time  `json:"last_failure_at" gorm:"index"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (LoginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+// IsLocked checks if further attempts must wait until LockedUntil
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,3 +25,41 @@ type Permission struct {
 func (Permission) TableName() string {
 	return "permissions"
 }
+
+// ParsePermissionName splits a permission name in "resource:action" format.
+// Both parts are lowercase identifiers (letters, digits and underscores) or the
+// "*" wildcard, and "*" alone grants every permission.
+func ParsePermissionName(name string) (resource, action string, ok bool) {
+	if name == PermissionWildcard {
+		return PermissionWildcard, PermissionWildcard, true
+	}
+
+	resource, action, found := strings.Cut(name, ":")
+	if !found || !isPermissionPart(resource) || !isPermissionPart(action) {
+		return "", "", false
+	}
+	return resource, action, true
+}
+
+// PermissionSlug returns the slug that belongs to a permission name,
+// e.g. "user:read" → "user-read", "dorm:*" → "dorm-all", "dorm:access_all" → "dorm-access-all"
+func PermissionSlug(name string) string {
+	return permissionSlugReplacer.Replace(name)
+}
+
+var permissionSlugReplacer = strings.NewReplacer(":", "-", "_", "-", PermissionWildcard, "all")
+
+func isPermissionPart(part string) bool {
+	if part == PermissionWildcard {
+		return true
+	}
+	if part == "" || part[0] < 'a' || part[0] > 'z' {
+		return false
+	}
+	for _, r := range part {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
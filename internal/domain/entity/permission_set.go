@@ -0,0 +1,132 @@
+package entity
+
+import "strings"
+
+// PermissionWildcard matches any resource or action in a permission pattern,
+// e.g. "user:*", "*:read" or "*" on its own
+const PermissionWildcard = "*"
+
+// PermissionDormAccessAll grants access to every dormitory without an explicit assignment
+const PermissionDormAccessAll = "dorm:access_all"
+
+// ImpliedActions lists the actions that come with holding another action,
+// e.g. a role that may update a resource may also read it
+var ImpliedActions = map[string][]string{
+	"create": {"read"},
+	"update": {"read"},
+	"delete": {"read"},
+}
+
+// MatchPermission checks if a granted permission or pattern covers a required
+// permission. Permissions are "resource:action" names; names without a
+// resource/action separator only match exactly.
+func MatchPermission(granted, required string) bool {
+	if required == "" {
+		return false
+	}
+	if granted == required || granted == PermissionWildcard {
+		return true
+	}
+
+	grantedResource, grantedAction, ok := strings.Cut(granted, ":")
+	if !ok {
+		return false
+	}
+	requiredResource, requiredAction, ok := strings.Cut(required, ":")
+	if !ok {
+		return false
+	}
+
+	if grantedResource != PermissionWildcard && grantedResource != requiredResource {
+		return false
+	}
+	return grantedAction == PermissionWildcard || actionImplies(grantedAction, requiredAction)
+}
+
+// actionImplies checks if an action is, or transitively implies, another action
+func actionImplies(action, required string) bool {
+	if action == required {
+		return true
+	}
+	for _, implied := range impliedClosure(action) {
+		if implied == required {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionSet is the resolved set of permissions granted to a principal.
+// Exact names, including implied actions, are looked up directly; only
+// wildcard patterns are matched one by one.
+type PermissionSet struct {
+	exact    map[string]bool
+	patterns []string
+}
+
+// NewPermissionSet creates a permission set from granted permission names and patterns
+func NewPermissionSet(names ...string) *PermissionSet {
+	set := &PermissionSet{exact: make(map[string]bool, len(names))}
+	for _, name := range names {
+		set.Add(name)
+	}
+	return set
+}
+
+// Add grants a permission name or pattern
+func (s *PermissionSet) Add(name string) {
+	if name == "" {
+		return
+	}
+
+	if strings.Contains(name, PermissionWildcard) {
+		for _, pattern := range s.patterns {
+			if pattern == name {
+				return
+			}
+		}
+		s.patterns = append(s.patterns, name)
+		return
+	}
+
+	s.exact[name] = true
+	if resource, action, ok := strings.Cut(name, ":"); ok {
+		for _, implied := range impliedClosure(action) {
+			s.exact[resource+":"+implied] = true
+		}
+	}
+}
+
+// Has checks if the set grants a specific permission
+func (s *PermissionSet) Has(permission string) bool {
+	if s == nil || permission == "" {
+		return false
+	}
+	if s.exact[permission] {
+		return true
+	}
+	for _, pattern := range s.patterns {
+		if MatchPermission(pattern, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// impliedClosure returns every action implied by an action, directly or transitively
+func impliedClosure(action string) []string {
+	seen := map[string]bool{action: true}
+	closure := make([]string, 0)
+	pending := append([]string(nil), ImpliedActions[action]...)
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		closure = append(closure, current)
+		pending = append(pending, ImpliedActions[current]...)
+	}
+	return closure
+}
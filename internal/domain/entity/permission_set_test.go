@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPermission(t *testing.T) {
+	tests := []struct {
+		name           string
+		granted        string
+		required       string
+		expectedResult bool
+	}{
+		{name: "exact match", granted: "user:read", required: "user:read", expectedResult: true},
+		{name: "different action", granted: "user:read", required: "user:update", expectedResult: false},
+		{name: "different resource", granted: "user:read", required: "dorm:read", expectedResult: false},
+		{name: "global wildcard", granted: "*", required: "role:delete", expectedResult: true},
+		{name: "resource wildcard", granted: "user:*", required: "user:delete", expectedResult: true},
+		{name: "resource wildcard on other resource", granted: "user:*", required: "dorm:delete", expectedResult: false},
+		{name: "action wildcard", granted: "*:read", required: "audit:read", expectedResult: true},
+		{name: "action wildcard with other action", granted: "*:read", required: "audit:delete", expectedResult: false},
+		{name: "update implies read", granted: "dorm:update", required: "dorm:read", expectedResult: true},
+		{name: "delete implies read", granted: "dorm:delete", required: "dorm:read", expectedResult: true},
+		{name: "read does not imply update", granted: "dorm:read", required: "dorm:update", expectedResult: false},
+		{name: "implied action only on same resource", granted: "dorm:update", required: "user:read", expectedResult: false},
+		{name: "action wildcard with implied action", granted: "*:update", required: "role:read", expectedResult: true},
+		{name: "name without separator matches exactly", granted: "users.read", required: "users.read", expectedResult: true},
+		{name: "name without separator", granted: "users.write", required: "users.read", expectedResult: false},
+		{name: "pattern is not a required permission", granted: "user:read", required: "user:*", expectedResult: false},
+		{name: "empty required permission", granted: "*", required: "", expectedResult: false},
+		{name: "empty granted permission", granted: "", required: "user:read", expectedResult: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, MatchPermission(tt.granted, tt.required))
+		})
+	}
+}
+
+func TestPermissionSet_Has(t *testing.T) {
+	set := NewPermissionSet("user:update", "dorm:*", "*:read", "dorm:*")
+
+	tests := []struct {
+		name           string
+		permission     string
+		expectedResult bool
+	}{
+		{name: "granted permission", permission: "user:update", expectedResult: true},
+		{name: "implied action", permission: "user:read", expectedResult: true},
+		{name: "not granted action", permission: "user:delete", expectedResult: false},
+		{name: "resource pattern", permission: "dorm:delete", expectedResult: true},
+		{name: "action pattern", permission: "audit:read", expectedResult: true},
+		{name: "not granted", permission: "role:create", expectedResult: false},
+		{name: "empty permission", permission: "", expectedResult: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, set.Has(tt.permission))
+		})
+	}
+
+	var empty *PermissionSet
+	assert.False(t, empty.Has("user:read"))
+}
+
+func TestUser_PermissionSet(t *testing.T) {
+	user := &User{
+		ID: uuid.New(),
+		Roles: []Role{
+			{Name: "support", Permissions: []Permission{{Name: "user:update"}}},
+			{Name: "auditor", Permissions: []Permission{{Name: "audit:*"}}},
+		},
+	}
+
+	set := user.PermissionSet()
+
+	assert.True(t, set.Has("user:read"))
+	assert.True(t, set.Has("audit:export"))
+	assert.False(t, set.Has("user:delete"))
+	assert.True(t, user.HasPermission("audit:read"))
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePermissionName(t *testing.T) {
+	tests := []struct {
+		name             string
+		permission       string
+		expectedResource string
+		expectedAction   string
+		expectedOK       bool
+	}{
+		{name: "resource and action", permission: "user:read", expectedResource: "user", expectedAction: "read", expectedOK: true},
+		{name: "underscore in action", permission: "dorm:access_all", expectedResource: "dorm", expectedAction: "access_all", expectedOK: true},
+		{name: "resource wildcard", permission: "dorm:*", expectedResource: "dorm", expectedAction: "*", expectedOK: true},
+		{name: "global wildcard", permission: "*", expectedResource: "*", expectedAction: "*", expectedOK: true},
+		{name: "missing action", permission: "user:", expectedOK: false},
+		{name: "missing separator", permission: "user-read", expectedOK: false},
+		{name: "uppercase", permission: "User:read", expectedOK: false},
+		{name: "too many parts", permission: "user:read:own", expectedOK: false},
+		{name: "leading digit", permission: "1user:read", expectedOK: false},
+		{name: "empty", permission: "", expectedOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, action, ok := ParsePermissionName(tt.permission)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedResource, resource)
+			assert.Equal(t, tt.expectedAction, action)
+		})
+	}
+}
+
+func TestPermissionSlug(t *testing.T) {
+	assert.Equal(t, "user-read", PermissionSlug("user:read"))
+	assert.Equal(t, "dorm-access-all", PermissionSlug("dorm:access_all"))
+	assert.Equal(t, "role-all", PermissionSlug("role:*"))
+	assert.Equal(t, "all", PermissionSlug("*"))
+}
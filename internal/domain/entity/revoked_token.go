@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// RevokedToken represents a denylisted access token, identified by its jti claim
+type RevokedToken struct {
+	TokenID   string    `json:"token_id" gorm:"size:64;primaryKey"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (RevokedToken) TableName() string {
+	return "revoked_tokens"
+}
@@ -6,19 +6,29 @@ import (
 	"github.com/google/uuid"
 )
 
+// DefaultRoleSlug is the role assigned to new users when no role is given
+const DefaultRoleSlug = "user"
+
 // Role represents a role entity in the domain
 type Role struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	IsActive    bool      `json:"is_active"`
-	IsProtected bool      `json:"is_protected"` // Roles that cannot have permissions edited
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	IsActive    bool       `json:"is_active"`
+	IsProtected bool       `json:"is_protected"`                               // Roles that cannot have permissions edited
+	RequireMFA  bool       `json:"require_mfa"`                                // Users holding this role must use two-factor authentication
+	ParentID    *uuid.UUID `gorm:"type:uuid;index" json:"parent_id,omitempty"` // Role whose permissions this role inherits
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 
 	// Relations
+	Parent      *Role        `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL;" json:"parent,omitempty"`
 	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions,omitempty"`
 	Users       []User       `gorm:"many2many:user_roles;" json:"users,omitempty"`
+
+	// InheritedPermissions are the permissions of the role's ancestors that are
+	// not assigned directly. They are resolved by the repository, not stored.
+	InheritedPermissions []Permission `gorm:"-" json:"inherited_permissions,omitempty"`
 }
 
 // TableName specifies the table name for GORM
@@ -26,10 +36,22 @@ func (Role) TableName() string {
 	return "roles"
 }
 
-// HasPermission checks if role has a specific permission
+// EffectivePermissions returns the permissions assigned directly to the role
+// followed by those inherited from its ancestors
+func (r *Role) EffectivePermissions() []Permission {
+	if len(r.InheritedPermissions) == 0 {
+		return r.Permissions
+	}
+	permissions := make([]Permission, 0, len(r.Permissions)+len(r.InheritedPermissions))
+	permissions = append(permissions, r.Permissions...)
+	return append(permissions, r.InheritedPermissions...)
+}
+
+// HasPermission checks if role has a specific permission, directly, through
+// an ancestor role or through a wildcard pattern or implied action
 func (r *Role) HasPermission(permissionName string) bool {
-	for _, perm := range r.Permissions {
-		if perm.Name == permissionName {
+	for _, perm := range r.EffectivePermissions() {
+		if MatchPermission(perm.Name, permissionName) {
 			return true
 		}
 	}
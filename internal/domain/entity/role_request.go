@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RoleRequestStatus is the review state of a role request
+type RoleRequestStatus string
+
+const (
+	RoleRequestPending  RoleRequestStatus = "pending"
+	RoleRequestApproved RoleRequestStatus = "approved"
+	RoleRequestDenied   RoleRequestStatus = "denied"
+)
+
+// RoleRequest is a user's request to be granted a role, optionally until a
+// given time (just-in-time elevation). A reviewer approves or denies it.
+type RoleRequest struct {
+	ID         uuid.UUID         `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID         `json:"user_id" gorm:"type:uuid;index;not null"`
+	RoleID     uuid.UUID         `json:"role_id" gorm:"type:uuid;index;not null"`
+	Reason     string            `json:"reason" gorm:"size:500"`
+	Status     RoleRequestStatus `json:"status" gorm:"size:20;index;not null"`
+	ExpiresAt  *time.Time        `json:"expires_at,omitempty"` // When the granted role is revoked again
+	ReviewerID *uuid.UUID        `json:"reviewer_id,omitempty" gorm:"type:uuid"`
+	ReviewNote string            `json:"review_note,omitempty" gorm:"size:500"`
+	ReviewedAt *time.Time        `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+
+	// Relations
+	User User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Role Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+}
+
+// TableName specifies the table name for GORM
+func (RoleRequest) TableName() string {
+	return "role_requests"
+}
+
+// IsPending checks if the request still awaits review
+func (r *RoleRequest) IsPending() bool {
+	return r.Status == RoleRequestPending
+}
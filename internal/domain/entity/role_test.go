@@ -63,3 +63,18 @@ func TestRole_HasPermission_EmptyPermissions(t *testing.T) {
 	result := role.HasPermission("users.read")
 	assert.False(t, result)
 }
+
+func TestRole_HasPermission_Inherited(t *testing.T) {
+	role := &Role{
+		ID:                   uuid.New(),
+		Name:                 "super_admin",
+		Permissions:          []Permission{{ID: uuid.New(), Name: "permission:*"}},
+		InheritedPermissions: []Permission{{ID: uuid.New(), Name: "user:update"}},
+	}
+
+	assert.True(t, role.HasPermission("permission:create"))
+	assert.True(t, role.HasPermission("user:update"))
+	assert.True(t, role.HasPermission("user:read"))
+	assert.False(t, role.HasPermission("role:read"))
+	assert.Len(t, role.EffectivePermissions(), 2)
+}
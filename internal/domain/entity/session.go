@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session represents a login on a device. Each session owns one refresh token
+// family and stays active while that family has an unrevoked, unexpired token.
+type Session struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;index;not null"`
+	FamilyID   uuid.UUID `json:"-" gorm:"type:uuid;uniqueIndex;not null"`
+	UserAgent  string    `json:"user_agent" gorm:"size:512"`
+	IPAddress  string    `json:"ip_address" gorm:"size:45"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Session) TableName() string {
+	return "user_sessions"
+}
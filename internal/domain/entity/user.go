@@ -5,22 +5,25 @@ import (
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 // User represents a user entity in the domain
 type User struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Never expose password in JSON
-	Name      string    `json:"name"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID              uuid.UUID      `json:"id"`
+	Email           string         `json:"email"`
+	Password        string         `json:"-"` // Never expose password in JSON
+	Name            string         `json:"name"`
+	IsActive        bool           `json:"is_active"`
+	EmailVerifiedAt *time.Time     `json:"email_verified_at,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"` // Soft delete, excluded from queries by default
 
 	// Relations
-	Roles       []Role       `gorm:"many2many:user_roles;" json:"roles,omitempty"`
-	Dormitories []Dormitory  `gorm:"many2many:user_dormitories;" json:"dormitories,omitempty"`
+	Roles          []Role              `gorm:"many2many:user_roles;" json:"roles,omitempty"`
+	Dormitories    []Dormitory         `gorm:"many2many:user_dormitories;" json:"dormitories,omitempty"`
+	DormitoryRoles []UserDormitoryRole `gorm:"foreignKey:UserID" json:"dormitory_roles,omitempty"`
 }
 
 // TableName specifies the table name for GORM
@@ -44,38 +47,84 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
-// HasPermission checks if user has a specific permission through their roles
-func (u *User) HasPermission(permission string) bool {
+// IsEmailVerified checks if the user has verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// RequiresMFA checks if any of the user's roles makes two-factor authentication mandatory
+func (u *User) RequiresMFA() bool {
 	for _, role := range u.Roles {
-		for _, perm := range role.Permissions {
-			if perm.Name == permission {
-				return true
-			}
+		if role.RequireMFA {
+			return true
 		}
 	}
 	return false
 }
 
-// HasRole checks if user has a specific role
-func (u *User) HasRole(roleName string) bool {
+// HasPermission checks if user has a specific permission through their roles.
+// Prefer PermissionSet when checking several permissions for the same user.
+func (u *User) HasPermission(permission string) bool {
+	return u.PermissionSet().Has(permission)
+}
+
+// PermissionSet resolves the permissions granted by all of the user's roles,
+// including wildcard patterns and implied actions.
+// Roles scoped to a dormitory are not included, see PermissionSetFor.
+func (u *User) PermissionSet() *PermissionSet {
+	set := NewPermissionSet()
 	for _, role := range u.Roles {
-		if role.Name == roleName {
+		for _, perm := range role.EffectivePermissions() {
+			set.Add(perm.Name)
+		}
+	}
+	return set
+}
+
+// PermissionSetFor resolves the permissions of the user within a dormitory:
+// the permissions of the global roles plus those of the roles scoped to that dormitory
+func (u *User) PermissionSetFor(dormitoryID uuid.UUID) *PermissionSet {
+	set := u.PermissionSet()
+	for _, scoped := range u.DormitoryRoles {
+		if scoped.DormitoryID != dormitoryID {
+			continue
+		}
+		for _, perm := range scoped.Role.EffectivePermissions() {
+			set.Add(perm.Name)
+		}
+	}
+	return set
+}
+
+// HasRole checks if user has a specific role, identified by its slug
+func (u *User) HasRole(roleSlug string) bool {
+	for _, role := range u.Roles {
+		if role.Slug == roleSlug {
 			return true
 		}
 	}
 	return false
 }
 
+// CanAccessAllDormitories checks if user's roles grant access to every dormitory.
+// Only global roles are considered: a scoped role never widens access beyond its dormitory.
+func (u *User) CanAccessAllDormitories() bool {
+	return u.HasPermission(PermissionDormAccessAll)
+}
+
 // CanAccessDormitory checks if user can access a specific dormitory
-// Returns true if user has access to all dormitories or specific dormitory
+// Returns true if user has access to all dormitories, is linked to the specific
+// dormitory or holds a role scoped to it
 func (u *User) CanAccessDormitory(dormitoryID uuid.UUID) bool {
-	// Check if user has access to all dormitories (via special role or guard)
-	for _, role := range u.Roles {
-		if role.Name == "admin" || role.Name == "super_admin" {
-			return true
-		}
+	// Check if user has access to all dormitories (via permission)
+	if u.CanAccessAllDormitories() {
+		return true
 	}
+	return u.IsAssignedToDormitory(dormitoryID)
+}
 
+// IsAssignedToDormitory checks if user is linked to the dormitory or holds a role scoped to it
+func (u *User) IsAssignedToDormitory(dormitoryID uuid.UUID) bool {
 	// Check if user has access to specific dormitory
 	for _, dorm := range u.Dormitories {
 		if dorm.ID == dormitoryID {
@@ -83,5 +132,12 @@ func (u *User) CanAccessDormitory(dormitoryID uuid.UUID) bool {
 		}
 	}
 
+	// Check if user holds a role within the specific dormitory
+	for _, scoped := range u.DormitoryRoles {
+		if scoped.DormitoryID == dormitoryID {
+			return true
+		}
+	}
+
 	return false
 }
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDormitoryRole assigns a role to a user within a single dormitory.
+// Unlike UserRole, the role's permissions only apply to requests for that dormitory.
+type UserDormitoryRole struct {
+	UserID      uuid.UUID `gorm:"primaryKey" json:"user_id"`
+	DormitoryID uuid.UUID `gorm:"primaryKey" json:"dormitory_id"`
+	RoleID      uuid.UUID `gorm:"primaryKey" json:"role_id"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Relations
+	Role      Role      `gorm:"foreignKey:RoleID" json:"role,omitempty"`
+	Dormitory Dormitory `gorm:"foreignKey:DormitoryID" json:"dormitory,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (UserDormitoryRole) TableName() string {
+	return "user_dormitory_roles"
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserMFA represents a user's TOTP two-factor authentication setup.
+// The secret is stored as base32 because it is needed to verify codes.
+type UserMFA struct {
+	UserID       uuid.UUID  `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Secret       string     `json:"-" gorm:"size:64;not null"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedStep int64      `json:"-"` // TOTP time step of the last accepted code, prevents replay
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// IsEnabled checks if enrollment has been confirmed with a valid code
+func (m *UserMFA) IsEnabled() bool {
+	return m.ConfirmedAt != nil
+}
+
+// MFARecoveryCode represents a hashed one-time recovery code
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index;not null"`
+	CodeHash  string     `json:"-" gorm:"size:64;not null"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
@@ -1,16 +1,26 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
-// UserRole represents the many-to-many relationship between users and roles
+// UserRole represents the many-to-many relationship between users and roles.
+// A grant with ExpiresAt is time-bound: it stops counting once it expires and
+// is revoked by the role grant sweeper.
 type UserRole struct {
-	UserID uuid.UUID `gorm:"primaryKey"`
-	RoleID uuid.UUID `gorm:"primaryKey"`
+	UserID    uuid.UUID  `gorm:"primaryKey"`
+	RoleID    uuid.UUID  `gorm:"primaryKey"`
+	ExpiresAt *time.Time `gorm:"index"`
 }
 
 // TableName specifies the table name for GORM
 func (UserRole) TableName() string {
 	return "user_roles"
 }
+
+// IsExpired checks if a time-bound grant has expired at the given time
+func (ur *UserRole) IsExpired(now time.Time) bool {
+	return ur.ExpiresAt != nil && !ur.ExpiresAt.After(now)
+}
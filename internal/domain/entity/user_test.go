@@ -154,8 +154,8 @@ func TestUser_HasRole(t *testing.T) {
 		ID:    uuid.New(),
 		Email: "test@example.com",
 		Roles: []Role{
-			{ID: uuid.New(), Name: "admin"},
-			{ID: uuid.New(), Name: "user"},
+			{ID: uuid.New(), Name: "Admin", Slug: "admin"},
+			{ID: uuid.New(), Name: "User", Slug: "user"},
 		},
 	}
 
@@ -179,6 +179,11 @@ func TestUser_HasRole(t *testing.T) {
 			roleName:       "super_admin",
 			expectedResult: false,
 		},
+		{
+			name:           "failure - display name is not a slug",
+			roleName:       "Admin",
+			expectedResult: false,
+		},
 		{
 			name:           "failure - empty role",
 			roleName:       "",
@@ -205,29 +210,41 @@ func TestUser_CanAccessDormitory(t *testing.T) {
 		expectedResult bool
 	}{
 		{
-			name: "success - admin can access any dormitory",
+			name: "success - dorm:access_all can access any dormitory",
 			user: &User{
 				ID:    uuid.New(),
 				Email: "admin@example.com",
 				Roles: []Role{
-					{ID: uuid.New(), Name: "admin"},
+					{ID: uuid.New(), Name: "Admin", Slug: "admin", Permissions: []Permission{{Name: PermissionDormAccessAll}}},
 				},
 			},
 			dormitoryID:    dormitoryID,
 			expectedResult: true,
 		},
 		{
-			name: "success - super_admin can access any dormitory",
+			name: "success - global wildcard can access any dormitory",
 			user: &User{
 				ID:    uuid.New(),
 				Email: "superadmin@example.com",
 				Roles: []Role{
-					{ID: uuid.New(), Name: "super_admin"},
+					{ID: uuid.New(), Name: "Super Admin", Slug: "super_admin", Permissions: []Permission{{Name: "*"}}},
 				},
 			},
 			dormitoryID:    dormitoryID,
 			expectedResult: true,
 		},
+		{
+			name: "failure - admin role name alone grants no access",
+			user: &User{
+				ID:    uuid.New(),
+				Email: "admin@example.com",
+				Roles: []Role{
+					{ID: uuid.New(), Name: "admin", Slug: "admin"},
+				},
+			},
+			dormitoryID:    dormitoryID,
+			expectedResult: false,
+		},
 		{
 			name: "success - user can access assigned dormitory",
 			user: &User{
@@ -258,6 +275,30 @@ func TestUser_CanAccessDormitory(t *testing.T) {
 			dormitoryID:    otherDormitoryID,
 			expectedResult: false,
 		},
+		{
+			name: "success - role scoped to the dormitory grants access",
+			user: &User{
+				ID:    uuid.New(),
+				Email: "warden@example.com",
+				DormitoryRoles: []UserDormitoryRole{
+					{DormitoryID: dormitoryID, Role: Role{Name: "Warden", Slug: "warden"}},
+				},
+			},
+			dormitoryID:    dormitoryID,
+			expectedResult: true,
+		},
+		{
+			name: "failure - dorm:access_all in a scoped role does not widen access",
+			user: &User{
+				ID:    uuid.New(),
+				Email: "warden@example.com",
+				DormitoryRoles: []UserDormitoryRole{
+					{DormitoryID: dormitoryID, Role: Role{Name: "Warden", Slug: "warden", Permissions: []Permission{{Name: PermissionDormAccessAll}}}},
+				},
+			},
+			dormitoryID:    otherDormitoryID,
+			expectedResult: false,
+		},
 		{
 			name: "failure - user with no roles or dormitories",
 			user: &User{
@@ -276,3 +317,29 @@ func TestUser_CanAccessDormitory(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_PermissionSetFor(t *testing.T) {
+	dormitoryID := uuid.New()
+	otherDormitoryID := uuid.New()
+
+	user := &User{
+		ID:    uuid.New(),
+		Roles: []Role{{Name: "User", Slug: "user", Permissions: []Permission{{Name: "dorm:read"}}}},
+		DormitoryRoles: []UserDormitoryRole{
+			{
+				DormitoryID: dormitoryID,
+				Role:        Role{Name: "Warden", Slug: "warden", Permissions: []Permission{{Name: "dorm:update"}}},
+			},
+		},
+	}
+
+	// Scoped permissions only apply within their dormitory
+	assert.True(t, user.PermissionSetFor(dormitoryID).Has("dorm:update"))
+	assert.False(t, user.PermissionSetFor(otherDormitoryID).Has("dorm:update"))
+	assert.False(t, user.PermissionSet().Has("dorm:update"))
+	assert.False(t, user.HasPermission("dorm:update"))
+
+	// Global permissions apply everywhere
+	assert.True(t, user.PermissionSetFor(dormitoryID).Has("dorm:read"))
+	assert.True(t, user.PermissionSetFor(otherDormitoryID).Has("dorm:read"))
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	// Authentication errors
@@ -8,26 +11,66 @@ var (
 	ErrTokenExpired       = errors.New("token has expired")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenNotFound      = errors.New("token not found")
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+	ErrAccountLocked      = errors.New("account is temporarily locked")
+	ErrTooManyAttempts    = errors.New("too many attempts")
+
+	// Session errors
+	ErrSessionNotFound = errors.New("session not found")
+
+	// External login errors
+	ErrIdentityProviderNotFound = errors.New("identity provider not found")
+	ErrExternalAuthFailed       = errors.New("external authentication failed")
+	ErrExternalEmailNotVerified = errors.New("email is not verified by the identity provider")
+
+	// Two-factor authentication errors
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFAMandatory      = errors.New("two-factor authentication is required by your role")
 
 	// User errors
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 	ErrUserInactive      = errors.New("user is inactive")
+	ErrEmailNotVerified  = errors.New("email is not verified")
 
 	// Role errors
-	ErrRoleNotFound      = errors.New("role not found")
-	ErrRoleAlreadyExists = errors.New("role already exists")
-	ErrProtectedRole     = errors.New("cannot modify protected role")
+	ErrRoleNotFound       = errors.New("role not found")
+	ErrRoleAlreadyExists  = errors.New("role already exists")
+	ErrProtectedRole      = errors.New("cannot modify protected role")
+	ErrParentRoleNotFound = errors.New("parent role not found")
+	ErrRoleHierarchyCycle = errors.New("role cannot inherit from itself or its descendants")
+
+	// Role request errors
+	ErrRoleRequestNotFound   = errors.New("role request not found")
+	ErrRoleRequestExists     = errors.New("a pending request for this role already exists")
+	ErrRoleRequestNotPending = errors.New("role request has already been reviewed")
+	ErrOwnRoleRequest        = errors.New("cannot review your own role request")
+	ErrRoleAlreadyHeld       = errors.New("role is already held permanently")
 
 	// Permission errors
 	ErrPermissionNotFound      = errors.New("permission not found")
 	ErrPermissionAlreadyExists = errors.New("permission already exists")
 	ErrPermissionDenied        = errors.New("permission denied")
+	ErrInvalidPermissionName   = errors.New("permission name must be in resource:action format")
+	ErrPermissionSlugMismatch  = errors.New("permission slug does not match its name")
+	ErrPermissionInUse         = errors.New("permission is assigned to a protected role")
+
+	// API key errors
+	ErrAPIKeyNotFound = errors.New("api key not found")
 
 	// Dormitory errors
-	ErrDormitoryNotFound      = errors.New("dormitory not found")
-	ErrDormitoryAlreadyExists = errors.New("dormitory already exists")
-	ErrDormitoryAccessDenied  = errors.New("access denied to this dormitory")
+	ErrDormitoryNotFound       = errors.New("dormitory not found")
+	ErrDormitoryAlreadyExists  = errors.New("dormitory already exists")
+	ErrDormitoryAccessDenied   = errors.New("access denied to this dormitory")
+	ErrDormitoryMemberExists   = errors.New("user is already a member of this dormitory")
+	ErrDormitoryMemberNotFound = errors.New("user is not a member of this dormitory")
+
+	// Listing errors
+	ErrInvalidSortField    = errors.New("invalid sort field")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrCursorSortNotUsable = errors.New("cursor pagination only supports the default sort")
 
 	// General errors
 	ErrInternalServer = errors.New("internal server error")
@@ -35,3 +78,17 @@ var (
 	ErrUnauthorized   = errors.New("unauthorized")
 	ErrForbidden      = errors.New("forbidden")
 )
+
+// RetryAfterError wraps an error that clears after a delay, such as a temporary lockout
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
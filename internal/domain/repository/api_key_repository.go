@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// APIKeyRepository defines the interface for API key data operations
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *entity.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+	GetByKeyHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error)
+	Update(ctx context.Context, key *entity.APIKey) error
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
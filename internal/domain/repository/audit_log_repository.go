@@ -10,6 +10,8 @@ import (
 type AuditLogRepository interface {
 	Create(ctx context.Context, log *entity.AuditLog) error
 	List(ctx context.Context, filter AuditLogFilter) ([]*entity.AuditLog, int64, error)
+	// ListAfter lists audit logs newest first from a keyset position; filter.Page and filter.PageSize are ignored
+	ListAfter(ctx context.Context, filter AuditLogFilter, page KeysetPage) ([]*entity.AuditLog, int64, error)
 }
 
 // AuditLogFilter represents filtering and pagination options for listing audit logs
@@ -19,4 +21,6 @@ type AuditLogFilter struct {
 	Resource   string
 	Action     string
 	ActorEmail string
+	// DormitoryID limits the logs to events on the dormitory
+	DormitoryID string
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keyset is the position of a row in (created_at DESC, id DESC) order
+type Keyset struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// KeysetPage asks for the rows after a keyset position, newest first
+type KeysetPage struct {
+	After     *Keyset // nil for the first page
+	Limit     int
+	WithCount bool // Also count every matching row, at the cost of an extra query
+}
+
+// IDPage asks for the rows with an ID above AfterID, in ID order
+type IDPage struct {
+	AfterID   int // 0 for the first page
+	Limit     int
+	WithCount bool // Also count every matching row, at the cost of an extra query
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -13,8 +14,28 @@ type DormitoryRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error)
 	Update(ctx context.Context, dormitory *entity.Dormitory) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error)
+	List(ctx context.Context, filter DormitoryFilter, limit, offset int) ([]*entity.Dormitory, int64, error)
+	// ListAfter lists dormitories newest first from a keyset position; filter.Sort is ignored
+	ListAfter(ctx context.Context, filter DormitoryFilter, page KeysetPage) ([]*entity.Dormitory, int64, error)
+	// ListDeleted lists soft-deleted dormitories, most recently deleted first
+	ListDeleted(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// PurgeDeleted permanently removes dormitories soft-deleted before the given time, with the rows that reference them
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 	AssignToUser(ctx context.Context, userID, dormitoryID uuid.UUID) error
 	RemoveFromUser(ctx context.Context, userID, dormitoryID uuid.UUID) error
 	GetUserDormitories(ctx context.Context, userID uuid.UUID) ([]*entity.Dormitory, error)
+	IsMember(ctx context.Context, userID, dormitoryID uuid.UUID) (bool, error)
+	ListMembers(ctx context.Context, dormitoryID uuid.UUID, limit, offset int) ([]*entity.User, int64, error)
+}
+
+// DormitorySortFields lists the fields dormitories can be sorted by
+var DormitorySortFields = []string{"name", "is_active", "created_at", "updated_at"}
+
+// DormitoryFilter represents search, filtering and sorting options for listing dormitories
+type DormitoryFilter struct {
+	Search   string // Matches name or description, case-insensitive
+	IsActive *bool
+	Sort     Sort // One of DormitorySortFields; newest first when empty
 }
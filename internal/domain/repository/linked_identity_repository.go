@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// LinkedIdentityRepository defines the interface for external identity links
+type LinkedIdentityRepository interface {
+	Create(ctx context.Context, identity *entity.LinkedIdentity) error
+	// GetByProviderSubject returns nil without error when the identity is not linked
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.LinkedIdentity, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.LinkedIdentity, error)
+	UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+// OIDCStateRepository defines the interface for pending OpenID Connect logins
+type OIDCStateRepository interface {
+	Create(ctx context.Context, state *entity.OIDCLoginState) error
+	// Consume deletes and returns the state so it can only be used once
+	Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error)
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// MFARepository defines the interface for two-factor authentication data operations
+type MFARepository interface {
+	// GetByUserID returns nil without error when the user has no MFA setup
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*entity.UserMFA, error)
+	Save(ctx context.Context, mfa *entity.UserMFA) error
+	// Delete removes the MFA setup and recovery codes of the user
+	Delete(ctx context.Context, userID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []entity.MFARecoveryCode) error
+	// UseRecoveryCode marks an unused recovery code as used. It fails if no such code exists.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
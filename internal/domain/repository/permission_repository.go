@@ -12,7 +12,10 @@ type PermissionRepository interface {
 	Create(ctx context.Context, permission *entity.Permission) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Permission, error)
 	GetBySlug(ctx context.Context, slug string) (*entity.Permission, error)
+	GetByName(ctx context.Context, name string) (*entity.Permission, error)
+	GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.Permission, error)
 	Update(ctx context.Context, permission *entity.Permission) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	List(ctx context.Context, limit, offset int) ([]*entity.Permission, int64, error)
+	ListAll(ctx context.Context) ([]*entity.Permission, error)
 }
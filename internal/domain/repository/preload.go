@@ -0,0 +1,24 @@
+package repository
+
+// Relation names a relation that list methods can load alongside the rows.
+// Relations are loaded in batch, with a fixed number of queries per page.
+type Relation string
+
+const (
+	// RelationPermissions loads the permissions of roles, including inherited ones
+	RelationPermissions Relation = "permissions"
+	// RelationRoles loads the roles of users; expired grants are left out
+	RelationRoles Relation = "roles"
+	// RelationDormitories loads the dormitories of users
+	RelationDormitories Relation = "dormitories"
+)
+
+// Preloads reports whether the relation is among the requested ones
+func Preloads(with []Relation, relation Relation) bool {
+	for _, r := range with {
+		if r == relation {
+			return true
+		}
+	}
+	return false
+}
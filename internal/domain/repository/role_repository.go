@@ -14,8 +14,11 @@ type RoleRepository interface {
 	GetBySlug(ctx context.Context, slug string) (*entity.Role, error)
 	Update(ctx context.Context, role *entity.Role) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*entity.Role, int64, error)
+	// List lists roles; RelationPermissions is the only relation roles can preload
+	List(ctx context.Context, limit, offset int, with ...Relation) ([]*entity.Role, int64, error)
 	GetWithPermissions(ctx context.Context, id uuid.UUID) (*entity.Role, error)
 	AssignPermission(ctx context.Context, roleID, permissionID uuid.UUID) error
 	RemovePermission(ctx context.Context, roleID, permissionID uuid.UUID) error
+	// SyncPermissions assigns and removes permissions of a role in one transaction
+	SyncPermissions(ctx context.Context, roleID uuid.UUID, assign, remove []uuid.UUID) error
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// RoleRequestRepository defines the interface for role request data operations
+type RoleRequestRepository interface {
+	Create(ctx context.Context, request *entity.RoleRequest) error
+	// GetByID retrieves a request together with its user and role
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleRequest, error)
+	// List lists requests with the given status, or all requests when status is empty, newest first
+	List(ctx context.Context, status entity.RoleRequestStatus, limit, offset int) ([]*entity.RoleRequest, int64, error)
+	// ListByUser lists the requests of a user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleRequest, error)
+	// HasPending checks if a user already has a pending request for a role
+	HasPending(ctx context.Context, userID, roleID uuid.UUID) (bool, error)
+	// Review stores the review of a pending request. It reports false when
+	// the request was no longer pending, e.g. because another reviewer was first.
+	Review(ctx context.Context, request *entity.RoleRequest) (bool, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// SessionRepository defines the interface for login session data operations
+type SessionRepository interface {
+	Create(ctx context.Context, session *entity.Session) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error)
+	// ListActiveByUser lists sessions whose refresh token family is still usable at the given time
+	ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*entity.Session, error)
+	// Touch records activity (a token refresh) on the session owning a refresh token family
+	Touch(ctx context.Context, familyID uuid.UUID, ipAddress string, at time.Time) error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"strings"
+
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+)
+
+// Sort orders a listing by a single field
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a sort expression such as "name" or "-created_at" (descending).
+// The field must be one of allowed; an empty expression yields the zero Sort, meaning the default order.
+func ParseSort(expr string, allowed []string) (Sort, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Sort{}, nil
+	}
+
+	sort := Sort{Field: strings.TrimPrefix(expr, "-"), Desc: strings.HasPrefix(expr, "-")}
+	for _, field := range allowed {
+		if sort.Field == field {
+			return sort, nil
+		}
+	}
+
+	return Sort{}, domainErrors.ErrInvalidSortField
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "context"
+
+// TransactionManager runs units of work that span several repositories.
+// The context passed to fn carries the transaction; repository calls made
+// with it take part in the transaction, calls made with any other context do not.
+type TransactionManager interface {
+	// WithinTx commits when fn returns nil and rolls back otherwise, returning fn's error.
+	// Calling it again with a context that already carries a transaction nests a savepoint
+	// in it: an error from the inner fn only undoes the inner writes, and those writes
+	// are committed with the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
@@ -14,9 +15,39 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
-	List(ctx context.Context, limit, offset int) ([]*entity.User, int64, error)
+	// List lists users, preloading RelationRoles and RelationDormitories when asked
+	List(ctx context.Context, filter UserFilter, limit, offset int, with ...Relation) ([]*entity.User, int64, error)
+	// ListAfter lists users newest first from a keyset position; filter.Sort is ignored
+	ListAfter(ctx context.Context, filter UserFilter, page KeysetPage, with ...Relation) ([]*entity.User, int64, error)
+	// ListDeleted lists soft-deleted users, most recently deleted first
+	ListDeleted(ctx context.Context, limit, offset int, with ...Relation) ([]*entity.User, int64, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	// PurgeDeleted permanently removes users soft-deleted before the given time, with the rows that reference them
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 	GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	GetWithRolesAndDormitories(ctx context.Context, id uuid.UUID) (*entity.User, error)
-	AssignRole(ctx context.Context, userID, roleID uuid.UUID) error
+	// AssignRole grants a role, until expiresAt when given. Granting it again replaces the expiry,
+	// except that a permanent grant is never shortened.
+	AssignRole(ctx context.Context, userID, roleID uuid.UUID, expiresAt *time.Time) error
+	// HasPermanentRole checks if a user holds a role directly without an expiry
+	HasPermanentRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error)
 	RemoveRole(ctx context.Context, userID, roleID uuid.UUID) error
+	// RevokeExpiredRoles removes the time-bound grants that expired before the given time and returns them
+	RevokeExpiredRoles(ctx context.Context, before time.Time) ([]*entity.UserRole, error)
+	GetDormitoryRoles(ctx context.Context, userID uuid.UUID) ([]*entity.UserDormitoryRole, error)
+	AssignDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error
+	RemoveDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error
+}
+
+// UserSortFields lists the fields users can be sorted by
+var UserSortFields = []string{"name", "email", "is_active", "created_at", "updated_at"}
+
+// UserFilter represents search, filtering and sorting options for listing users
+type UserFilter struct {
+	Search      string // Matches name or email, case-insensitive
+	Role        string // Role slug, granted directly and not expired
+	IsActive    *bool
+	DormitoryID *uuid.UUID // Membership through user_dormitories
+	Sort        Sort       // One of UserSortFields; newest first when empty
 }
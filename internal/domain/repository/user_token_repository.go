@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// UserTokenRepository defines the interface for single-use user token data operations
+type UserTokenRepository interface {
+	Create(ctx context.Context, token *entity.UserToken) error
+	GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*entity.UserToken, error)
+	// MarkUsed marks an unused token as used. It fails if the token was already used.
+	MarkUsed(ctx context.Context, id uuid.UUID) error
+	// InvalidateForUser marks all unused tokens of a user for the given purpose as used
+	InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string) error
+}
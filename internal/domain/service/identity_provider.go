@@ -0,0 +1,22 @@
+package service
+
+import "context"
+
+// ExternalIdentity is the identity asserted by an external identity provider
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// IdentityProvider defines the interface for an external OpenID Connect identity provider
+type IdentityProvider interface {
+	// Name returns the key of the provider used in routes and linked identities
+	Name() string
+	// AuthCodeURL builds the authorization request for the authorization code flow with PKCE (S256)
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange redeems an authorization code and returns the identity from the validated ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*ExternalIdentity, error)
+}
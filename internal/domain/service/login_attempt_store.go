@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+// LoginAttemptStore defines the interface for storing failed login counters.
+// Keys identify what is being throttled, e.g. an account email or a client IP.
+type LoginAttemptStore interface {
+	// Get returns nil without error when the key has no recorded failures
+	Get(ctx context.Context, key string) (*entity.LoginAttempt, error)
+	// Increment atomically records a failure. Counters idle for longer than window start over.
+	Increment(ctx context.Context, key string, window time.Duration) (*entity.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+}
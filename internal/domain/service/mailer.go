@@ -0,0 +1,15 @@
+package service
+
+import "context"
+
+// Mailer defines the interface for sending emails
+type Mailer interface {
+	Send(ctx context.Context, message MailMessage) error
+}
+
+// MailMessage represents a plain-text email
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,12 @@
+package service
+
+import "time"
+
+// OTPService defines the interface for time-based one-time passwords (RFC 6238)
+type OTPService interface {
+	GenerateSecret() (string, error)
+	// ProvisioningURI returns an otpauth:// URI that authenticator apps import via QR code
+	ProvisioningURI(secret, accountName string) string
+	// Validate checks a code against the secret and returns the matched time step
+	Validate(secret, code string, at time.Time) (step int64, ok bool)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitResult represents the outcome of taking a token from a rate limit bucket
+type RateLimitResult struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next token is available, set when not allowed
+	RetryAfter time.Duration
+	// ResetAfter is how long until the bucket is full again
+	ResetAfter time.Duration
+}
+
+// RateLimitStore defines the interface for token-bucket rate limit state.
+// A bucket holds up to limit tokens and refills at limit tokens per window.
+// A store shared between replicas (e.g. Redis) enforces one limit across all instances.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
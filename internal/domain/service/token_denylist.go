@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// TokenDenylist defines the interface for revoking access tokens before they expire.
+// Entries are keyed by the token's jti claim and only need to be kept until expiresAt,
+// after which the token is rejected anyway.
+type TokenDenylist interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
@@ -10,16 +10,31 @@ import (
 type TokenService interface {
 	GenerateAccessToken(userID uuid.UUID, email string, roles []string) (string, error)
 	GenerateRefreshToken(userID uuid.UUID) (string, error)
+	// GenerateMFAToken generates a short-lived token proving the password step of a two-step login
+	GenerateMFAToken(userID uuid.UUID) (string, error)
 	ValidateToken(tokenString string) (*TokenClaims, error)
 	RefreshAccessToken(refreshToken string) (string, error)
+	AccessTokenExpiry() time.Duration
+	RefreshTokenExpiry() time.Duration
+	MFATokenExpiry() time.Duration
+	JWKS() JSONWebKeySet
 }
 
+// Token types stored in the type claim
+const (
+	TokenTypeAccess     = "access"
+	TokenTypeRefresh    = "refresh"
+	TokenTypeMFAPending = "mfa_pending"
+)
+
 // TokenClaims represents the claims in a JWT token
 type TokenClaims struct {
-	UserID uuid.UUID
-	Email  string
-	Roles  []string
-	Exp    int64
+	TokenID string // jti claim
+	Type    string // type claim, one of the TokenType constants
+	UserID  uuid.UUID
+	Email   string
+	Roles   []string
+	Exp     int64
 }
 
 // TokenPair represents a pair of access and refresh tokens
@@ -28,3 +43,20 @@ type TokenPair struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresAt    time.Time `json:"expires_at"`
 }
+
+// JSONWebKeySet represents the public keys used to verify tokens (RFC 7517)
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JSONWebKey represents a single public key in a JSONWebKeySet
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
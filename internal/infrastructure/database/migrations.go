@@ -248,4 +248,359 @@ func init() {
 			return db.Migrator().DropTable(&entity.AuditLog{})
 		},
 	)
+
+	// Migration 007: Create refresh_tokens table
+	RegisterMigration(
+		"007_create_refresh_tokens",
+		"Create refresh_tokens table for refresh token rotation and revocation",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.RefreshToken{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.RefreshToken{})
+		},
+	)
+
+	// Migration 008: Create revoked_tokens table
+	RegisterMigration(
+		"008_create_revoked_tokens",
+		"Create revoked_tokens table for the access token denylist",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.RevokedToken{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.RevokedToken{})
+		},
+	)
+
+	// Migration 009: Add email verification and single-use user tokens
+	RegisterMigration(
+		"009_add_email_verification_and_user_tokens",
+		"Add email_verified_at to users and create user_tokens table",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.User{}, "email_verified_at") {
+				if err := db.Migrator().AddColumn(&entity.User{}, "email_verified_at"); err != nil {
+					return err
+				}
+				// Existing accounts predate verification; treat them as verified so
+				// enabling AUTH_REQUIRE_EMAIL_VERIFICATION does not lock them out
+				if err := db.Exec("UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL").Error; err != nil {
+					return err
+				}
+			}
+			return db.AutoMigrate(&entity.UserToken{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.UserToken{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.User{}, "email_verified_at") {
+				return db.Migrator().DropColumn(&entity.User{}, "email_verified_at")
+			}
+			return nil
+		},
+	)
+
+	// Migration 010: Add two-factor authentication
+	RegisterMigration(
+		"010_add_two_factor_authentication",
+		"Add require_mfa to roles and create user_mfa and mfa_recovery_codes tables",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.Role{}, "require_mfa") {
+				if err := db.Migrator().AddColumn(&entity.Role{}, "require_mfa"); err != nil {
+					return err
+				}
+			}
+			// Administrative roles must use 2FA, matching what the seeder creates
+			if err := db.Exec("UPDATE roles SET require_mfa = ? WHERE slug IN ?", true, []string{"admin", "super_admin"}).Error; err != nil {
+				return err
+			}
+			return db.AutoMigrate(&entity.UserMFA{}, &entity.MFARecoveryCode{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.MFARecoveryCode{}, &entity.UserMFA{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.Role{}, "require_mfa") {
+				return db.Migrator().DropColumn(&entity.Role{}, "require_mfa")
+			}
+			return nil
+		},
+	)
+
+	// Migration 011: Create login_attempts table
+	RegisterMigration(
+		"011_create_login_attempts",
+		"Create login_attempts table for brute-force protection",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.LoginAttempt{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.LoginAttempt{})
+		},
+	)
+
+	// Migration 012: Create api_keys and api_key_permissions tables
+	RegisterMigration(
+		"012_create_api_keys",
+		"Create api_keys and api_key_permissions tables for personal access tokens",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.APIKey{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable("api_key_permissions"); err != nil {
+				return err
+			}
+			return db.Migrator().DropTable(&entity.APIKey{})
+		},
+	)
+
+	// Migration 013: Create linked_identities and oidc_login_states tables
+	RegisterMigration(
+		"013_create_linked_identities",
+		"Create linked_identities and oidc_login_states tables for external login",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.LinkedIdentity{}, &entity.OIDCLoginState{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.OIDCLoginState{}, &entity.LinkedIdentity{})
+		},
+	)
+
+	// Migration 014: Create user_sessions table
+	RegisterMigration(
+		"014_create_user_sessions",
+		"Create user_sessions table for session management",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.Session{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.Session{})
+		},
+	)
+
+	// Migration 015: Replace the hardcoded admin dormitory bypass with a permission
+	RegisterMigration(
+		"015_add_dorm_access_all_permission",
+		"Create dorm:access_all permission and grant it to the admin and super_admin roles",
+		func(db *gorm.DB) error {
+			// Roles that previously bypassed the dormitory guard by name keep their access
+			return ensurePermissions(db, []string{entity.PermissionDormAccessAll}, []string{"admin", "super_admin"})
+		},
+		func(db *gorm.DB) error {
+			return dropPermissions(db, []string{entity.PermissionDormAccessAll})
+		},
+	)
+
+	// Migration 016: Create user_dormitory_roles table
+	RegisterMigration(
+		"016_create_user_dormitory_roles",
+		"Create user_dormitory_roles table for roles scoped to a dormitory",
+		func(db *gorm.DB) error {
+			return db.AutoMigrate(&entity.UserDormitoryRole{})
+		},
+		func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&entity.UserDormitoryRole{})
+		},
+	)
+
+	// Migration 017: Add permissions for the permission management API
+	RegisterMigration(
+		"017_add_permission_management_permissions",
+		"Create permission:create, permission:update, permission:delete and permission:* and grant them to the super_admin role",
+		func(db *gorm.DB) error {
+			return ensurePermissions(db, permissionManagementPermissions, []string{"super_admin"})
+		},
+		func(db *gorm.DB) error {
+			return dropPermissions(db, permissionManagementPermissions)
+		},
+	)
+
+	// Migration 018: Add role hierarchy
+	RegisterMigration(
+		"018_add_role_hierarchy",
+		"Add parent_id to roles so a role inherits the permissions of its ancestors, and let super_admin inherit from admin",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.Role{}, "parent_id") {
+				if err := db.Migrator().AddColumn(&entity.Role{}, "ParentID"); err != nil {
+					return err
+				}
+			}
+			if !db.Migrator().HasIndex(&entity.Role{}, "ParentID") {
+				if err := db.Migrator().CreateIndex(&entity.Role{}, "ParentID"); err != nil {
+					return err
+				}
+			}
+			if !db.Migrator().HasConstraint(&entity.Role{}, "Parent") {
+				if err := db.Migrator().CreateConstraint(&entity.Role{}, "Parent"); err != nil {
+					return err
+				}
+			}
+
+			var admin entity.Role
+			result := db.Where("slug = ?", "admin").First(&admin)
+			if result.Error == gorm.ErrRecordNotFound {
+				return nil
+			} else if result.Error != nil {
+				return result.Error
+			}
+			return db.Model(&entity.Role{}).
+				Where("slug = ? AND parent_id IS NULL", "super_admin").
+				Update("parent_id", admin.ID).Error
+		},
+		func(db *gorm.DB) error {
+			if db.Migrator().HasConstraint(&entity.Role{}, "Parent") {
+				if err := db.Migrator().DropConstraint(&entity.Role{}, "Parent"); err != nil {
+					return err
+				}
+			}
+			if db.Migrator().HasColumn(&entity.Role{}, "parent_id") {
+				return db.Migrator().DropColumn(&entity.Role{}, "parent_id")
+			}
+			return nil
+		},
+	)
+
+	// Migration 019: Add time-bound role grants and role requests
+	RegisterMigration(
+		"019_add_role_grant_expiry_and_requests",
+		"Add expires_at to user_roles and create role_requests table for the role request workflow",
+		func(db *gorm.DB) error {
+			if !db.Migrator().HasColumn(&entity.UserRole{}, "expires_at") {
+				if err := db.Migrator().AddColumn(&entity.UserRole{}, "ExpiresAt"); err != nil {
+					return err
+				}
+			}
+			if !db.Migrator().HasIndex(&entity.UserRole{}, "ExpiresAt") {
+				if err := db.Migrator().CreateIndex(&entity.UserRole{}, "ExpiresAt"); err != nil {
+					return err
+				}
+			}
+			return db.AutoMigrate(&entity.RoleRequest{})
+		},
+		func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&entity.RoleRequest{}); err != nil {
+				return err
+			}
+			if db.Migrator().HasColumn(&entity.UserRole{}, "expires_at") {
+				return db.Migrator().DropColumn(&entity.UserRole{}, "expires_at")
+			}
+			return nil
+		},
+	)
+
+	// Migration 020: Index deleted_at for soft deletion
+	RegisterMigration(
+		"020_add_soft_delete_indexes",
+		"Add deleted_at indexes to users and dormitories for soft delete scoping and purging",
+		func(db *gorm.DB) error {
+			for _, model := range []interface{}{&entity.User{}, &entity.Dormitory{}} {
+				if !db.Migrator().HasColumn(model, "deleted_at") {
+					if err := db.Migrator().AddColumn(model, "DeletedAt"); err != nil {
+						return err
+					}
+				}
+				if !db.Migrator().HasIndex(model, "DeletedAt") {
+					if err := db.Migrator().CreateIndex(model, "DeletedAt"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			for _, model := range []interface{}{&entity.User{}, &entity.Dormitory{}} {
+				if db.Migrator().HasIndex(model, "DeletedAt") {
+					if err := db.Migrator().DropIndex(model, "DeletedAt"); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+
+	// Migration 021: Composite indexes backing cursor (keyset) pagination
+	RegisterMigration(
+		"021_add_keyset_pagination_indexes",
+		"Add (created_at, id) indexes to audit_logs, users and dormitories for cursor pagination",
+		func(db *gorm.DB) error {
+			for _, table := range []string{"audit_logs", "users", "dormitories"} {
+				if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_" + table + "_created_at_id ON " + table + " (created_at, id)").Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		func(db *gorm.DB) error {
+			for _, table := range []string{"dormitories", "users", "audit_logs"} {
+				if err := db.Exec("DROP INDEX IF EXISTS idx_" + table + "_created_at_id").Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+var permissionManagementPermissions = []string{"permission:create", "permission:update", "permission:delete", "permission:*"}
+
+// ensurePermissions creates the named permissions if they do not exist yet and
+// grants them to the roles with the given slugs
+func ensurePermissions(db *gorm.DB, names []string, roleSlugs []string) error {
+	var roles []entity.Role
+	if err := db.Where("slug IN ?", roleSlugs).Find(&roles).Error; err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		var permission entity.Permission
+		result := db.Where("name = ?", name).First(&permission)
+		if result.Error == gorm.ErrRecordNotFound {
+			resource, action, _ := entity.ParsePermissionName(name)
+			now := time.Now()
+			permission = entity.Permission{
+				ID:        uuid.New(),
+				Name:      name,
+				Slug:      entity.PermissionSlug(name),
+				Resource:  resource,
+				Action:    action,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := db.Create(&permission).Error; err != nil {
+				return err
+			}
+		} else if result.Error != nil {
+			return result.Error
+		}
+
+		for _, role := range roles {
+			grant := entity.RolePermission{RoleID: role.ID, PermissionID: permission.ID}
+			if err := db.Where(&grant).FirstOrCreate(&grant).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dropPermissions deletes the named permissions and their role grants
+func dropPermissions(db *gorm.DB, names []string) error {
+	var permissions []entity.Permission
+	if err := db.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		if err := db.Where("permission_id = ?", permission.ID).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if err := db.Delete(&permission).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
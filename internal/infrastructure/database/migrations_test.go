@@ -0,0 +1,45 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func findMigration(t *testing.T, version string) MigrationStep {
+	t.Helper()
+	for _, step := range GetMigrations() {
+		if step.Version == version {
+			return step
+		}
+	}
+	t.Fatalf("Migration %s is not registered", version)
+	return MigrationStep{}
+}
+
+func TestMigration010_RequiresMFAForAdministrativeRoles(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&entity.Role{}))
+
+	// Roles created before the migration existed have require_mfa = false
+	for _, slug := range []string{"user", "admin", "super_admin"} {
+		role := &entity.Role{ID: uuid.New(), Name: slug, Slug: slug, IsActive: true, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		require.NoError(t, db.Create(role).Error)
+	}
+
+	require.NoError(t, findMigration(t, "010_add_two_factor_authentication").Up(db))
+
+	var roles []entity.Role
+	require.NoError(t, db.Order("slug").Find(&roles).Error)
+	require.Len(t, roles, 3)
+	for _, role := range roles {
+		assert.Equal(t, role.Slug != "user", role.RequireMFA, role.Slug)
+	}
+}
@@ -18,8 +18,12 @@ func NewAuditLogRepository() repository.AuditLogRepository {
 	return &auditLogRepository{db: database.DB}
 }
 
+// Create writes the entry in a transaction of its own, which becomes a savepoint
+// inside WithinTx: a failed audit write, ignored by callers, must not abort the work it records.
 func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
-	return r.db.WithContext(ctx).Create(log).Error
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(log).Error
+	})
 }
 
 func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]*entity.AuditLog, int64, error) {
@@ -35,17 +39,7 @@ func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLo
 
 	offset := (filter.Page - 1) * filter.PageSize
 
-	query := r.db.WithContext(ctx).Model(&entity.AuditLog{})
-
-	if filter.Resource != "" {
-		query = query.Where("resource = ?", filter.Resource)
-	}
-	if filter.Action != "" {
-		query = query.Where("action = ?", filter.Action)
-	}
-	if filter.ActorEmail != "" {
-		query = query.Where("actor_email = ?", filter.ActorEmail)
-	}
+	query := r.filtered(ctx, filter)
 
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -59,3 +53,29 @@ func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLo
 
 	return logs, total, nil
 }
+
+func (r *auditLogRepository) ListAfter(ctx context.Context, filter repository.AuditLogFilter, page repository.KeysetPage) ([]*entity.AuditLog, int64, error) {
+	var logs []*entity.AuditLog
+	total, err := listAfterKeyset(r.filtered(ctx, filter), "audit_logs", page, &logs)
+	return logs, total, err
+}
+
+// filtered returns the audit log query restricted by the filter, without ordering or paging
+func (r *auditLogRepository) filtered(ctx context.Context, filter repository.AuditLogFilter) *gorm.DB {
+	query := conn(ctx, r.db).Model(&entity.AuditLog{})
+
+	if filter.Resource != "" {
+		query = query.Where("resource = ?", filter.Resource)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ActorEmail != "" {
+		query = query.Where("actor_email = ?", filter.ActorEmail)
+	}
+	if filter.DormitoryID != "" {
+		query = query.Where("resource = ? AND target_id = ?", "dormitory", filter.DormitoryID)
+	}
+
+	return query
+}
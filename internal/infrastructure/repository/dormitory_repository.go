@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type dormitoryRepository struct {
@@ -22,12 +24,12 @@ func NewDormitoryRepository() repository.DormitoryRepository {
 }
 
 func (r *dormitoryRepository) Create(ctx context.Context, dormitory *entity.Dormitory) error {
-	return r.db.WithContext(ctx).Create(dormitory).Error
+	return conn(ctx, r.db).Create(dormitory).Error
 }
 
 func (r *dormitoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error) {
 	var dormitory entity.Dormitory
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&dormitory).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&dormitory).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,23 +37,78 @@ func (r *dormitoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 }
 
 func (r *dormitoryRepository) Update(ctx context.Context, dormitory *entity.Dormitory) error {
-	return r.db.WithContext(ctx).Save(dormitory).Error
+	return conn(ctx, r.db).Save(dormitory).Error
 }
 
 func (r *dormitoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Dormitory{}, id).Error
+	return conn(ctx, r.db).Delete(&entity.Dormitory{}, id).Error
 }
 
-func (r *dormitoryRepository) List(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error) {
+// dormitorySortColumns maps repository.DormitorySortFields to their columns
+var dormitorySortColumns = map[string]string{
+	"name":       "dormitories.name",
+	"is_active":  "dormitories.is_active",
+	"created_at": "dormitories.created_at",
+	"updated_at": "dormitories.updated_at",
+}
+
+func (r *dormitoryRepository) List(ctx context.Context, filter repository.DormitoryFilter, limit, offset int) ([]*entity.Dormitory, int64, error) {
+	order, err := orderBy(filter.Sort, dormitorySortColumns, "dormitories.created_at DESC")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := r.filtered(ctx, filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var dormitories []*entity.Dormitory
+	err = query.
+		Order(order).
+		Order("dormitories.id").
+		Limit(limit).
+		Offset(offset).
+		Find(&dormitories).Error
+
+	return dormitories, total, err
+}
+
+func (r *dormitoryRepository) ListAfter(ctx context.Context, filter repository.DormitoryFilter, page repository.KeysetPage) ([]*entity.Dormitory, int64, error) {
+	var dormitories []*entity.Dormitory
+	total, err := listAfterKeyset(r.filtered(ctx, filter), "dormitories", page, &dormitories)
+	return dormitories, total, err
+}
+
+// filtered returns the dormitory query restricted by the filter, without ordering
+func (r *dormitoryRepository) filtered(ctx context.Context, filter repository.DormitoryFilter) *gorm.DB {
+	query := conn(ctx, r.db).Model(&entity.Dormitory{})
+
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("(LOWER(dormitories.name) LIKE LOWER(?) OR LOWER(dormitories.description) LIKE LOWER(?))", like, like)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("dormitories.is_active = ?", *filter.IsActive)
+	}
+
+	return query
+}
+
+func (r *dormitoryRepository) ListDeleted(ctx context.Context, limit, offset int) ([]*entity.Dormitory, int64, error) {
 	var dormitories []*entity.Dormitory
 	var total int64
 
-	err := r.db.WithContext(ctx).Model(&entity.Dormitory{}).Count(&total).Error
-	if err != nil {
+	deleted := conn(ctx, r.db).Unscoped().Model(&entity.Dormitory{}).Where("deleted_at IS NOT NULL")
+
+	if err := deleted.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.WithContext(ctx).
+	err := deleted.
+		Order("deleted_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&dormitories).Error
@@ -59,8 +116,51 @@ func (r *dormitoryRepository) List(ctx context.Context, limit, offset int) ([]*e
 	return dormitories, total, err
 }
 
+func (r *dormitoryRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.Dormitory, error) {
+	var dormitory entity.Dormitory
+	err := conn(ctx, r.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&dormitory).Error
+	if err != nil {
+		return nil, err
+	}
+	return &dormitory, nil
+}
+
+func (r *dormitoryRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Unscoped().
+		Model(&entity.Dormitory{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+func (r *dormitoryRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Unscoped().Model(&entity.Dormitory{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		if err := tx.Where("dormitory_id IN ?", ids).Delete(&entity.UserDormitory{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("dormitory_id IN ?", ids).Delete(&entity.UserDormitoryRole{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&entity.Dormitory{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// AssignToUser adds a user to a dormitory; adding an existing member is a no-op
 func (r *dormitoryRepository) AssignToUser(ctx context.Context, userID, dormitoryID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
 		Create(&entity.UserDormitory{
 			UserID:      userID,
 			DormitoryID: dormitoryID,
@@ -68,16 +168,47 @@ func (r *dormitoryRepository) AssignToUser(ctx context.Context, userID, dormitor
 }
 
 func (r *dormitoryRepository) RemoveFromUser(ctx context.Context, userID, dormitoryID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("user_id = ? AND dormitory_id = ?", userID, dormitoryID).
 		Delete(&entity.UserDormitory{}).Error
 }
 
 func (r *dormitoryRepository) GetUserDormitories(ctx context.Context, userID uuid.UUID) ([]*entity.Dormitory, error) {
 	var dormitories []*entity.Dormitory
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Joins("JOIN user_dormitories ON user_dormitories.dormitory_id = dormitories.id").
 		Where("user_dormitories.user_id = ?", userID).
 		Find(&dormitories).Error
 	return dormitories, err
 }
+
+func (r *dormitoryRepository) IsMember(ctx context.Context, userID, dormitoryID uuid.UUID) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.UserDormitory{}).
+		Where("user_id = ? AND dormitory_id = ?", userID, dormitoryID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *dormitoryRepository) ListMembers(ctx context.Context, dormitoryID uuid.UUID, limit, offset int) ([]*entity.User, int64, error) {
+	var users []*entity.User
+	var total int64
+
+	members := conn(ctx, r.db).
+		Model(&entity.User{}).
+		Joins("JOIN user_dormitories ON user_dormitories.user_id = users.id").
+		Where("user_dormitories.dormitory_id = ?", dormitoryID)
+
+	if err := members.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := members.
+		Order("users.name ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+
+	return users, total, err
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestDormitoryRepository_ListFilters(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &dormitoryRepository{db: db}
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	newDormitory := func(name, description string, active bool, age time.Duration) *entity.Dormitory {
+		dormitory := &entity.Dormitory{
+			ID:          uuid.New(),
+			Name:        name,
+			Description: description,
+			CreatedAt:   base.Add(age),
+			UpdatedAt:   base.Add(age),
+		}
+		require.NoError(t, db.Create(dormitory).Error)
+		require.NoError(t, db.Model(dormitory).Update("is_active", active).Error)
+		return dormitory
+	}
+	putra := newDormitory("Asrama Putra", "Gedung utara", true, time.Minute)
+	putri := newDormitory("Asrama Putri", "Gedung selatan", true, 2*time.Minute)
+	lama := newDormitory("Wisma Lama", "Sedang direnovasi", false, 3*time.Minute)
+
+	isActive := true
+	tests := []struct {
+		name     string
+		filter   repository.DormitoryFilter
+		expected []*entity.Dormitory
+	}{
+		{name: "default order is newest first", filter: repository.DormitoryFilter{}, expected: []*entity.Dormitory{lama, putri, putra}},
+		{name: "search name case-insensitive", filter: repository.DormitoryFilter{Search: "asrama"}, expected: []*entity.Dormitory{putri, putra}},
+		{name: "search description", filter: repository.DormitoryFilter{Search: "renovasi"}, expected: []*entity.Dormitory{lama}},
+		{name: "active only", filter: repository.DormitoryFilter{IsActive: &isActive}, expected: []*entity.Dormitory{putri, putra}},
+		{name: "sort by name", filter: repository.DormitoryFilter{Sort: repository.Sort{Field: "name"}}, expected: []*entity.Dormitory{putra, putri, lama}},
+		{name: "sort by created_at ascending", filter: repository.DormitoryFilter{Sort: repository.Sort{Field: "created_at"}}, expected: []*entity.Dormitory{putra, putri, lama}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dormitories, total, err := repo.List(ctx, tt.filter, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expected)), total)
+			require.Len(t, dormitories, len(tt.expected))
+			for i, dormitory := range dormitories {
+				assert.Equal(t, tt.expected[i].ID, dormitory.ID)
+			}
+		})
+	}
+
+	// Paging keeps the total of the filtered set
+	dormitories, total, err := repo.List(ctx, repository.DormitoryFilter{Sort: repository.Sort{Field: "name"}}, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, dormitories, 1)
+	assert.Equal(t, putri.ID, dormitories[0].ID)
+
+	_, _, err = repo.List(ctx, repository.DormitoryFilter{Sort: repository.Sort{Field: "id"}}, 10, 0)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidSortField)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type linkedIdentityRepository struct {
+	db *gorm.DB
+}
+
+// NewLinkedIdentityRepository creates a new linked identity repository
+func NewLinkedIdentityRepository() repository.LinkedIdentityRepository {
+	return &linkedIdentityRepository{
+		db: database.DB,
+	}
+}
+
+func (r *linkedIdentityRepository) Create(ctx context.Context, identity *entity.LinkedIdentity) error {
+	return conn(ctx, r.db).Create(identity).Error
+}
+
+func (r *linkedIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*entity.LinkedIdentity, error) {
+	var identity entity.LinkedIdentity
+	err := conn(ctx, r.db).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *linkedIdentityRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.LinkedIdentity, error) {
+	var identities []*entity.LinkedIdentity
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&identities).Error
+	return identities, err
+}
+
+func (r *linkedIdentityRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.LinkedIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", at).Error
+}
+
+type oidcStateRepository struct {
+	db *gorm.DB
+}
+
+// NewOIDCStateRepository creates a new OpenID Connect login state repository
+func NewOIDCStateRepository() repository.OIDCStateRepository {
+	return &oidcStateRepository{
+		db: database.DB,
+	}
+}
+
+func (r *oidcStateRepository) Create(ctx context.Context, state *entity.OIDCLoginState) error {
+	return conn(ctx, r.db).Create(state).Error
+}
+
+func (r *oidcStateRepository) Consume(ctx context.Context, stateHash string) (*entity.OIDCLoginState, error) {
+	var state entity.OIDCLoginState
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("state_hash = ?", stateHash).First(&state).Error; err != nil {
+			return err
+		}
+		// Only the request that deletes the row may use the state
+		result := tx.Where("state_hash = ?", stateHash).Delete(&entity.OIDCLoginState{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *oidcStateRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	return conn(ctx, r.db).
+		Where("expires_at < ?", before).
+		Delete(&entity.OIDCLoginState{}).Error
+}
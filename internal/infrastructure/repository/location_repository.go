@@ -6,6 +6,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
 )
 
 type provinceRepository struct{}
@@ -23,18 +24,14 @@ func NewVillageRepository() repository.VillageRepository   { return &villageRepo
 
 func (r *provinceRepository) GetByID(ctx context.Context, id int) (*entity.Province, error) {
 	var province entity.Province
-	if err := database.DB.WithContext(ctx).First(&province, id).Error; err != nil {
+	if err := conn(ctx, database.DB).First(&province, id).Error; err != nil {
 		return nil, err
 	}
 	return &province, nil
 }
 
 func (r *provinceRepository) List(ctx context.Context, page, pageSize int, search string) ([]*entity.Province, int64, error) {
-	db := database.DB.WithContext(ctx).Model(&entity.Province{})
-	if search != "" {
-		like := "%" + search + "%"
-		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
-	}
+	db := r.filtered(ctx, search)
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -58,23 +55,31 @@ func (r *provinceRepository) List(ctx context.Context, page, pageSize int, searc
 	return provinces, total, nil
 }
 
+func (r *provinceRepository) ListAfter(ctx context.Context, page repository.IDPage, search string) ([]*entity.Province, int64, error) {
+	var provinces []*entity.Province
+	total, err := listAfterID(r.filtered(ctx, search), page, &provinces)
+	return provinces, total, err
+}
+
+func (r *provinceRepository) filtered(ctx context.Context, search string) *gorm.DB {
+	db := conn(ctx, database.DB).Model(&entity.Province{})
+	if search != "" {
+		like := "%" + search + "%"
+		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
+	}
+	return db
+}
+
 func (r *regencyRepository) GetByID(ctx context.Context, id int) (*entity.Regency, error) {
 	var regency entity.Regency
-	if err := database.DB.WithContext(ctx).First(&regency, id).Error; err != nil {
+	if err := conn(ctx, database.DB).First(&regency, id).Error; err != nil {
 		return nil, err
 	}
 	return &regency, nil
 }
 
 func (r *regencyRepository) List(ctx context.Context, page, pageSize int, provinceID *int, search string) ([]*entity.Regency, int64, error) {
-	db := database.DB.WithContext(ctx).Model(&entity.Regency{})
-	if provinceID != nil {
-		db = db.Where("province_id = ?", *provinceID)
-	}
-	if search != "" {
-		like := "%" + search + "%"
-		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
-	}
+	db := r.filtered(ctx, provinceID, search)
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -98,23 +103,34 @@ func (r *regencyRepository) List(ctx context.Context, page, pageSize int, provin
 	return regencies, total, nil
 }
 
+func (r *regencyRepository) ListAfter(ctx context.Context, page repository.IDPage, provinceID *int, search string) ([]*entity.Regency, int64, error) {
+	var regencies []*entity.Regency
+	total, err := listAfterID(r.filtered(ctx, provinceID, search), page, &regencies)
+	return regencies, total, err
+}
+
+func (r *regencyRepository) filtered(ctx context.Context, provinceID *int, search string) *gorm.DB {
+	db := conn(ctx, database.DB).Model(&entity.Regency{})
+	if provinceID != nil {
+		db = db.Where("province_id = ?", *provinceID)
+	}
+	if search != "" {
+		like := "%" + search + "%"
+		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
+	}
+	return db
+}
+
 func (r *districtRepository) GetByID(ctx context.Context, id int) (*entity.District, error) {
 	var district entity.District
-	if err := database.DB.WithContext(ctx).First(&district, id).Error; err != nil {
+	if err := conn(ctx, database.DB).First(&district, id).Error; err != nil {
 		return nil, err
 	}
 	return &district, nil
 }
 
 func (r *districtRepository) List(ctx context.Context, page, pageSize int, regencyID *int, search string) ([]*entity.District, int64, error) {
-	db := database.DB.WithContext(ctx).Model(&entity.District{})
-	if regencyID != nil {
-		db = db.Where("regency_id = ?", *regencyID)
-	}
-	if search != "" {
-		like := "%" + search + "%"
-		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
-	}
+	db := r.filtered(ctx, regencyID, search)
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -138,23 +154,34 @@ func (r *districtRepository) List(ctx context.Context, page, pageSize int, regen
 	return districts, total, nil
 }
 
+func (r *districtRepository) ListAfter(ctx context.Context, page repository.IDPage, regencyID *int, search string) ([]*entity.District, int64, error) {
+	var districts []*entity.District
+	total, err := listAfterID(r.filtered(ctx, regencyID, search), page, &districts)
+	return districts, total, err
+}
+
+func (r *districtRepository) filtered(ctx context.Context, regencyID *int, search string) *gorm.DB {
+	db := conn(ctx, database.DB).Model(&entity.District{})
+	if regencyID != nil {
+		db = db.Where("regency_id = ?", *regencyID)
+	}
+	if search != "" {
+		like := "%" + search + "%"
+		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
+	}
+	return db
+}
+
 func (r *villageRepository) GetByID(ctx context.Context, id int) (*entity.Village, error) {
 	var village entity.Village
-	if err := database.DB.WithContext(ctx).First(&village, id).Error; err != nil {
+	if err := conn(ctx, database.DB).First(&village, id).Error; err != nil {
 		return nil, err
 	}
 	return &village, nil
 }
 
 func (r *villageRepository) List(ctx context.Context, page, pageSize int, districtID *int, search string) ([]*entity.Village, int64, error) {
-	db := database.DB.WithContext(ctx).Model(&entity.Village{})
-	if districtID != nil {
-		db = db.Where("district_id = ?", *districtID)
-	}
-	if search != "" {
-		like := "%" + search + "%"
-		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
-	}
+	db := r.filtered(ctx, districtID, search)
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -177,3 +204,21 @@ func (r *villageRepository) List(ctx context.Context, page, pageSize int, distri
 	}
 	return villages, total, nil
 }
+
+func (r *villageRepository) ListAfter(ctx context.Context, page repository.IDPage, districtID *int, search string) ([]*entity.Village, int64, error) {
+	var villages []*entity.Village
+	total, err := listAfterID(r.filtered(ctx, districtID, search), page, &villages)
+	return villages, total, err
+}
+
+func (r *villageRepository) filtered(ctx context.Context, districtID *int, search string) *gorm.DB {
+	db := conn(ctx, database.DB).Model(&entity.Village{})
+	if districtID != nil {
+		db = db.Where("district_id = ?", *districtID)
+	}
+	if search != "" {
+		like := "%" + search + "%"
+		db = db.Where("LOWER(name) LIKE LOWER(?)", like)
+	}
+	return db
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// listAfterKeyset loads the rows of query that come after page.After in
+// (created_at DESC, id DESC) order. Columns are qualified with table because
+// filters may join or reference other tables.
+func listAfterKeyset(query *gorm.DB, table string, page repository.KeysetPage, dest interface{}) (int64, error) {
+	var total int64
+	if page.WithCount {
+		if err := query.Count(&total).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	if page.After != nil {
+		query = query.Where("("+table+".created_at < ? OR ("+table+".created_at = ? AND "+table+".id < ?))",
+			page.After.CreatedAt, page.After.CreatedAt, page.After.ID)
+	}
+
+	err := query.
+		Order(table + ".created_at DESC").
+		Order(table + ".id DESC").
+		Limit(page.Limit).
+		Find(dest).Error
+
+	return total, err
+}
+
+// listAfterID loads the rows of query with an id above page.AfterID, in id order
+func listAfterID(query *gorm.DB, page repository.IDPage, dest interface{}) (int64, error) {
+	var total int64
+	if page.WithCount {
+		if err := query.Count(&total).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	if page.AfterID > 0 {
+		query = query.Where("id > ?", page.AfterID)
+	}
+
+	err := query.Order("id ASC").Limit(page.Limit).Find(dest).Error
+	return total, err
+}
@@ -22,12 +22,12 @@ func NewPermissionRepository() repository.PermissionRepository {
 }
 
 func (r *permissionRepository) Create(ctx context.Context, permission *entity.Permission) error {
-	return r.db.WithContext(ctx).Create(permission).Error
+	return conn(ctx, r.db).Create(permission).Error
 }
 
 func (r *permissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Permission, error) {
 	var permission entity.Permission
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&permission).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&permission).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,28 @@ func (r *permissionRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 
 func (r *permissionRepository) GetBySlug(ctx context.Context, slug string) (*entity.Permission, error) {
 	var permission entity.Permission
-	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&permission).Error
+	err := conn(ctx, r.db).Where("slug = ?", slug).First(&permission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+func (r *permissionRepository) GetByName(ctx context.Context, name string) (*entity.Permission, error) {
+	var permission entity.Permission
+	err := conn(ctx, r.db).Where("name = ?", name).First(&permission).Error
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+func (r *permissionRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.Permission, error) {
+	var permission entity.Permission
+	err := conn(ctx, r.db).
+		Preload("Roles").
+		Where("id = ?", id).
+		First(&permission).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,26 +65,44 @@ func (r *permissionRepository) GetBySlug(ctx context.Context, slug string) (*ent
 }
 
 func (r *permissionRepository) Update(ctx context.Context, permission *entity.Permission) error {
-	return r.db.WithContext(ctx).Save(permission).Error
+	return conn(ctx, r.db).Save(permission).Error
 }
 
+// Delete deletes a permission and removes it from the roles it is assigned to
 func (r *permissionRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Permission{}, id).Error
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("permission_id = ?", id).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+		// API keys lose the scope instead of keeping a dangling reference
+		if err := tx.Exec("DELETE FROM api_key_permissions WHERE permission_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Permission{}, id).Error
+	})
 }
 
 func (r *permissionRepository) List(ctx context.Context, limit, offset int) ([]*entity.Permission, int64, error) {
 	var permissions []*entity.Permission
 	var total int64
 
-	err := r.db.WithContext(ctx).Model(&entity.Permission{}).Count(&total).Error
+	err := conn(ctx, r.db).Model(&entity.Permission{}).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).
 		Limit(limit).
 		Offset(offset).
 		Find(&permissions).Error
 
 	return permissions, total, err
 }
+
+func (r *permissionRepository) ListAll(ctx context.Context) ([]*entity.Permission, error) {
+	var permissions []*entity.Permission
+	err := conn(ctx, r.db).
+		Order("name ASC").
+		Find(&permissions).Error
+	return permissions, err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestPermissionRepository_DeleteRemovesReferences(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &permissionRepository{db: db}
+	ctx := context.Background()
+
+	dormRead := entity.Permission{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read"}
+	dormUpdate := entity.Permission{ID: uuid.New(), Name: "dorm:update", Slug: "dorm-update", Resource: "dorm", Action: "update"}
+
+	role := &entity.Role{ID: uuid.New(), Name: "Warden", Slug: "warden", IsActive: true, Permissions: []entity.Permission{dormRead, dormUpdate}}
+	require.NoError(t, db.Create(role).Error)
+
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     "test@example.com",
+		Password:  "hashedpassword",
+		Name:      "Test User",
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(user).Error)
+	apiKey := &entity.APIKey{
+		ID:          uuid.New(),
+		UserID:      user.ID,
+		Name:        "CI",
+		Prefix:      "pat_test",
+		KeyHash:     "hash",
+		Permissions: []entity.Permission{dormRead, dormUpdate},
+	}
+	require.NoError(t, db.Create(apiKey).Error)
+
+	require.NoError(t, repo.Delete(ctx, dormUpdate.ID))
+
+	countRefs := func(table string) int64 {
+		var count int64
+		require.NoError(t, db.Table(table).Where("permission_id = ?", dormUpdate.ID).Count(&count).Error)
+		return count
+	}
+	assert.Equal(t, int64(0), countRefs("role_permissions"))
+	assert.Equal(t, int64(0), countRefs("api_key_permissions"))
+
+	// The key keeps the permissions that still exist
+	var found entity.APIKey
+	require.NoError(t, db.Preload("Permissions").First(&found, "id = ?", apiKey.ID).Error)
+	require.Len(t, found.Permissions, 1)
+	assert.Equal(t, "dorm:read", found.Permissions[0].Name)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// preloadUserRelations loads the requested relations of a page of users in
+// batch, two queries per relation whatever the number of users
+func preloadUserRelations(db *gorm.DB, users []*entity.User, with []repository.Relation) error {
+	if len(users) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	if repository.Preloads(with, repository.RelationRoles) {
+		if err := preloadUserRoles(db, users, ids); err != nil {
+			return err
+		}
+	}
+	if repository.Preloads(with, repository.RelationDormitories) {
+		if err := preloadUserDormitories(db, users, ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// preloadUserRoles loads the roles granted to the users, leaving out expired
+// grants. GORM's many2many preload cannot filter on the join table, so the
+// grants are loaded first and the roles matched to them.
+func preloadUserRoles(db *gorm.DB, users []*entity.User, ids []uuid.UUID) error {
+	var grants []entity.UserRole
+	err := db.Where("user_id IN ? AND (expires_at IS NULL OR expires_at > ?)", ids, time.Now()).
+		Find(&grants).Error
+	if err != nil {
+		return err
+	}
+
+	granted := make(map[uuid.UUID]map[uuid.UUID]bool, len(users))
+	roleIDs := make([]uuid.UUID, 0, len(grants))
+	for _, grant := range grants {
+		if granted[grant.UserID] == nil {
+			granted[grant.UserID] = make(map[uuid.UUID]bool)
+		}
+		granted[grant.UserID][grant.RoleID] = true
+		roleIDs = append(roleIDs, grant.RoleID)
+	}
+
+	var roles []entity.Role
+	if len(roleIDs) > 0 {
+		if err := db.Where("id IN ?", roleIDs).Order("name").Find(&roles).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, user := range users {
+		user.Roles = nil
+		for _, role := range roles {
+			if granted[user.ID][role.ID] {
+				user.Roles = append(user.Roles, role)
+			}
+		}
+	}
+	return nil
+}
+
+// preloadUserDormitories loads the dormitories the users belong to
+func preloadUserDormitories(db *gorm.DB, users []*entity.User, ids []uuid.UUID) error {
+	var links []entity.UserDormitory
+	if err := db.Where("user_id IN ?", ids).Find(&links).Error; err != nil {
+		return err
+	}
+
+	members := make(map[uuid.UUID]map[uuid.UUID]bool, len(users))
+	dormitoryIDs := make([]uuid.UUID, 0, len(links))
+	for _, link := range links {
+		if members[link.UserID] == nil {
+			members[link.UserID] = make(map[uuid.UUID]bool)
+		}
+		members[link.UserID][link.DormitoryID] = true
+		dormitoryIDs = append(dormitoryIDs, link.DormitoryID)
+	}
+
+	var dormitories []entity.Dormitory
+	if len(dormitoryIDs) > 0 {
+		if err := db.Where("id IN ?", dormitoryIDs).Order("name").Find(&dormitories).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, user := range users {
+		user.Dormitories = nil
+		for _, dormitory := range dormitories {
+			if members[user.ID][dormitory.ID] {
+				user.Dormitories = append(user.Dormitories, dormitory)
+			}
+		}
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type roleRepository struct {
@@ -22,12 +23,12 @@ func NewRoleRepository() repository.RoleRepository {
 }
 
 func (r *roleRepository) Create(ctx context.Context, role *entity.Role) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return conn(ctx, r.db).Create(role).Error
 }
 
 func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Role, error) {
 	var role entity.Role
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&role).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&role).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +37,7 @@ func (r *roleRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Rol
 
 func (r *roleRepository) GetBySlug(ctx context.Context, slug string) (*entity.Role, error) {
 	var role entity.Role
-	err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&role).Error
+	err := conn(ctx, r.db).Where("slug = ?", slug).First(&role).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,44 +45,61 @@ func (r *roleRepository) GetBySlug(ctx context.Context, slug string) (*entity.Ro
 }
 
 func (r *roleRepository) Update(ctx context.Context, role *entity.Role) error {
-	return r.db.WithContext(ctx).Save(role).Error
+	return conn(ctx, r.db).Save(role).Error
 }
 
 func (r *roleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Role{}, id).Error
+	return conn(ctx, r.db).Delete(&entity.Role{}, id).Error
 }
 
-func (r *roleRepository) List(ctx context.Context, limit, offset int) ([]*entity.Role, int64, error) {
+func (r *roleRepository) List(ctx context.Context, limit, offset int, with ...repository.Relation) ([]*entity.Role, int64, error) {
 	var roles []*entity.Role
 	var total int64
 
-	err := r.db.WithContext(ctx).Model(&entity.Role{}).Count(&total).Error
+	err := conn(ctx, r.db).Model(&entity.Role{}).Count(&total).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.WithContext(ctx).
+	query := conn(ctx, r.db)
+	if repository.Preloads(with, repository.RelationPermissions) {
+		query = query.Preload("Permissions")
+	}
+	err = query.
 		Limit(limit).
 		Offset(offset).
 		Find(&roles).Error
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return roles, total, err
+	if repository.Preloads(with, repository.RelationPermissions) {
+		if err := loadInheritedPermissions(conn(ctx, r.db), roles...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return roles, total, nil
 }
 
 func (r *roleRepository) GetWithPermissions(ctx context.Context, id uuid.UUID) (*entity.Role, error) {
 	var role entity.Role
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Permissions").
 		Where("id = ?", id).
 		First(&role).Error
 	if err != nil {
 		return nil, err
 	}
+	if err := loadInheritedPermissions(conn(ctx, r.db), &role); err != nil {
+		return nil, err
+	}
 	return &role, nil
 }
 
+// AssignPermission grants a permission to a role; granting it again is a no-op
 func (r *roleRepository) AssignPermission(ctx context.Context, roleID, permissionID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
 		Create(&entity.RolePermission{
 			RoleID:       roleID,
 			PermissionID: permissionID,
@@ -89,7 +107,98 @@ func (r *roleRepository) AssignPermission(ctx context.Context, roleID, permissio
 }
 
 func (r *roleRepository) RemovePermission(ctx context.Context, roleID, permissionID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("role_id = ? AND permission_id = ?", roleID, permissionID).
 		Delete(&entity.RolePermission{}).Error
 }
+
+func (r *roleRepository) SyncPermissions(ctx context.Context, roleID uuid.UUID, assign, remove []uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if len(remove) > 0 {
+			err := tx.Where("role_id = ? AND permission_id IN ?", roleID, remove).
+				Delete(&entity.RolePermission{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		for _, permissionID := range assign {
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&entity.RolePermission{
+					RoleID:       roleID,
+					PermissionID: permissionID,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// loadInheritedPermissions resolves the permissions each role inherits from its
+// ancestors, skipping those it already holds. The given roles must have their
+// permissions loaded. Missing ancestors are loaded in batch, one level of the
+// hierarchy at a time, and the walk stops at a cycle or a missing parent.
+func loadInheritedPermissions(db *gorm.DB, roles ...*entity.Role) error {
+	ancestors := make(map[uuid.UUID]*entity.Role, len(roles))
+	for _, role := range roles {
+		ancestors[role.ID] = role
+	}
+	if err := loadAncestors(db, ancestors, roles); err != nil {
+		return err
+	}
+
+	for _, role := range roles {
+		role.InheritedPermissions = nil
+		held := make(map[string]bool, len(role.Permissions))
+		for _, perm := range role.Permissions {
+			held[perm.Name] = true
+		}
+
+		visited := map[uuid.UUID]bool{role.ID: true}
+		for parentID := role.ParentID; parentID != nil && !visited[*parentID]; {
+			parent, ok := ancestors[*parentID]
+			if !ok {
+				break
+			}
+			visited[parent.ID] = true
+
+			for _, perm := range parent.Permissions {
+				if !held[perm.Name] {
+					held[perm.Name] = true
+					role.InheritedPermissions = append(role.InheritedPermissions, perm)
+				}
+			}
+			parentID = parent.ParentID
+		}
+	}
+	return nil
+}
+
+// loadAncestors adds the ancestors of the roles that are not in the map yet,
+// with their permissions, issuing three queries per level of the hierarchy
+func loadAncestors(db *gorm.DB, ancestors map[uuid.UUID]*entity.Role, roles []*entity.Role) error {
+	requested := make(map[uuid.UUID]bool)
+	for level := roles; len(level) > 0; {
+		var missing []uuid.UUID
+		for _, role := range level {
+			if id := role.ParentID; id != nil && ancestors[*id] == nil && !requested[*id] {
+				requested[*id] = true
+				missing = append(missing, *id)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+
+		var parents []*entity.Role
+		if err := db.Preload("Permissions").Where("id IN ?", missing).Find(&parents).Error; err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			ancestors[parent.ID] = parent
+		}
+		level = parents
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestRoleRepository_ListPreloadsPermissions(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &roleRepository{db: db}
+	ctx := context.Background()
+
+	userRead := entity.Permission{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"}
+	dormRead := entity.Permission{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read"}
+
+	// staff <- manager
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true, Permissions: []entity.Permission{userRead}}
+	require.NoError(t, db.Create(staff).Error)
+	manager := &entity.Role{ID: uuid.New(), Name: "Manager", Slug: "manager", IsActive: true, ParentID: &staff.ID, Permissions: []entity.Permission{dormRead}}
+	require.NoError(t, db.Create(manager).Error)
+
+	var roles []*entity.Role
+	// Count, roles, role_permissions and permissions; the parent is already on the page
+	testutil.AssertQueryCount(t, db, 4, func() {
+		var err error
+		roles, _, err = repo.List(ctx, 10, 0, repository.RelationPermissions)
+		require.NoError(t, err)
+	})
+	require.Len(t, roles, 2)
+	for _, role := range roles {
+		if role.ID == manager.ID {
+			assert.Equal(t, []string{"dorm:read"}, permissionNames(role.Permissions))
+			assert.Equal(t, []string{"user:read"}, permissionNames(role.InheritedPermissions))
+		}
+	}
+
+	// More roles do not mean more queries
+	for i := 0; i < 5; i++ {
+		role := &entity.Role{ID: uuid.New(), Name: fmt.Sprintf("Role %d", i), Slug: fmt.Sprintf("role-%d", i), IsActive: true, ParentID: &manager.ID, Permissions: []entity.Permission{userRead}}
+		require.NoError(t, db.Create(role).Error)
+	}
+	testutil.AssertQueryCount(t, db, 4, func() {
+		roles, _, _ = repo.List(ctx, 10, 0, repository.RelationPermissions)
+	})
+	require.Len(t, roles, 7)
+
+	// Ancestors missing from the page are loaded one level at a time:
+	// roles, role_permissions and permissions for manager, then for staff
+	testutil.AssertQueryCount(t, db, 6, func() {
+		child := &entity.Role{ID: uuid.New(), ParentID: &manager.ID, Permissions: []entity.Permission{userRead}}
+		require.NoError(t, loadInheritedPermissions(db, child))
+		assert.Equal(t, []string{"dorm:read"}, permissionNames(child.InheritedPermissions))
+	})
+
+	// Without the relation only the roles are loaded
+	testutil.AssertQueryCount(t, db, 2, func() {
+		roles, _, _ = repo.List(ctx, 10, 0)
+	})
+	for _, role := range roles {
+		assert.Empty(t, role.Permissions)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type roleRequestRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRequestRepository creates a new role request repository
+func NewRoleRequestRepository() repository.RoleRequestRepository {
+	return &roleRequestRepository{
+		db: database.DB,
+	}
+}
+
+func (r *roleRequestRepository) Create(ctx context.Context, request *entity.RoleRequest) error {
+	return conn(ctx, r.db).Omit("User", "Role").Create(request).Error
+}
+
+func (r *roleRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.RoleRequest, error) {
+	var request entity.RoleRequest
+	err := conn(ctx, r.db).
+		Preload("User").
+		Preload("Role").
+		Where("id = ?", id).
+		First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *roleRequestRepository) List(ctx context.Context, status entity.RoleRequestStatus, limit, offset int) ([]*entity.RoleRequest, int64, error) {
+	var requests []*entity.RoleRequest
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.RoleRequest{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Preload("User").
+		Preload("Role").
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&requests).Error
+
+	return requests, total, err
+}
+
+func (r *roleRequestRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RoleRequest, error) {
+	var requests []*entity.RoleRequest
+	err := conn(ctx, r.db).
+		Preload("Role").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&requests).Error
+	return requests, err
+}
+
+func (r *roleRequestRepository) HasPending(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.RoleRequest{}).
+		Where("user_id = ? AND role_id = ? AND status = ?", userID, roleID, entity.RoleRequestPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *roleRequestRepository) Review(ctx context.Context, request *entity.RoleRequest) (bool, error) {
+	result := conn(ctx, r.db).
+		Model(&entity.RoleRequest{}).
+		Where("id = ? AND status = ?", request.ID, entity.RoleRequestPending).
+		Updates(map[string]interface{}{
+			"status":      request.Status,
+			"expires_at":  request.ExpiresAt,
+			"reviewer_id": request.ReviewerID,
+			"review_note": request.ReviewNote,
+			"reviewed_at": request.ReviewedAt,
+			"updated_at":  request.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
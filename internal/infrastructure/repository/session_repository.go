@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository() repository.SessionRepository {
+	return &sessionRepository{
+		db: database.DB,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, session *entity.Session) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+func (r *sessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Session, error) {
+	var session entity.Session
+	err := conn(ctx, r.db).Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *sessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID, now time.Time) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Where("EXISTS (?)", r.db.
+			Model(&entity.RefreshToken{}).
+			Select("1").
+			Where("refresh_tokens.family_id = user_sessions.family_id").
+			Where("refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > ?", now)).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, familyID uuid.UUID, ipAddress string, at time.Time) error {
+	return conn(ctx, r.db).
+		Model(&entity.Session{}).
+		Where("family_id = ?", familyID).
+		Updates(map[string]interface{}{
+			"ip_address":   ipAddress,
+			"last_seen_at": at,
+		}).Error
+}
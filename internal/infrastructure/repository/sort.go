@@ -0,0 +1,23 @@
+package repository
+
+import (
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+)
+
+// orderBy turns a sort into an ORDER BY expression. Only fields present in columns are accepted,
+// so user input never reaches the SQL; an empty sort falls back to the given default.
+func orderBy(sort repository.Sort, columns map[string]string, fallback string) (string, error) {
+	if sort.Field == "" {
+		return fallback, nil
+	}
+
+	column, ok := columns[sort.Field]
+	if !ok {
+		return "", domainErrors.ErrInvalidSortField
+	}
+	if sort.Desc {
+		return column + " DESC", nil
+	}
+	return column + " ASC", nil
+}
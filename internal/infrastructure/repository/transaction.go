@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// txKey is the context key under which WithinTx stores the transaction
+type txKey struct{}
+
+type transactionManager struct {
+	db *gorm.DB
+}
+
+// NewTransactionManager creates a new transaction manager
+func NewTransactionManager() repository.TransactionManager {
+	return &transactionManager{
+		db: database.DB,
+	}
+}
+
+// WithinTx runs fn in a GORM transaction propagated through the context.
+// A nested call runs in a savepoint of the outer transaction, so its failure
+// only undoes its own writes.
+func (m *transactionManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db when there is none.
+// Repositories start every query from it so they take part in WithinTx.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
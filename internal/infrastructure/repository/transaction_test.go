@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestTransactionManager_WithinTx(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	txManager := &transactionManager{db: db}
+	userRepo := &userRepository{db: db}
+	roleRepo := &roleRepository{db: db}
+	auditLogRepo := &auditLogRepository{db: db}
+	ctx := context.Background()
+
+	newUser := func(email string) *entity.User {
+		return &entity.User{
+			ID:        uuid.New(),
+			Email:     email,
+			Password:  "hashedpassword",
+			Name:      "Test User",
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+	}
+	countUsers := func() int64 {
+		var count int64
+		require.NoError(t, db.Model(&entity.User{}).Count(&count).Error)
+		return count
+	}
+
+	t.Run("commits writes of every repository", func(t *testing.T) {
+		role := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true}
+		user := newUser("commit@example.com")
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			if err := roleRepo.Create(ctx, role); err != nil {
+				return err
+			}
+			if err := userRepo.Create(ctx, user); err != nil {
+				return err
+			}
+			return userRepo.AssignRole(ctx, user.ID, role.ID, nil)
+		})
+		require.NoError(t, err)
+
+		found, err := userRepo.GetWithRoles(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, found.Roles, 1)
+	})
+
+	t.Run("rolls back every repository on error", func(t *testing.T) {
+		before := countUsers()
+		failure := errors.New("fail after writes")
+		role := &entity.Role{ID: uuid.New(), Name: "Warden", Slug: "warden", IsActive: true}
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, roleRepo.Create(ctx, role))
+			require.NoError(t, userRepo.Create(ctx, newUser("rollback@example.com")))
+
+			// Reads with the same context see the uncommitted writes
+			_, err := userRepo.GetByEmail(ctx, "rollback@example.com")
+			require.NoError(t, err)
+			return failure
+		})
+		assert.Equal(t, failure, err)
+
+		assert.Equal(t, before, countUsers())
+		_, err = roleRepo.GetBySlug(ctx, "warden")
+		assert.Error(t, err)
+	})
+
+	t.Run("nested call rolls back to a savepoint", func(t *testing.T) {
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, userRepo.Create(ctx, newUser("outer@example.com")))
+			innerErr := txManager.WithinTx(ctx, func(ctx context.Context) error {
+				require.NoError(t, userRepo.Create(ctx, newUser("inner@example.com")))
+				return errors.New("inner failure")
+			})
+			assert.Error(t, innerErr)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = userRepo.GetByEmail(ctx, "outer@example.com")
+		assert.NoError(t, err)
+		_, err = userRepo.GetByEmail(ctx, "inner@example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("failed audit write does not abort the transaction", func(t *testing.T) {
+		// The test database has no audit_logs table, so the write fails
+		err := txManager.WithinTx(ctx, func(ctx context.Context) error {
+			require.NoError(t, userRepo.Create(ctx, newUser("audited@example.com")))
+			assert.Error(t, auditLogRepo.Create(ctx, &entity.AuditLog{ID: uuid.New(), Action: "user:create", CreatedAt: time.Now()}))
+			return userRepo.Create(ctx, newUser("after-audit@example.com"))
+		})
+		require.NoError(t, err)
+
+		_, err = userRepo.GetByEmail(ctx, "after-audit@example.com")
+		assert.NoError(t, err)
+	})
+}
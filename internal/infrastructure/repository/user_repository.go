@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -22,12 +24,12 @@ func NewUserRepository() repository.UserRepository {
 }
 
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return conn(ctx, r.db).Create(user).Error
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
+	err := conn(ctx, r.db).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +38,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Use
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := conn(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
@@ -44,67 +46,311 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 }
 
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return conn(ctx, r.db).Save(user).Error
 }
 
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.User{}, id).Error
+	return conn(ctx, r.db).Delete(&entity.User{}, id).Error
 }
 
-func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*entity.User, int64, error) {
-	var users []*entity.User
+// userSortColumns maps repository.UserSortFields to their columns
+var userSortColumns = map[string]string{
+	"name":       "users.name",
+	"email":      "users.email",
+	"is_active":  "users.is_active",
+	"created_at": "users.created_at",
+	"updated_at": "users.updated_at",
+}
+
+func (r *userRepository) List(ctx context.Context, filter repository.UserFilter, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	order, err := orderBy(filter.Sort, userSortColumns, "users.created_at DESC")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := r.filtered(ctx, filter)
+
 	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []*entity.User
+	err = query.
+		Order(order).
+		Order("users.id").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := preloadUserRelations(conn(ctx, r.db), users, with); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
 
-	err := r.db.WithContext(ctx).Model(&entity.User{}).Count(&total).Error
+func (r *userRepository) ListAfter(ctx context.Context, filter repository.UserFilter, page repository.KeysetPage, with ...repository.Relation) ([]*entity.User, int64, error) {
+	var users []*entity.User
+	total, err := listAfterKeyset(r.filtered(ctx, filter), "users", page, &users)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	err = r.db.WithContext(ctx).
+	if err := preloadUserRelations(conn(ctx, r.db), users, with); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// filtered returns the user query restricted by the filter, without ordering
+func (r *userRepository) filtered(ctx context.Context, filter repository.UserFilter) *gorm.DB {
+	query := conn(ctx, r.db).Model(&entity.User{})
+
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("(LOWER(users.name) LIKE LOWER(?) OR LOWER(users.email) LIKE LOWER(?))", like, like)
+	}
+	if filter.Role != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM user_roles JOIN roles ON roles.id = user_roles.role_id
+			WHERE user_roles.user_id = users.id AND roles.slug = ?
+			AND (user_roles.expires_at IS NULL OR user_roles.expires_at > ?))`, filter.Role, time.Now())
+	}
+	if filter.IsActive != nil {
+		query = query.Where("users.is_active = ?", *filter.IsActive)
+	}
+	if filter.DormitoryID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM user_dormitories WHERE user_dormitories.user_id = users.id AND user_dormitories.dormitory_id = ?)", *filter.DormitoryID)
+	}
+
+	return query
+}
+
+func (r *userRepository) ListDeleted(ctx context.Context, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	var users []*entity.User
+	var total int64
+
+	deleted := conn(ctx, r.db).Unscoped().Model(&entity.User{}).Where("deleted_at IS NOT NULL")
+
+	if err := deleted.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := deleted.
+		Order("deleted_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := preloadUserRelations(conn(ctx, r.db), users, with); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (r *userRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	var user entity.User
+	err := conn(ctx, r.db).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Unscoped().
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
 
-	return users, total, err
+// userReferences lists the tables whose rows belong to a single user and go with it on purge.
+// Audit logs are kept; they only hold the user ID as text.
+var userReferences = []string{
+	"user_roles",
+	"user_dormitories",
+	"user_dormitory_roles",
+	"role_requests",
+	"user_sessions",
+	"refresh_tokens",
+	"user_tokens",
+	"user_mfa",
+	"mfa_recovery_codes",
+	"linked_identities",
+}
+
+func (r *userRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		err := tx.Unscoped().Model(&entity.User{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+
+		apiKeys := tx.Model(&entity.APIKey{}).Select("id").Where("user_id IN ?", ids)
+		if err := tx.Exec("DELETE FROM api_key_permissions WHERE api_key_id IN (?)", apiKeys).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&entity.APIKey{}).Error; err != nil {
+			return err
+		}
+		for _, table := range userReferences {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE user_id IN ?", ids).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&entity.User{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
 }
 
 func (r *userRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).
-		Preload("Roles").
+	err := conn(ctx, r.db).
+		Preload("Roles", "id NOT IN (?)", expiredRoleGrants(conn(ctx, r.db), id)).
 		Preload("Roles.Permissions").
 		Where("id = ?", id).
 		First(&user).Error
 	if err != nil {
 		return nil, err
 	}
+	if err := loadUserInheritedPermissions(conn(ctx, r.db), &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
 func (r *userRepository) GetWithRolesAndDormitories(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	var user entity.User
-	err := r.db.WithContext(ctx).
-		Preload("Roles").
+	err := conn(ctx, r.db).
+		Preload("Roles", "id NOT IN (?)", expiredRoleGrants(conn(ctx, r.db), id)).
 		Preload("Roles.Permissions").
 		Preload("Dormitories").
+		Preload("DormitoryRoles.Role.Permissions").
 		Where("id = ?", id).
 		First(&user).Error
 	if err != nil {
 		return nil, err
 	}
+	if err := loadUserInheritedPermissions(conn(ctx, r.db), &user); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
-func (r *userRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+// AssignRole grants a role; granting it again replaces the expiry of the existing grant
+func (r *userRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID, expiresAt *time.Time) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "role_id"}},
+			// A permanent grant stays permanent when a time-bound one is granted on top
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"expires_at": gorm.Expr("CASE WHEN user_roles.expires_at IS NULL THEN NULL ELSE excluded.expires_at END"),
+			}),
+		}).
 		Create(&entity.UserRole{
-			UserID: userID,
-			RoleID: roleID,
+			UserID:    userID,
+			RoleID:    roleID,
+			ExpiresAt: expiresAt,
 		}).Error
 }
 
+func (r *userRepository) HasPermanentRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).
+		Model(&entity.UserRole{}).
+		Where("user_id = ? AND role_id = ? AND expires_at IS NULL", userID, roleID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 func (r *userRepository) RemoveRole(ctx context.Context, userID, roleID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Where("user_id = ? AND role_id = ?", userID, roleID).
 		Delete(&entity.UserRole{}).Error
 }
+
+func (r *userRepository) RevokeExpiredRoles(ctx context.Context, before time.Time) ([]*entity.UserRole, error) {
+	var revoked []*entity.UserRole
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var expired []*entity.UserRole
+		if err := tx.Where("expires_at <= ?", before).Find(&expired).Error; err != nil {
+			return err
+		}
+		for _, grant := range expired {
+			// The expiry is checked again so a grant renewed meanwhile is kept
+			result := tx.Where("user_id = ? AND role_id = ? AND expires_at <= ?", grant.UserID, grant.RoleID, before).
+				Delete(&entity.UserRole{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				revoked = append(revoked, grant)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revoked, nil
+}
+
+func (r *userRepository) GetDormitoryRoles(ctx context.Context, userID uuid.UUID) ([]*entity.UserDormitoryRole, error) {
+	var scopedRoles []*entity.UserDormitoryRole
+	err := conn(ctx, r.db).
+		Preload("Role").
+		Preload("Dormitory").
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&scopedRoles).Error
+	return scopedRoles, err
+}
+
+// AssignDormitoryRole grants a role within a dormitory; granting it again is a no-op
+func (r *userRepository) AssignDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.UserDormitoryRole{
+			UserID:      userID,
+			DormitoryID: dormitoryID,
+			RoleID:      roleID,
+		}).Error
+}
+
+func (r *userRepository) RemoveDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Where("user_id = ? AND dormitory_id = ? AND role_id = ?", userID, dormitoryID, roleID).
+		Delete(&entity.UserDormitoryRole{}).Error
+}
+
+// loadUserInheritedPermissions resolves the inherited permissions of the user's global and dormitory-scoped roles
+func loadUserInheritedPermissions(db *gorm.DB, user *entity.User) error {
+	roles := make([]*entity.Role, 0, len(user.Roles)+len(user.DormitoryRoles))
+	for i := range user.Roles {
+		roles = append(roles, &user.Roles[i])
+	}
+	for i := range user.DormitoryRoles {
+		roles = append(roles, &user.DormitoryRoles[i].Role)
+	}
+	return loadInheritedPermissions(db, roles...)
+}
+
+// expiredRoleGrants selects the roles whose time-bound grant to the user has
+// expired, so they no longer count even before the sweeper removes them
+func expiredRoleGrants(db *gorm.DB, userID uuid.UUID) *gorm.DB {
+	return db.Model(&entity.UserRole{}).
+		Select("role_id").
+		Where("user_id = ? AND expires_at <= ?", userID, time.Now())
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
 	"github.com/your-org/go-backend-starter/internal/testutil"
 )
 
@@ -161,6 +163,83 @@ func TestUserRepository_Delete(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestUserRepository_SoftDeleteRestoreAndPurge(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	role := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true}
+	require.NoError(t, db.Create(role).Error)
+	dormitory := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A", IsActive: true}
+	require.NoError(t, db.Create(dormitory).Error)
+
+	newUser := func(email string) *entity.User {
+		user := &entity.User{
+			ID:        uuid.New(),
+			Email:     email,
+			Password:  "hashedpassword",
+			Name:      "Test User",
+			IsActive:  true,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		require.NoError(t, db.Create(user).Error)
+		require.NoError(t, repo.AssignRole(ctx, user.ID, role.ID, nil))
+		require.NoError(t, db.Create(&entity.UserDormitory{UserID: user.ID, DormitoryID: dormitory.ID}).Error)
+		return user
+	}
+	kept := newUser("kept@example.com")
+	restored := newUser("restored@example.com")
+	purged := newUser("purged@example.com")
+
+	for _, user := range []*entity.User{restored, purged} {
+		require.NoError(t, repo.Delete(ctx, user.ID))
+	}
+
+	// Deleted users are hidden from regular queries but kept in the table
+	_, err := repo.GetByID(ctx, restored.ID)
+	assert.Error(t, err)
+	_, total, err := repo.List(ctx, repository.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	deleted, total, err := repo.ListDeleted(ctx, 10, 0, repository.RelationRoles)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, deleted, 2)
+	assert.True(t, deleted[0].DeletedAt.Valid)
+	require.Len(t, deleted[0].Roles, 1)
+
+	_, err = repo.GetDeletedByID(ctx, kept.ID)
+	assert.Error(t, err)
+
+	// Restoring brings the roles and dormitories back with the user
+	require.NoError(t, repo.Restore(ctx, restored.ID))
+	found, err := repo.GetWithRolesAndDormitories(ctx, restored.ID)
+	require.NoError(t, err)
+	assert.Len(t, found.Roles, 1)
+	assert.Len(t, found.Dormitories, 1)
+
+	// Only users deleted before the cut-off are purged
+	count, err := repo.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	count, err = repo.PurgeDeleted(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	var remaining int64
+	require.NoError(t, db.Unscoped().Model(&entity.User{}).Count(&remaining).Error)
+	assert.Equal(t, int64(2), remaining)
+	require.NoError(t, db.Model(&entity.UserRole{}).Where("user_id = ?", purged.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+	require.NoError(t, db.Model(&entity.UserDormitory{}).Where("user_id = ?", purged.ID).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
 func TestUserRepository_List(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
@@ -183,18 +262,219 @@ func TestUserRepository_List(t *testing.T) {
 	}
 
 	// List users
-	users, total, err := repo.List(ctx, 10, 0)
+	users, total, err := repo.List(ctx, repository.UserFilter{}, 10, 0)
 	require.NoError(t, err)
 	assert.Equal(t, int64(5), total)
 	assert.Len(t, users, 5)
 
 	// Test pagination
-	users, total, err = repo.List(ctx, 2, 0)
+	users, total, err = repo.List(ctx, repository.UserFilter{}, 2, 0)
 	require.NoError(t, err)
 	assert.Equal(t, int64(5), total)
 	assert.Len(t, users, 2)
 }
 
+func TestUserRepository_ListFilters(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	newUser := func(name, email string, active bool, age time.Duration) *entity.User {
+		user := &entity.User{
+			ID:        uuid.New(),
+			Email:     email,
+			Password:  "hashedpassword",
+			Name:      name,
+			CreatedAt: base.Add(age),
+			UpdatedAt: base.Add(age),
+		}
+		require.NoError(t, db.Create(user).Error)
+		// IsActive defaults to true, so false has to be written explicitly
+		require.NoError(t, db.Model(user).Update("is_active", active).Error)
+		return user
+	}
+	alice := newUser("Alice", "alice@example.com", true, time.Minute)
+	bob := newUser("Bob", "bob@campus.test", false, 2*time.Minute)
+	carol := newUser("Carol", "carol@example.com", true, 3*time.Minute)
+
+	admin := &entity.Role{ID: uuid.New(), Name: "Admin", Slug: "admin", IsActive: true}
+	require.NoError(t, db.Create(admin).Error)
+	require.NoError(t, db.Create(&entity.UserRole{UserID: alice.ID, RoleID: admin.ID}).Error)
+	expired := time.Now().Add(-time.Minute)
+	require.NoError(t, db.Create(&entity.UserRole{UserID: carol.ID, RoleID: admin.ID, ExpiresAt: &expired}).Error)
+
+	dormitory := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A", IsActive: true}
+	require.NoError(t, db.Create(dormitory).Error)
+	require.NoError(t, db.Create(&entity.UserDormitory{UserID: bob.ID, DormitoryID: dormitory.ID}).Error)
+
+	isActive := false
+	tests := []struct {
+		name     string
+		filter   repository.UserFilter
+		expected []*entity.User
+	}{
+		{name: "default order is newest first", filter: repository.UserFilter{}, expected: []*entity.User{carol, bob, alice}},
+		{name: "search name case-insensitive", filter: repository.UserFilter{Search: "ALI"}, expected: []*entity.User{alice}},
+		{name: "search email", filter: repository.UserFilter{Search: "campus"}, expected: []*entity.User{bob}},
+		{name: "role skips expired grants", filter: repository.UserFilter{Role: "admin"}, expected: []*entity.User{alice}},
+		{name: "inactive only", filter: repository.UserFilter{IsActive: &isActive}, expected: []*entity.User{bob}},
+		{name: "dormitory members", filter: repository.UserFilter{DormitoryID: &dormitory.ID}, expected: []*entity.User{bob}},
+		{name: "sort by name", filter: repository.UserFilter{Sort: repository.Sort{Field: "name"}}, expected: []*entity.User{alice, bob, carol}},
+		{name: "sort by email descending", filter: repository.UserFilter{Sort: repository.Sort{Field: "email", Desc: true}}, expected: []*entity.User{carol, bob, alice}},
+		{name: "combined filters", filter: repository.UserFilter{Search: "example", IsActive: &isActive}, expected: []*entity.User{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, total, err := repo.List(ctx, tt.filter, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expected)), total)
+			require.Len(t, users, len(tt.expected))
+			for i, user := range users {
+				assert.Equal(t, tt.expected[i].ID, user.ID)
+			}
+		})
+	}
+
+	// Only whitelisted fields reach the ORDER BY clause
+	_, _, err := repo.List(ctx, repository.UserFilter{Sort: repository.Sort{Field: "password; DROP TABLE users"}}, 10, 0)
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidSortField)
+}
+
+func TestUserRepository_ListAfter(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	// Several users share a creation time, so the ID has to break ties
+	base := time.Now().Add(-time.Hour).UTC()
+	for i := 0; i < 7; i++ {
+		createdAt := base.Add(time.Duration(i/3) * time.Minute)
+		require.NoError(t, db.Create(&entity.User{
+			ID:        uuid.New(),
+			Email:     fmt.Sprintf("keyset%d@example.com", i),
+			Password:  "hashedpassword",
+			Name:      fmt.Sprintf("Keyset %d", i),
+			IsActive:  true,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}).Error)
+	}
+
+	var seen []*entity.User
+	page := repository.KeysetPage{Limit: 3, WithCount: true}
+	for len(seen) < 7 {
+		users, total, err := repo.ListAfter(ctx, repository.UserFilter{}, page)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), total)
+		require.NotEmpty(t, users)
+		seen = append(seen, users...)
+
+		last := users[len(users)-1]
+		page.After = &repository.Keyset{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	// Nothing after the last row, and no count unless asked for
+	users, total, err := repo.ListAfter(ctx, repository.UserFilter{}, repository.KeysetPage{After: page.After, Limit: 3})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Zero(t, total)
+
+	// Pages cover every user once, in (created_at DESC, id DESC) order
+	require.Len(t, seen, 7)
+	for i := 1; i < len(seen); i++ {
+		prev, cur := seen[i-1], seen[i]
+		if prev.CreatedAt.Equal(cur.CreatedAt) {
+			assert.Greater(t, prev.ID.String(), cur.ID.String())
+		} else {
+			assert.True(t, prev.CreatedAt.After(cur.CreatedAt))
+		}
+	}
+
+	// Filters apply to keyset pages too
+	users, total, err = repo.ListAfter(ctx, repository.UserFilter{Search: "keyset6"}, repository.KeysetPage{Limit: 3, WithCount: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, users, 1)
+}
+
+func TestUserRepository_ListPreloadsRelations(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true}
+	require.NoError(t, db.Create(staff).Error)
+	warden := &entity.Role{ID: uuid.New(), Name: "Warden", Slug: "warden", IsActive: true}
+	require.NoError(t, db.Create(warden).Error)
+	dormitory := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A", IsActive: true}
+	require.NoError(t, db.Create(dormitory).Error)
+
+	past := time.Now().Add(-time.Minute)
+	newUsers := func(n int) {
+		for i := 0; i < n; i++ {
+			user := &entity.User{
+				ID:        uuid.New(),
+				Email:     fmt.Sprintf("%s@example.com", uuid.NewString()),
+				Password:  "hashedpassword",
+				Name:      "Test User",
+				IsActive:  true,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}
+			require.NoError(t, db.Create(user).Error)
+			require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, nil))
+			require.NoError(t, repo.AssignRole(ctx, user.ID, warden.ID, &past))
+			require.NoError(t, db.Create(&entity.UserDormitory{UserID: user.ID, DormitoryID: dormitory.ID}).Error)
+		}
+	}
+	with := []repository.Relation{repository.RelationRoles, repository.RelationDormitories}
+
+	// Count and users, then grants and roles, then memberships and dormitories,
+	// whatever the number of users on the page
+	newUsers(2)
+	var users []*entity.User
+	testutil.AssertQueryCount(t, db, 6, func() {
+		var err error
+		users, _, err = repo.List(ctx, repository.UserFilter{}, 10, 0, with...)
+		require.NoError(t, err)
+	})
+	require.Len(t, users, 2)
+
+	newUsers(6)
+	testutil.AssertQueryCount(t, db, 6, func() {
+		users, _, _ = repo.List(ctx, repository.UserFilter{}, 10, 0, with...)
+	})
+	require.Len(t, users, 8)
+	for _, user := range users {
+		// The expired warden grant is left out
+		require.Len(t, user.Roles, 1)
+		assert.Equal(t, "staff", user.Roles[0].Slug)
+		require.Len(t, user.Dormitories, 1)
+		assert.Equal(t, dormitory.ID, user.Dormitories[0].ID)
+	}
+
+	testutil.AssertQueryCount(t, db, 5, func() {
+		users, _, _ = repo.ListAfter(ctx, repository.UserFilter{}, repository.KeysetPage{Limit: 10}, with...)
+	})
+	require.Len(t, users, 8)
+	assert.Len(t, users[0].Roles, 1)
+
+	// Without relations only the users are loaded
+	testutil.AssertQueryCount(t, db, 2, func() {
+		users, _, _ = repo.List(ctx, repository.UserFilter{}, 10, 0)
+	})
+	assert.Empty(t, users[0].Roles)
+	assert.Empty(t, users[0].Dormitories)
+}
+
 func TestUserRepository_GetWithRoles(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
@@ -233,3 +513,155 @@ func TestUserRepository_GetWithRoles(t *testing.T) {
 	assert.Len(t, userWithRoles.Roles, 1)
 	assert.Equal(t, "admin", userWithRoles.Roles[0].Name)
 }
+
+func TestUserRepository_GetWithRolesAndDormitories_InheritedPermissions(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	userRead := entity.Permission{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"}
+	dormRead := entity.Permission{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read"}
+	roleRead := entity.Permission{ID: uuid.New(), Name: "role:read", Slug: "role-read", Resource: "role", Action: "read"}
+
+	// base <- staff <- manager
+	base := &entity.Role{ID: uuid.New(), Name: "Base", Slug: "base", IsActive: true, Permissions: []entity.Permission{userRead}}
+	require.NoError(t, db.Create(base).Error)
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true, ParentID: &base.ID, Permissions: []entity.Permission{dormRead}}
+	require.NoError(t, db.Create(staff).Error)
+	manager := &entity.Role{ID: uuid.New(), Name: "Manager", Slug: "manager", IsActive: true, ParentID: &staff.ID, Permissions: []entity.Permission{roleRead, userRead}}
+	require.NoError(t, db.Create(manager).Error)
+
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     "test@example.com",
+		Password:  "hashedpassword",
+		Name:      "Test User",
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(user).Error)
+	require.NoError(t, db.Create(&entity.UserRole{UserID: user.ID, RoleID: manager.ID}).Error)
+
+	found, err := repo.GetWithRolesAndDormitories(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Roles, 1)
+	// Permissions already assigned directly are not repeated
+	require.Len(t, found.Roles[0].InheritedPermissions, 1)
+	assert.Equal(t, "dorm:read", found.Roles[0].InheritedPermissions[0].Name)
+	assert.True(t, found.HasPermission("dorm:read"))
+	assert.True(t, found.HasPermission("user:read"))
+
+	// A cycle stored in the database stops the walk
+	require.NoError(t, db.Model(base).Update("parent_id", manager.ID).Error)
+	require.NoError(t, db.Where("role_id = ?", manager.ID).Delete(&entity.RolePermission{}).Error)
+	found, err = repo.GetWithRolesAndDormitories(ctx, user.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"dorm:read", "user:read"}, permissionNames(found.Roles[0].InheritedPermissions))
+}
+
+func TestUserRepository_RoleGrantExpiry(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true}
+	require.NoError(t, db.Create(staff).Error)
+	warden := &entity.Role{ID: uuid.New(), Name: "Warden", Slug: "warden", IsActive: true}
+	require.NoError(t, db.Create(warden).Error)
+
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     "test@example.com",
+		Password:  "hashedpassword",
+		Name:      "Test User",
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	// Assigning twice updates the expiry instead of failing
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, nil))
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, &future))
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, repo.AssignRole(ctx, user.ID, warden.ID, &past))
+
+	// An expired grant no longer counts before the sweeper has run
+	found, err := repo.GetWithRolesAndDormitories(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, found.Roles, 1)
+	assert.Equal(t, "staff", found.Roles[0].Slug)
+
+	revoked, err := repo.RevokeExpiredRoles(ctx, time.Now())
+	require.NoError(t, err)
+	require.Len(t, revoked, 1)
+	assert.Equal(t, warden.ID, revoked[0].RoleID)
+
+	var remaining int64
+	require.NoError(t, db.Model(&entity.UserRole{}).Where("user_id = ?", user.ID).Count(&remaining).Error)
+	assert.Equal(t, int64(1), remaining)
+}
+
+func TestUserRepository_AssignRoleKeepsPermanentGrant(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	repo := &userRepository{db: db}
+	ctx := context.Background()
+
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true}
+	require.NoError(t, db.Create(staff).Error)
+	user := &entity.User{
+		ID:        uuid.New(),
+		Email:     "test@example.com",
+		Password:  "hashedpassword",
+		Name:      "Test User",
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, db.Create(user).Error)
+
+	expiresAt := func() *time.Time {
+		var grant entity.UserRole
+		require.NoError(t, db.Where("user_id = ? AND role_id = ?", user.ID, staff.ID).First(&grant).Error)
+		return grant.ExpiresAt
+	}
+
+	// A time-bound grant can be extended or made permanent
+	soon := time.Now().Add(time.Hour)
+	later := time.Now().Add(24 * time.Hour)
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, &soon))
+	held, err := repo.HasPermanentRole(ctx, user.ID, staff.ID)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, &later))
+	require.NotNil(t, expiresAt())
+	assert.WithinDuration(t, later, *expiresAt(), time.Second)
+
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, nil))
+	assert.Nil(t, expiresAt())
+
+	// Granting a time-bound role on top of a permanent one does not shorten it
+	require.NoError(t, repo.AssignRole(ctx, user.ID, staff.ID, &soon))
+	assert.Nil(t, expiresAt())
+
+	held, err = repo.HasPermanentRole(ctx, user.ID, staff.ID)
+	require.NoError(t, err)
+	assert.True(t, held)
+}
+
+func permissionNames(permissions []entity.Permission) []string {
+	names := make([]string, 0, len(permissions))
+	for _, perm := range permissions {
+		names = append(names, perm.Name)
+	}
+	return names
+}
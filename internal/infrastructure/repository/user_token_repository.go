@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository creates a new user token repository
+func NewUserTokenRepository() repository.UserTokenRepository {
+	return &userTokenRepository{
+		db: database.DB,
+	}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, token *entity.UserToken) error {
+	return conn(ctx, r.db).Create(token).Error
+}
+
+func (r *userTokenRepository) GetByTokenHash(ctx context.Context, purpose, tokenHash string) (*entity.UserToken, error) {
+	var token entity.UserToken
+	err := conn(ctx, r.db).
+		Where("purpose = ? AND token_hash = ?", purpose, tokenHash).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *userTokenRepository) MarkUsed(ctx context.Context, id uuid.UUID) error {
+	// Conditional update so concurrent requests cannot both use the same token
+	result := conn(ctx, r.db).
+		Model(&entity.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userTokenRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string) error {
+	return conn(ctx, r.db).
+		Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,19 +13,30 @@ import (
 	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
+// defaultSecretKey is only accepted outside production
+const defaultSecretKey = "default-secret-key-change-in-production"
+
 type jwtService struct {
-	secretKey            []byte
-	accessTokenExpiry   time.Duration
-	refreshTokenExpiry  time.Duration
+	signingMethod      jwt.SigningMethod
+	signingKey         interface{}
+	signingKeyID       string
+	verificationKeys   map[string]interface{}
+	jwks               service.JSONWebKeySet
+	accessTokenExpiry  time.Duration
+	refreshTokenExpiry time.Duration
+	mfaTokenExpiry     time.Duration
 }
 
-// NewJWTService creates a new JWT service
-func NewJWTService() service.TokenService {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "default-secret-key-change-in-production"
-	}
-
+// NewJWTService creates a new JWT service.
+//
+// The signing algorithm is selected by JWT_SIGNING_ALG:
+//   - HS256 (default): signs with JWT_SECRET
+//   - RS256 / EdDSA: signs with the PEM private key at JWT_PRIVATE_KEY_PATH
+//
+// JWT_KEY_ID sets the kid header of issued tokens. During key rotation,
+// JWT_VERIFICATION_KEYS lists additional public keys that are still accepted,
+// as comma-separated kid=path/to/public.pem pairs.
+func NewJWTService() (service.TokenService, error) {
 	accessExpiry := 15 * time.Minute
 	if expiryStr := os.Getenv("JWT_ACCESS_TOKEN_EXPIRY"); expiryStr != "" {
 		if parsed, err := time.ParseDuration(expiryStr); err == nil {
@@ -38,49 +51,152 @@ func NewJWTService() service.TokenService {
 		}
 	}
 
-	return &jwtService{
-		secretKey:           []byte(secretKey),
-		accessTokenExpiry:   accessExpiry,
-		refreshTokenExpiry:  refreshExpiry,
+	mfaExpiry := 5 * time.Minute
+	if expiryStr := os.Getenv("JWT_MFA_TOKEN_EXPIRY"); expiryStr != "" {
+		if parsed, err := time.ParseDuration(expiryStr); err == nil {
+			mfaExpiry = parsed
+		}
+	}
+
+	s := &jwtService{
+		signingKeyID:       os.Getenv("JWT_KEY_ID"),
+		verificationKeys:   make(map[string]interface{}),
+		jwks:               service.JSONWebKeySet{Keys: []service.JSONWebKey{}},
+		accessTokenExpiry:  accessExpiry,
+		refreshTokenExpiry: refreshExpiry,
+		mfaTokenExpiry:     mfaExpiry,
+	}
+
+	alg := os.Getenv("JWT_SIGNING_ALG")
+	switch alg {
+	case "", jwt.SigningMethodHS256.Alg():
+		secretKey := os.Getenv("JWT_SECRET")
+		if secretKey == "" || secretKey == defaultSecretKey {
+			if os.Getenv("APP_ENV") == "production" {
+				return nil, errors.New("JWT_SECRET must be set to a non-default value in production")
+			}
+			secretKey = defaultSecretKey
+		}
+		s.signingMethod = jwt.SigningMethodHS256
+		s.signingKey = []byte(secretKey)
+		s.verificationKeys[s.signingKeyID] = s.signingKey
+		// Symmetric keys are never published
+		return s, nil
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		privateKey, publicKey, err := loadPrivateKey(alg, os.Getenv("JWT_PRIVATE_KEY_PATH"))
+		if err != nil {
+			return nil, err
+		}
+		s.signingMethod = jwt.GetSigningMethod(alg)
+		s.signingKey = privateKey
+		if s.signingKeyID == "" {
+			s.signingKeyID = keyThumbprint(publicKey)
+		}
+		if err := s.addVerificationKey(s.signingKeyID, publicKey); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT_SIGNING_ALG %q", alg)
+	}
+
+	// Additional verification keys (previous keys during rotation)
+	if keys := os.Getenv("JWT_VERIFICATION_KEYS"); keys != "" {
+		for _, entry := range strings.Split(keys, ",") {
+			kid, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+			if !ok || kid == "" || path == "" {
+				return nil, fmt.Errorf("invalid JWT_VERIFICATION_KEYS entry %q, expected kid=path", entry)
+			}
+			publicKey, err := loadPublicKey(alg, path)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.addVerificationKey(kid, publicKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// addVerificationKey registers a public key and publishes it in the JWKS
+func (s *jwtService) addVerificationKey(kid string, publicKey interface{}) error {
+	jwk, err := toJSONWebKey(kid, s.signingMethod.Alg(), publicKey)
+	if err != nil {
+		return err
+	}
+	s.verificationKeys[kid] = publicKey
+	s.jwks.Keys = append(s.jwks.Keys, jwk)
+	return nil
+}
+
+// sign signs the claims with the current signing key
+func (s *jwtService) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(s.signingMethod, claims)
+	if s.signingKeyID != "" {
+		token.Header["kid"] = s.signingKeyID
+	}
+	return token.SignedString(s.signingKey)
+}
+
+// keyFunc resolves the verification key for a token based on its kid header
+func (s *jwtService) keyFunc(token *jwt.Token) (interface{}, error) {
+	if token.Method.Alg() != s.signingMethod.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.verificationKeys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
 	}
+	return key, nil
 }
 
 // GenerateAccessToken generates a new access token
 func (s *jwtService) GenerateAccessToken(userID uuid.UUID, email string, roles []string) (string, error) {
 	claims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
 		"user_id": userID.String(),
 		"email":   email,
 		"roles":   roles,
-		"type":    "access",
+		"type":    service.TokenTypeAccess,
 		"exp":     time.Now().Add(s.accessTokenExpiry).Unix(),
 		"iat":     time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	return s.sign(claims)
 }
 
 // GenerateRefreshToken generates a new refresh token
 func (s *jwtService) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
 		"user_id": userID.String(),
-		"type":    "refresh",
+		"type":    service.TokenTypeRefresh,
 		"exp":     time.Now().Add(s.refreshTokenExpiry).Unix(),
 		"iat":     time.Now().Unix(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	return s.sign(claims)
+}
+
+// GenerateMFAToken generates a short-lived token for the second login step
+func (s *jwtService) GenerateMFAToken(userID uuid.UUID) (string, error) {
+	claims := jwt.MapClaims{
+		"jti":     uuid.New().String(),
+		"user_id": userID.String(),
+		"type":    service.TokenTypeMFAPending,
+		"exp":     time.Now().Add(s.mfaTokenExpiry).Unix(),
+		"iat":     time.Now().Unix(),
+	}
+
+	return s.sign(claims)
 }
 
 // ValidateToken validates and parses a JWT token
 func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return s.secretKey, nil
-	})
+	token, err := jwt.Parse(tokenString, s.keyFunc)
 
 	if err != nil {
 		return nil, domainErrors.ErrInvalidToken
@@ -120,6 +236,14 @@ func (s *jwtService) ValidateToken(tokenString string) (*service.TokenClaims, er
 		Exp:    int64(exp),
 	}
 
+	if jti, ok := claims["jti"].(string); ok {
+		tokenClaims.TokenID = jti
+	}
+
+	if tokenType, ok := claims["type"].(string); ok {
+		tokenClaims.Type = tokenType
+	}
+
 	// Extract email and roles for access tokens
 	if email, ok := claims["email"].(string); ok {
 		tokenClaims.Email = email
@@ -145,12 +269,10 @@ func (s *jwtService) RefreshAccessToken(refreshToken string) (string, error) {
 	}
 
 	// Check if it's a refresh token
-	token, _ := jwt.Parse(refreshToken, func(token *jwt.Token) (interface{}, error) {
-		return s.secretKey, nil
-	})
+	token, _ := jwt.Parse(refreshToken, s.keyFunc)
 
 	if tokenClaims, ok := token.Claims.(jwt.MapClaims); ok {
-		if tokenType, ok := tokenClaims["type"].(string); !ok || tokenType != "refresh" {
+		if tokenType, ok := tokenClaims["type"].(string); !ok || tokenType != service.TokenTypeRefresh {
 			return "", domainErrors.ErrInvalidToken
 		}
 	}
@@ -160,3 +282,23 @@ func (s *jwtService) RefreshAccessToken(refreshToken string) (string, error) {
 	// So we'll need to fetch from database in the use case
 	return s.GenerateAccessToken(claims.UserID, "", []string{})
 }
+
+// AccessTokenExpiry returns the lifetime of access tokens
+func (s *jwtService) AccessTokenExpiry() time.Duration {
+	return s.accessTokenExpiry
+}
+
+// RefreshTokenExpiry returns the lifetime of refresh tokens
+func (s *jwtService) RefreshTokenExpiry() time.Duration {
+	return s.refreshTokenExpiry
+}
+
+// MFATokenExpiry returns the lifetime of mfa_pending tokens
+func (s *jwtService) MFATokenExpiry() time.Duration {
+	return s.mfaTokenExpiry
+}
+
+// JWKS returns the public verification keys
+func (s *jwtService) JWKS() service.JSONWebKeySet {
+	return s.jwks
+}
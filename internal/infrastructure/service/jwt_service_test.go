@@ -1,7 +1,14 @@
 package service
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,7 +22,8 @@ func TestJWTService_GenerateAccessToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 	email := "test@example.com"
 	roles := []string{"admin", "user"}
@@ -30,7 +38,8 @@ func TestJWTService_GenerateRefreshToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 
 	token, err := service.GenerateRefreshToken(userID)
@@ -43,7 +52,8 @@ func TestJWTService_ValidateToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 	email := "test@example.com"
 	roles := []string{"admin", "user"}
@@ -59,14 +69,36 @@ func TestJWTService_ValidateToken(t *testing.T) {
 	assert.Equal(t, userID, claims.UserID)
 	assert.Equal(t, email, claims.Email)
 	assert.Equal(t, roles, claims.Roles)
+	assert.NotEmpty(t, claims.TokenID)
+	assert.Equal(t, "access", claims.Type)
 	assert.Greater(t, claims.Exp, time.Now().Unix())
 }
 
+func TestJWTService_GenerateMFAToken(t *testing.T) {
+	testutil.SetTestEnv()
+	defer testutil.UnsetTestEnv()
+
+	service, err := NewJWTService()
+	require.NoError(t, err)
+	userID := uuid.New()
+
+	token, err := service.GenerateMFAToken(userID)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateToken(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Equal(t, "mfa_pending", claims.Type)
+	assert.LessOrEqual(t, claims.Exp, time.Now().Add(service.MFATokenExpiry()).Unix())
+}
+
 func TestJWTService_ValidateToken_InvalidToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 
 	tests := []struct {
 		name  string
@@ -102,7 +134,8 @@ func TestJWTService_ValidateToken_ExpiredToken(t *testing.T) {
 	defer os.Unsetenv("JWT_SECRET")
 	defer os.Unsetenv("JWT_ACCESS_TOKEN_EXPIRY")
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 	email := "test@example.com"
 	roles := []string{"admin"}
@@ -124,7 +157,8 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 	os.Setenv("JWT_SECRET", "secret1")
 	defer os.Unsetenv("JWT_SECRET")
 
-	service1 := NewJWTService()
+	service1, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 	email := "test@example.com"
 	roles := []string{"admin"}
@@ -135,7 +169,8 @@ func TestJWTService_ValidateToken_WrongSecret(t *testing.T) {
 
 	// Try to validate with service2 using different secret
 	os.Setenv("JWT_SECRET", "secret2")
-	service2 := NewJWTService()
+	service2, err := NewJWTService()
+	require.NoError(t, err)
 
 	claims, err := service2.ValidateToken(token)
 	assert.Error(t, err)
@@ -146,7 +181,8 @@ func TestJWTService_RefreshAccessToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 
 	// Generate refresh token
@@ -164,7 +200,8 @@ func TestJWTService_RefreshAccessToken_InvalidToken(t *testing.T) {
 	testutil.SetTestEnv()
 	defer testutil.UnsetTestEnv()
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 
 	// Try to refresh with access token (should fail)
 	userID := uuid.New()
@@ -186,7 +223,8 @@ func TestJWTService_RefreshAccessToken_ExpiredToken(t *testing.T) {
 	defer os.Unsetenv("JWT_SECRET")
 	defer os.Unsetenv("JWT_REFRESH_TOKEN_EXPIRY")
 
-	service := NewJWTService()
+	service, err := NewJWTService()
+	require.NoError(t, err)
 	userID := uuid.New()
 
 	// Generate refresh token
@@ -201,3 +239,112 @@ func TestJWTService_RefreshAccessToken_ExpiredToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, accessToken)
 }
+
+// writeKeyPair generates a key pair for alg and writes PEM files into dir
+func writeKeyPair(t *testing.T, dir, name, alg string) (privatePath, publicPath string) {
+	t.Helper()
+
+	var privateKey crypto.Signer
+	switch alg {
+	case "RS256":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		privateKey = key
+	case "EdDSA":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		privateKey = key
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	require.NoError(t, err)
+
+	privatePath = filepath.Join(dir, name+".pem")
+	publicPath = filepath.Join(dir, name+".pub.pem")
+	require.NoError(t, os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+	return privatePath, publicPath
+}
+
+func TestJWTService_AsymmetricSigning(t *testing.T) {
+	for _, alg := range []string{"RS256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			dir := t.TempDir()
+			privatePath, _ := writeKeyPair(t, dir, "current", alg)
+
+			t.Setenv("JWT_SIGNING_ALG", alg)
+			t.Setenv("JWT_PRIVATE_KEY_PATH", privatePath)
+			t.Setenv("JWT_KEY_ID", "key-1")
+
+			service, err := NewJWTService()
+			require.NoError(t, err)
+
+			userID := uuid.New()
+			token, err := service.GenerateAccessToken(userID, "test@example.com", []string{"user"})
+			require.NoError(t, err)
+
+			claims, err := service.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, userID, claims.UserID)
+
+			jwks := service.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, "key-1", jwks.Keys[0].Kid)
+			assert.Equal(t, alg, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	oldPrivatePath, oldPublicPath := writeKeyPair(t, dir, "old", "RS256")
+	newPrivatePath, _ := writeKeyPair(t, dir, "new", "RS256")
+
+	t.Setenv("JWT_SIGNING_ALG", "RS256")
+	t.Setenv("JWT_PRIVATE_KEY_PATH", oldPrivatePath)
+	t.Setenv("JWT_KEY_ID", "old")
+	oldService, err := NewJWTService()
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	oldToken, err := oldService.GenerateAccessToken(userID, "test@example.com", nil)
+	require.NoError(t, err)
+
+	// Rotate: sign with the new key, keep accepting the old one
+	t.Setenv("JWT_PRIVATE_KEY_PATH", newPrivatePath)
+	t.Setenv("JWT_KEY_ID", "new")
+	t.Setenv("JWT_VERIFICATION_KEYS", "old="+oldPublicPath)
+	newService, err := NewJWTService()
+	require.NoError(t, err)
+
+	claims, err := newService.ValidateToken(oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, claims.UserID)
+	assert.Len(t, newService.JWKS().Keys, 2)
+
+	// Once the old key is dropped its tokens are rejected
+	t.Setenv("JWT_VERIFICATION_KEYS", "")
+	rotatedService, err := NewJWTService()
+	require.NoError(t, err)
+
+	_, err = rotatedService.ValidateToken(oldToken)
+	assert.Error(t, err)
+}
+
+func TestJWTService_DefaultSecretInProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	t.Setenv("JWT_SECRET", "")
+
+	_, err := NewJWTService()
+	assert.Error(t, err)
+
+	t.Setenv("JWT_SECRET", defaultSecretKey)
+	_, err = NewJWTService()
+	assert.Error(t, err)
+
+	t.Setenv("JWT_SECRET", "a-real-production-secret")
+	_, err = NewJWTService()
+	assert.NoError(t, err)
+}
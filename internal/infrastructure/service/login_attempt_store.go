@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewLoginAttemptStoreFromEnv creates the login attempt store selected by LOGIN_ATTEMPT_STORE.
+// Supported values are "memory" and "database" (default).
+func NewLoginAttemptStoreFromEnv() service.LoginAttemptStore {
+	if os.Getenv("LOGIN_ATTEMPT_STORE") == "memory" {
+		return NewMemoryLoginAttemptStore()
+	}
+	return NewDBLoginAttemptStore()
+}
+
+type memoryLoginAttemptStore struct {
+	mu      sync.Mutex
+	entries map[string]*entity.LoginAttempt
+	window  time.Duration // longest window seen, used to drop idle entries
+}
+
+// NewMemoryLoginAttemptStore creates an in-memory login attempt store.
+// Counters are not shared between instances and are lost on restart.
+func NewMemoryLoginAttemptStore() service.LoginAttemptStore {
+	return &memoryLoginAttemptStore{
+		entries: make(map[string]*entity.LoginAttempt),
+	}
+}
+
+func (s *memoryLoginAttemptStore) Get(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempt, ok := s.entries[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *memoryLoginAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if window > s.window {
+		s.window = window
+	}
+	for k, attempt := range s.entries {
+		if isStaleLoginAttempt(attempt, now, s.window) {
+			delete(s.entries, k)
+		}
+	}
+
+	attempt, ok := s.entries[key]
+	if !ok || now.Sub(attempt.LastFailureAt) > window {
+		attempt = &entity.LoginAttempt{Key: key}
+		s.entries[key] = attempt
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+
+	copied := *attempt
+	return &copied, nil
+}
+
+func (s *memoryLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempt, ok := s.entries[key]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+type dbLoginAttemptStore struct {
+	db *gorm.DB
+}
+
+// NewDBLoginAttemptStore creates a database-backed login attempt store
+func NewDBLoginAttemptStore() service.LoginAttemptStore {
+	return &dbLoginAttemptStore{
+		db: database.DB,
+	}
+}
+
+func (s *dbLoginAttemptStore) Get(ctx context.Context, key string) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	err := s.db.WithContext(ctx).Where("key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (s *dbLoginAttemptStore) Increment(ctx context.Context, key string, window time.Duration) (*entity.LoginAttempt, error) {
+	now := time.Now()
+	var attempt entity.LoginAttempt
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Drop idle counters
+		if err := tx.
+			Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", now.Add(-window), now).
+			Delete(&entity.LoginAttempt{}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&attempt).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && now.Sub(attempt.LastFailureAt) > window) {
+			attempt = entity.LoginAttempt{Key: key}
+		} else if err != nil {
+			return err
+		}
+
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		return tx.Save(&attempt).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (s *dbLoginAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	return s.db.WithContext(ctx).
+		Model(&entity.LoginAttempt{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+}
+
+func (s *dbLoginAttemptStore) Reset(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("key = ?", key).Delete(&entity.LoginAttempt{}).Error
+}
+
+// isStaleLoginAttempt checks if a counter is idle and no longer locked
+func isStaleLoginAttempt(attempt *entity.LoginAttempt, now time.Time, window time.Duration) bool {
+	return now.Sub(attempt.LastFailureAt) > window && !attempt.IsLocked(now)
+}
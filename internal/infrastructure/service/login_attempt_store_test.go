@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestLoginAttemptStore(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	stores := map[string]service.LoginAttemptStore{
+		"memory":   NewMemoryLoginAttemptStore(),
+		"database": &dbLoginAttemptStore{db: db},
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			attempt, err := store.Get(ctx, "email:user@example.com")
+			require.NoError(t, err)
+			assert.Nil(t, attempt)
+
+			for i := 1; i <= 3; i++ {
+				attempt, err = store.Increment(ctx, "email:user@example.com", time.Minute)
+				require.NoError(t, err)
+				assert.Equal(t, i, attempt.Failures)
+			}
+
+			until := time.Now().Add(time.Minute)
+			require.NoError(t, store.Lock(ctx, "email:user@example.com", until))
+
+			attempt, err = store.Get(ctx, "email:user@example.com")
+			require.NoError(t, err)
+			require.NotNil(t, attempt)
+			assert.Equal(t, 3, attempt.Failures)
+			assert.True(t, attempt.IsLocked(time.Now()))
+
+			// Counters idle for longer than the window start over
+			attempt, err = store.Increment(ctx, "ip:10.0.0.1", time.Minute)
+			require.NoError(t, err)
+			assert.Equal(t, 1, attempt.Failures)
+			attempt, err = store.Increment(ctx, "ip:10.0.0.1", -time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, 1, attempt.Failures)
+
+			require.NoError(t, store.Reset(ctx, "email:user@example.com"))
+			attempt, err = store.Get(ctx, "email:user@example.com")
+			require.NoError(t, err)
+			assert.Nil(t, attempt)
+		})
+	}
+}
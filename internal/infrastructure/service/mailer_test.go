@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.log")
+	mailer := NewFileMailer(path)
+
+	err := mailer.Send(context.Background(), service.MailMessage{
+		To:      "user@example.com",
+		Subject: "Reset your password",
+		Body:    "https://app.example.com/reset-password?token=abc",
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "To: user@example.com")
+	assert.Contains(t, string(content), "Subject: Reset your password")
+	assert.Contains(t, string(content), "token=abc")
+}
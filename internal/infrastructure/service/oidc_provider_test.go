@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func newStubProvider(t *testing.T, idp *testutil.StubIdP) service.IdentityProvider {
+	provider, err := NewOIDCProvider(OIDCConfig{
+		Name:         "campus",
+		IssuerURL:    idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://localhost:3000/callback",
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestOIDCProvider_AuthorizationCodeFlow(t *testing.T) {
+	idp := testutil.NewStubIdP(t, "client-id", "client secret")
+	provider := newStubProvider(t, idp)
+	ctx := context.Background()
+	verifier := "verifier-0123456789-0123456789-0123456789"
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", pkceChallenge(verifier))
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, idp.Issuer()+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+	assert.Equal(t, "http://localhost:3000/callback", parsed.Query().Get("redirect_uri"))
+
+	user := testutil.StubIdPUser{Subject: "sub-1", Email: "student@campus.test", EmailVerified: true, Name: "Student"}
+	code, state := idp.Authorize(t, authURL, user)
+	assert.Equal(t, "state-1", state)
+
+	identity, err := provider.Exchange(ctx, code, verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, &service.ExternalIdentity{
+		Provider:      "campus",
+		Subject:       "sub-1",
+		Email:         "student@campus.test",
+		EmailVerified: true,
+		Name:          "Student",
+	}, identity)
+
+	// Codes are single-use
+	_, err = provider.Exchange(ctx, code, verifier, "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_RejectsInvalidExchange(t *testing.T) {
+	idp := testutil.NewStubIdP(t, "client-id", "client-secret")
+	provider := newStubProvider(t, idp)
+	ctx := context.Background()
+	verifier := "verifier-0123456789-0123456789-0123456789"
+	user := testutil.StubIdPUser{Subject: "sub-1", Email: "student@campus.test"}
+
+	authURL, err := provider.AuthCodeURL(ctx, "state", "nonce", pkceChallenge(verifier))
+	require.NoError(t, err)
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		code, _ := idp.Authorize(t, authURL, user)
+		_, err := provider.Exchange(ctx, code, "another-verifier", "nonce")
+		assert.Error(t, err)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		code, _ := idp.Authorize(t, authURL, user)
+		_, err := provider.Exchange(ctx, code, verifier, "other-nonce")
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown client", func(t *testing.T) {
+		other, err := NewOIDCProvider(OIDCConfig{
+			Name:         "campus",
+			IssuerURL:    idp.Issuer(),
+			ClientID:     "other-client",
+			ClientSecret: idp.ClientSecret,
+			RedirectURL:  "http://localhost:3000/callback",
+		})
+		require.NoError(t, err)
+		code, _ := idp.Authorize(t, authURL, user)
+		_, err = other.Exchange(ctx, code, verifier, "nonce")
+		assert.Error(t, err)
+	})
+}
+
+func TestOIDCProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	idp := testutil.NewStubIdP(t, "client-id", "client-secret")
+	provider, err := NewOIDCProvider(OIDCConfig{
+		Name:        "campus",
+		IssuerURL:   idp.Issuer() + "/realms/other",
+		ClientID:    idp.ClientID,
+		RedirectURL: "http://localhost:3000/callback",
+	})
+	require.NoError(t, err)
+
+	_, err = provider.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}
+
+func TestNewIdentityProvidersFromEnv(t *testing.T) {
+	t.Setenv("OIDC_PROVIDERS", "campus")
+	t.Setenv("OIDC_CAMPUS_ISSUER_URL", "https://idp.campus.test")
+	t.Setenv("OIDC_CAMPUS_CLIENT_ID", "client-id")
+	t.Setenv("OIDC_CAMPUS_REDIRECT_URL", "http://localhost:3000/callback")
+
+	providers, err := NewIdentityProvidersFromEnv()
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "campus", providers[0].Name())
+
+	t.Setenv("OIDC_CAMPUS_CLIENT_ID", "")
+	_, err = NewIdentityProvidersFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRateLimitStore_Take(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	// The bucket starts full and allows a burst of limit requests
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(ctx, "ip:10.0.0.1", 3, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+	}
+
+	result, err := store.Take(ctx, "ip:10.0.0.1", 3, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	// One token refills every 20 seconds
+	assert.InDelta(t, 20*time.Second, result.RetryAfter, float64(time.Second))
+	assert.InDelta(t, time.Minute, result.ResetAfter, float64(time.Second))
+
+	// Other keys have their own bucket
+	result, err = store.Take(ctx, "ip:10.0.0.2", 3, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryRateLimitStore_Refill(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, err = store.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	time.Sleep(60 * time.Millisecond)
+
+	result, err = store.Take(ctx, "key", 1, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NewTokenDenylistFromEnv creates the token denylist selected by TOKEN_DENYLIST_STORE.
+// Supported values are "memory" and "database" (default).
+func NewTokenDenylistFromEnv() service.TokenDenylist {
+	if os.Getenv("TOKEN_DENYLIST_STORE") == "memory" {
+		return NewMemoryTokenDenylist()
+	}
+	return NewDBTokenDenylist()
+}
+
+type memoryTokenDenylist struct {
+	mu      sync.RWMutex
+	entries map[string]time.Time
+}
+
+// NewMemoryTokenDenylist creates an in-memory token denylist.
+// Entries are dropped once they expire. Revocations are not shared between
+// instances and are lost on restart.
+func NewMemoryTokenDenylist() service.TokenDenylist {
+	return &memoryTokenDenylist{
+		entries: make(map[string]time.Time),
+	}
+}
+
+func (d *memoryTokenDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for id, exp := range d.entries {
+		if now.After(exp) {
+			delete(d.entries, id)
+		}
+	}
+
+	d.entries[tokenID] = expiresAt
+	return nil
+}
+
+func (d *memoryTokenDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	d.mu.RLock()
+	expiresAt, ok := d.entries[tokenID]
+	d.mu.RUnlock()
+
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+type dbTokenDenylist struct {
+	db *gorm.DB
+}
+
+// NewDBTokenDenylist creates a database-backed token denylist
+func NewDBTokenDenylist() service.TokenDenylist {
+	return &dbTokenDenylist{
+		db: database.DB,
+	}
+}
+
+func (d *dbTokenDenylist) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	now := time.Now()
+
+	// Drop entries whose tokens have expired anyway
+	if err := d.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&entity.RevokedToken{}).Error; err != nil {
+		return err
+	}
+
+	return d.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.RevokedToken{
+			TokenID:   tokenID,
+			ExpiresAt: expiresAt,
+			CreatedAt: now,
+		}).Error
+}
+
+func (d *dbTokenDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var count int64
+	err := d.db.WithContext(ctx).
+		Model(&entity.RevokedToken{}).
+		Where("token_id = ? AND expires_at > ?", tokenID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+	"github.com/your-org/go-backend-starter/internal/testutil"
+)
+
+func TestTokenDenylist(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	denylists := map[string]service.TokenDenylist{
+		"memory":   NewMemoryTokenDenylist(),
+		"database": &dbTokenDenylist{db: db},
+	}
+
+	for name, denylist := range denylists {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			revoked, err := denylist.IsRevoked(ctx, "unknown-jti")
+			require.NoError(t, err)
+			assert.False(t, revoked)
+
+			require.NoError(t, denylist.Revoke(ctx, "active-jti", time.Now().Add(time.Hour)))
+			revoked, err = denylist.IsRevoked(ctx, "active-jti")
+			require.NoError(t, err)
+			assert.True(t, revoked)
+
+			// Revoking twice is not an error
+			require.NoError(t, denylist.Revoke(ctx, "active-jti", time.Now().Add(time.Hour)))
+
+			// Expired entries no longer count as revoked
+			require.NoError(t, denylist.Revoke(ctx, "expired-jti", time.Now().Add(-time.Minute)))
+			revoked, err = denylist.IsRevoked(ctx, "expired-jti")
+			require.NoError(t, err)
+			assert.False(t, revoked)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	// Test vectors from RFC 6238 Appendix B (SHA1, 8 digits)
+	key := []byte("12345678901234567890")
+
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, hotp(key, tt.unix/totpPeriod, 8))
+	}
+}
+
+func TestTOTPService_Validate(t *testing.T) {
+	svc := NewTOTPService("Test App")
+
+	secret, err := svc.GenerateSecret()
+	require.NoError(t, err)
+
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+
+	now := time.Now()
+	currentStep := now.Unix() / totpPeriod
+
+	step, ok := svc.Validate(secret, hotp(key, currentStep, totpDigits), now)
+	assert.True(t, ok)
+	assert.Equal(t, currentStep, step)
+
+	// Previous step is accepted for clock drift
+	_, ok = svc.Validate(secret, hotp(key, currentStep-1, totpDigits), now)
+	assert.True(t, ok)
+
+	// Codes outside the skew window are rejected
+	_, ok = svc.Validate(secret, hotp(key, currentStep-3, totpDigits), now)
+	assert.False(t, ok)
+
+	_, ok = svc.Validate(secret, "abc", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPService_ProvisioningURI(t *testing.T) {
+	svc := NewTOTPService("Test App")
+
+	uri := svc.ProvisioningURI("JBSWY3DPEHPK3PXP", "user@example.com")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Test%20App:user@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Test+App")
+}
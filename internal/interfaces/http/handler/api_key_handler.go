@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// APIKeyHandler handles personal access token requests for the current user
+type APIKeyHandler struct {
+	apiKeyUseCase *usecase.APIKeyUseCase
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyUseCase *usecase.APIKeyUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUseCase: apiKeyUseCase,
+	}
+}
+
+// CreateAPIKey handles API key creation
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.apiKeyUseCase.CreateAPIKey(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Expiry must be in the future")
+		case domainErrors.ErrPermissionDenied:
+			response.ErrorForbidden(c, "API keys can only be granted permissions you hold")
+		case domainErrors.ErrUserNotFound:
+			response.ErrorUnauthorized(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to create API key", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, resp, "API key created successfully. Store the key now, it will not be shown again")
+}
+
+// ListAPIKeys handles listing the current user's API keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	resp, err := h.apiKeyUseCase.ListAPIKeys(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list API keys", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "API keys retrieved successfully")
+}
+
+// GetAPIKey handles getting one of the current user's API keys
+func (h *APIKeyHandler) GetAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid API key ID", err.Error())
+		return
+	}
+
+	resp, err := h.apiKeyUseCase.GetAPIKey(c.Request.Context(), userID.(uuid.UUID), id)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrAPIKeyNotFound:
+			response.ErrorNotFound(c, "API key not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to get API key", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "API key retrieved successfully")
+}
+
+// UpdateAPIKey handles renaming one of the current user's API keys
+func (h *APIKeyHandler) UpdateAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid API key ID", err.Error())
+		return
+	}
+
+	var req dto.UpdateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.apiKeyUseCase.UpdateAPIKey(c.Request.Context(), userID.(uuid.UUID), id, req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrAPIKeyNotFound:
+			response.ErrorNotFound(c, "API key not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to update API key", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "API key updated successfully")
+}
+
+// DeleteAPIKey handles revoking one of the current user's API keys
+func (h *APIKeyHandler) DeleteAPIKey(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid API key ID", err.Error())
+		return
+	}
+
+	err = h.apiKeyUseCase.DeleteAPIKey(c.Request.Context(), userID.(uuid.UUID), id)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrAPIKeyNotFound:
+			response.ErrorNotFound(c, "API key not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to delete API key", err.Error())
+		}
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
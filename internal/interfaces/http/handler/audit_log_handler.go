@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
@@ -18,17 +19,30 @@ func NewAuditLogHandler(useCase *usecase.AuditLogUseCase) *AuditLogHandler {
 	return &AuditLogHandler{useCase: useCase}
 }
 
-// ListAuditLogs lists audit logs with pagination and simple filters
+// ListAuditLogs lists audit logs with pagination and simple filters.
+// Passing cursor or limit switches from page/page_size to cursor pagination.
 func (h *AuditLogHandler) ListAuditLogs(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 	resource := c.Query("resource")
 	action := c.Query("action")
 	actorEmail := c.Query("actor_email")
+	dormitoryID := c.Query("dormitory_id")
 
-	resp, err := h.useCase.ListAuditLogs(c.Request.Context(), page, pageSize, resource, action, actorEmail)
+	var resp interface{}
+	var err error
+	if query, ok := cursorQuery(c); ok {
+		resp, err = h.useCase.ListAuditLogsByCursor(c.Request.Context(), query, resource, action, actorEmail, dormitoryID)
+	} else {
+		resp, err = h.useCase.ListAuditLogs(c.Request.Context(), page, pageSize, resource, action, actorEmail, dormitoryID)
+	}
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list audit logs", err.Error())
+		switch err {
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list audit logs", err.Error())
+		}
 		return
 	}
 
@@ -2,10 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
@@ -14,6 +18,19 @@ type AuthUseCase interface {
 	Register(ctx context.Context, req dto.RegisterRequest) (*dto.AuthResponse, error)
 	Login(ctx context.Context, req dto.LoginRequest) (*dto.AuthResponse, error)
 	RefreshToken(ctx context.Context, req dto.RefreshTokenRequest) (*dto.AuthResponse, error)
+	Logout(ctx context.Context, req dto.LogoutRequest) error
+	LogoutAll(ctx context.Context, userID uuid.UUID, currentTokenID string) error
+	RevokeAccessToken(ctx context.Context, req dto.RevokeTokenRequest) error
+	ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error
+	SetupMFA(ctx context.Context, req dto.MFATokenRequest) (*dto.MFAEnrollResponse, error)
+	VerifyMFA(ctx context.Context, req dto.MFAVerifyRequest) (*dto.AuthResponse, error)
+	EnrollMFA(ctx context.Context, userID uuid.UUID) (*dto.MFAEnrollResponse, error)
+	ConfirmMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) error
+	JWKS() service.JSONWebKeySet
 }
 
 // AuthHandler handles authentication requests
@@ -30,7 +47,8 @@ func NewAuthHandler(authUseCase AuthUseCase) *AuthHandler {
 
 // Register handles user registration
 // @Summary Register a new user
-// @Description Register a new user account
+// @Description Register a new user account. When email verification is required,
+// @Description no tokens are returned until the email is verified.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -38,6 +56,7 @@ func NewAuthHandler(authUseCase AuthUseCase) *AuthHandler {
 // @Success 201 {object} dto.AuthResponse
 // @Failure 400 {object} map[string]string
 // @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /api/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req dto.RegisterRequest
@@ -48,6 +67,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	resp, err := h.authUseCase.Register(c.Request.Context(), req)
 	if err != nil {
+		if respondThrottled(c, err) {
+			return
+		}
 		switch err {
 		case domainErrors.ErrUserAlreadyExists:
 			response.ErrorConflict(c, "User already exists")
@@ -57,12 +79,18 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
+	if resp.EmailVerificationRequired {
+		response.SuccessCreated(c, resp, "User registered successfully; verify your email to sign in")
+		return
+	}
+
 	response.SuccessCreated(c, resp, "User registered successfully")
 }
 
 // Login handles user login
 // @Summary Login user
-// @Description Authenticate user and return tokens
+// @Description Authenticate user and return tokens. When two-factor authentication is required,
+// @Description only an mfa_token is returned, to be completed at /api/auth/mfa/verify.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -70,6 +98,8 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Success 200 {object} dto.AuthResponse
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /api/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -80,15 +110,25 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	resp, err := h.authUseCase.Login(c.Request.Context(), req)
 	if err != nil {
+		if respondThrottled(c, err) {
+			return
+		}
 		switch err {
 		case domainErrors.ErrInvalidCredentials, domainErrors.ErrUserInactive:
 			response.ErrorUnauthorized(c, "Invalid credentials")
+		case domainErrors.ErrEmailNotVerified:
+			response.ErrorForbidden(c, "Email not verified")
 		default:
 			response.ErrorInternalServer(c, "Failed to login", err.Error())
 		}
 		return
 	}
 
+	if resp.MFARequired {
+		response.SuccessOK(c, resp, "Two-factor authentication required")
+		return
+	}
+
 	response.SuccessOK(c, resp, "Login successful")
 }
 
@@ -113,8 +153,11 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	resp, err := h.authUseCase.RefreshToken(c.Request.Context(), req)
 	if err != nil {
 		switch err {
-		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired:
+		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired,
+			domainErrors.ErrTokenNotFound, domainErrors.ErrRefreshTokenReused:
 			response.ErrorUnauthorized(c, "Invalid or expired token")
+		case domainErrors.ErrEmailNotVerified:
+			response.ErrorForbidden(c, "Email not verified")
 		default:
 			response.ErrorInternalServer(c, "Failed to refresh token", err.Error())
 		}
@@ -123,3 +166,399 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	response.SuccessOK(c, resp, "Token refreshed successfully")
 }
+
+// Logout handles logging out the current session
+// @Summary Logout
+// @Description Revoke the refresh token family of the given refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LogoutRequest true "Logout request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req dto.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.authUseCase.Logout(c.Request.Context(), req); err != nil {
+		switch err {
+		case domainErrors.ErrTokenNotFound:
+			response.ErrorUnauthorized(c, "Invalid or expired token")
+		default:
+			response.ErrorInternalServer(c, "Failed to logout", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Logged out successfully")
+}
+
+// LogoutAll handles logging out every session of the current user
+// @Summary Logout from all sessions
+// @Description Revoke all refresh tokens of the authenticated user
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /api/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	if err := h.authUseCase.LogoutAll(c.Request.Context(), userID.(uuid.UUID), c.GetString("token_id")); err != nil {
+		response.ErrorInternalServer(c, "Failed to logout from all sessions", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, nil, "Logged out from all sessions successfully")
+}
+
+// RevokeToken handles revoking an access token by its ID
+// @Summary Revoke access token
+// @Description Denylist an access token (jti) so it is rejected before it expires
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.RevokeTokenRequest true "Revoke token request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/auth/revoke [post]
+func (h *AuthHandler) RevokeToken(c *gin.Context) {
+	var req dto.RevokeTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := h.authUseCase.RevokeAccessToken(c.Request.Context(), req); err != nil {
+		response.ErrorInternalServer(c, "Failed to revoke token", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, nil, "Token revoked successfully")
+}
+
+// ForgotPassword handles password reset link requests
+// @Summary Forgot password
+// @Description Send a password reset link to the email if it is registered
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Forgot password request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /api/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	if err := h.authUseCase.ForgotPassword(c.Request.Context(), req); err != nil {
+		response.ErrorInternalServer(c, "Failed to process password reset request", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, nil, "If the email is registered, a password reset link has been sent")
+}
+
+// ResetPassword handles resetting a password with a reset token
+// @Summary Reset password
+// @Description Set a new password using a password reset token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset password request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /api/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	if err := h.authUseCase.ResetPassword(c.Request.Context(), req); err != nil {
+		switch err {
+		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired, domainErrors.ErrUserNotFound:
+			response.ErrorBadRequest(c, "Invalid or expired token")
+		default:
+			response.ErrorInternalServer(c, "Failed to reset password", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Password reset successfully")
+}
+
+// VerifyEmail handles email verification
+// @Summary Verify email
+// @Description Mark the email address as verified using a verification token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Verify email request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /api/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	if err := h.authUseCase.VerifyEmail(c.Request.Context(), req); err != nil {
+		switch err {
+		case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired, domainErrors.ErrUserNotFound:
+			response.ErrorBadRequest(c, "Invalid or expired token")
+		default:
+			response.ErrorInternalServer(c, "Failed to verify email", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Email verified successfully")
+}
+
+// ResendVerification handles requests for a new email verification link
+// @Summary Resend verification email
+// @Description Send a new email verification link if the email is registered and not verified yet
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResendVerificationRequest true "Resend verification request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /api/auth/resend-verification [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	if err := h.authUseCase.ResendVerification(c.Request.Context(), req); err != nil {
+		response.ErrorInternalServer(c, "Failed to process verification request", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, nil, "If the email is registered and not verified, a verification link has been sent")
+}
+
+// SetupMFA handles TOTP enrollment during login when a role requires MFA
+// @Summary Set up two-factor authentication at login
+// @Description Generate a TOTP secret for a user who must enroll before completing login
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MFATokenRequest true "MFA token from login"
+// @Success 200 {object} dto.MFAEnrollResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/auth/mfa/setup [post]
+func (h *AuthHandler) SetupMFA(c *gin.Context) {
+	var req dto.MFATokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.authUseCase.SetupMFA(c.Request.Context(), req)
+	if err != nil {
+		handleMFAError(c, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Scan the provisioning URI with an authenticator app")
+}
+
+// VerifyMFA handles the second login step
+// @Summary Verify two-factor authentication code
+// @Description Exchange the mfa_token and a TOTP or recovery code for access and refresh tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MFAVerifyRequest true "MFA verify request"
+// @Success 200 {object} dto.AuthResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 423 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Router /api/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.authUseCase.VerifyMFA(c.Request.Context(), req)
+	if err != nil {
+		handleMFAError(c, err, "Failed to verify two-factor authentication code")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Login successful")
+}
+
+// EnrollMFA handles starting TOTP enrollment for the current user
+// @Summary Enroll in two-factor authentication
+// @Description Generate a TOTP secret and provisioning URI; confirm with /api/me/mfa/confirm
+// @Tags auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAEnrollResponse
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/me/mfa/enroll [post]
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	resp, err := h.authUseCase.EnrollMFA(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		handleMFAError(c, err, "Failed to enroll in two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Scan the provisioning URI with an authenticator app")
+}
+
+// ConfirmMFA handles enabling two-factor authentication for the current user
+// @Summary Confirm two-factor authentication
+// @Description Enable two-factor authentication with a TOTP code and return one-time recovery codes
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "TOTP code"
+// @Success 200 {object} dto.MFARecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/me/mfa/confirm [post]
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.authUseCase.ConfirmMFA(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		handleMFAError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Two-factor authentication enabled. Store the recovery codes in a safe place")
+}
+
+// DisableMFA handles turning off two-factor authentication for the current user
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication after verifying a TOTP or recovery code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "TOTP or recovery code"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /api/me/mfa [delete]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	if err := h.authUseCase.DisableMFA(c.Request.Context(), userID.(uuid.UUID), req); err != nil {
+		handleMFAError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	response.SuccessOK(c, nil, "Two-factor authentication disabled")
+}
+
+// handleMFAError maps two-factor authentication errors to responses
+func handleMFAError(c *gin.Context, err error, fallbackMessage string) {
+	if respondThrottled(c, err) {
+		return
+	}
+	switch err {
+	case domainErrors.ErrInvalidToken, domainErrors.ErrTokenExpired:
+		response.ErrorUnauthorized(c, "Invalid or expired MFA token")
+	case domainErrors.ErrInvalidMFACode:
+		response.ErrorUnauthorized(c, "Invalid two-factor authentication code")
+	case domainErrors.ErrUserInactive, domainErrors.ErrUserNotFound:
+		response.ErrorUnauthorized(c, "Invalid credentials")
+	case domainErrors.ErrMFANotEnabled:
+		response.ErrorBadRequest(c, "Two-factor authentication is not enabled")
+	case domainErrors.ErrMFAAlreadyEnabled:
+		response.ErrorConflict(c, "Two-factor authentication is already enabled")
+	case domainErrors.ErrMFAMandatory:
+		response.ErrorForbidden(c, "Two-factor authentication is required by your role")
+	default:
+		response.ErrorInternalServer(c, fallbackMessage, err.Error())
+	}
+}
+
+// respondThrottled writes a 423 or 429 response for lockout errors and reports whether it did
+func respondThrottled(c *gin.Context, err error) bool {
+	var retryErr *domainErrors.RetryAfterError
+	if !errors.As(err, &retryErr) {
+		return false
+	}
+
+	if retryErr.Err == domainErrors.ErrAccountLocked {
+		response.ErrorLocked(c, "Account temporarily locked due to too many failed attempts", retryErr.RetryAfter)
+	} else {
+		response.ErrorTooManyRequests(c, "Too many failed attempts, try again later", retryErr.RetryAfter)
+	}
+	return true
+}
+
+// JWKS serves the public keys used to verify tokens
+// @Summary JSON Web Key Set
+// @Description Public keys for verifying access tokens (empty for HS256)
+// @Tags auth
+// @Produce json
+// @Success 200 {object} service.JSONWebKeySet
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	// Served as a plain JWK Set (not wrapped) so standard JWT libraries can consume it
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.authUseCase.JWKS())
+}
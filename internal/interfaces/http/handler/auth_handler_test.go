@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/handler/mocks"
 	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 func setupRouter() *gin.Engine {
@@ -40,7 +42,7 @@ func TestAuthHandler_Register(t *testing.T) {
 				})).Return(&dto.AuthResponse{
 					AccessToken:  "access_token",
 					RefreshToken: "refresh_token",
-					User: dto.UserDTO{
+					User: &dto.UserDTO{
 						ID:    "user-id",
 						Email: "newuser@example.com",
 						Name:  "New User",
@@ -98,10 +100,11 @@ func TestAuthHandler_Register(t *testing.T) {
 
 func TestAuthHandler_Login(t *testing.T) {
 	tests := []struct {
-		name           string
-		requestBody    interface{}
-		setupMocks     func(*mocks.MockAuthUseCase)
-		expectedStatus int
+		name               string
+		requestBody        interface{}
+		setupMocks         func(*mocks.MockAuthUseCase)
+		expectedStatus     int
+		expectedRetryAfter string
 	}{
 		{
 			name: "success - login with valid credentials",
@@ -115,7 +118,7 @@ func TestAuthHandler_Login(t *testing.T) {
 				})).Return(&dto.AuthResponse{
 					AccessToken:  "access_token",
 					RefreshToken: "refresh_token",
-					User: dto.UserDTO{
+					User: &dto.UserDTO{
 						ID:    "user-id",
 						Email: "user@example.com",
 					},
@@ -134,6 +137,36 @@ func TestAuthHandler_Login(t *testing.T) {
 			},
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "failure - account locked",
+			requestBody: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(mockUseCase *mocks.MockAuthUseCase) {
+				mockUseCase.On("Login", mock.Anything, mock.Anything).Return(nil, &domainErrors.RetryAfterError{
+					Err:        domainErrors.ErrAccountLocked,
+					RetryAfter: 90 * time.Second,
+				})
+			},
+			expectedStatus:     http.StatusLocked,
+			expectedRetryAfter: "90",
+		},
+		{
+			name: "failure - too many attempts from client",
+			requestBody: dto.LoginRequest{
+				Email:    "user@example.com",
+				Password: "password123",
+			},
+			setupMocks: func(mockUseCase *mocks.MockAuthUseCase) {
+				mockUseCase.On("Login", mock.Anything, mock.Anything).Return(nil, &domainErrors.RetryAfterError{
+					Err:        domainErrors.ErrTooManyAttempts,
+					RetryAfter: 1500 * time.Millisecond,
+				})
+			},
+			expectedStatus:     http.StatusTooManyRequests,
+			expectedRetryAfter: "2",
+		},
 		{
 			name: "failure - invalid request body",
 			requestBody: map[string]interface{}{
@@ -164,6 +197,7 @@ func TestAuthHandler_Login(t *testing.T) {
 			router.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
 			mockUseCase.AssertExpectations(t)
 		})
 	}
@@ -187,7 +221,7 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 				})).Return(&dto.AuthResponse{
 					AccessToken:  "new_access_token",
 					RefreshToken: "new_refresh_token",
-					User: dto.UserDTO{
+					User: &dto.UserDTO{
 						ID: "user-id",
 					},
 				}, nil)
@@ -238,3 +272,98 @@ func TestAuthHandler_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_VerifyMFA(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		setupMocks     func(*mocks.MockAuthUseCase)
+		expectedStatus int
+	}{
+		{
+			name: "success - valid code",
+			requestBody: dto.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "123456",
+			},
+			setupMocks: func(mockUseCase *mocks.MockAuthUseCase) {
+				mockUseCase.On("VerifyMFA", mock.Anything, dto.MFAVerifyRequest{
+					MFAToken: "mfa_token",
+					Code:     "123456",
+				}).Return(&dto.AuthResponse{
+					AccessToken:  "access_token",
+					RefreshToken: "refresh_token",
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "failure - invalid code",
+			requestBody: dto.MFAVerifyRequest{
+				MFAToken: "mfa_token",
+				Code:     "000000",
+			},
+			setupMocks: func(mockUseCase *mocks.MockAuthUseCase) {
+				mockUseCase.On("VerifyMFA", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrInvalidMFACode)
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "failure - missing code",
+			requestBody: map[string]interface{}{
+				"mfa_token": "mfa_token",
+			},
+			setupMocks: func(mockUseCase *mocks.MockAuthUseCase) {
+				// No mock call expected
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUseCase := new(mocks.MockAuthUseCase)
+			tt.setupMocks(mockUseCase)
+
+			handler := NewAuthHandler(mockUseCase)
+
+			router := setupRouter()
+			router.POST("/mfa/verify", handler.VerifyMFA)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/mfa/verify", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockUseCase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_JWKS(t *testing.T) {
+	mockUseCase := new(mocks.MockAuthUseCase)
+	mockUseCase.On("JWKS").Return(service.JSONWebKeySet{
+		Keys: []service.JSONWebKey{{Kty: "OKP", Kid: "key-1", Alg: "EdDSA", Crv: "Ed25519", X: "abc"}},
+	})
+
+	handler := NewAuthHandler(mockUseCase)
+
+	router := setupRouter()
+	router.GET("/.well-known/jwks.json", handler.JWKS)
+
+	req, _ := http.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The key set is served unwrapped
+	var jwks service.JSONWebKeySet
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+	assert.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "key-1", jwks.Keys[0].Kid)
+	mockUseCase.AssertExpectations(t)
+}
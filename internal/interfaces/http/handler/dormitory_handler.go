@@ -163,27 +163,262 @@ func (h *DormitoryHandler) DeleteDormitory(c *gin.Context) {
 	response.SuccessNoContent(c)
 }
 
-// ListDormitories handles listing dormitories with pagination
+// ListDormitories handles listing dormitories with pagination, search, filters and sorting
 // @Summary List dormitories
-// @Description Get paginated list of dormitories
+// @Description Get paginated list of dormitories, optionally searched, filtered and sorted
 // @Tags dormitories
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
+// @Param q query string false "Search by name or description"
+// @Param is_active query bool false "Filter by active flag"
+// @Param sort query string false "Sort field (name, is_active, created_at, updated_at), prefix with - for descending" default(-created_at)
+// @Param cursor query string false "Cursor from next_cursor; switches to cursor pagination"
+// @Param limit query int false "Page size in cursor pagination; switches to cursor pagination" default(10)
+// @Param count query bool false "Include the total in cursor pagination"
+// @Param deleted query string false "Set to \"only\" to list deleted dormitories instead"
 // @Success 200 {object} dto.ListDormitoriesResponse
+// @Success 200 {object} dto.CursorDormitoriesResponse
 // @Failure 400 {object} map[string]string
 // @Router /api/dormitories [get]
 func (h *DormitoryHandler) ListDormitories(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
-	resp, err := h.dormitoryUseCase.ListDormitories(c.Request.Context(), page, pageSize)
+	isActive, err := optionalBoolQuery(c, "is_active")
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list dormitories", err.Error())
+		response.ErrorBadRequest(c, "Invalid is_active filter", err.Error())
+		return
+	}
+	filter := dto.ListDormitoriesFilter{
+		Search:   c.Query("q"),
+		IsActive: isActive,
+		Sort:     c.Query("sort"),
+	}
+
+	var resp interface{}
+	switch c.Query("deleted") {
+	case "":
+		if query, ok := cursorQuery(c); ok {
+			resp, err = h.dormitoryUseCase.ListDormitoriesByCursor(c.Request.Context(), query, filter)
+		} else {
+			resp, err = h.dormitoryUseCase.ListDormitories(c.Request.Context(), page, pageSize, filter)
+		}
+	case "only":
+		resp, err = h.dormitoryUseCase.ListDeletedDormitories(c.Request.Context(), page, pageSize)
+	default:
+		response.ErrorBadRequest(c, "Invalid deleted filter", "deleted must be \"only\"")
+		return
+	}
+	if err != nil {
+		switch err {
+		case domainErrors.ErrInvalidSortField:
+			response.ErrorBadRequest(c, "Invalid sort field")
+		case domainErrors.ErrCursorSortNotUsable:
+			response.ErrorBadRequest(c, "Cursor pagination only supports sort=-created_at")
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list dormitories", err.Error())
+		}
 		return
 	}
 
 	response.SuccessOK(c, resp, "Dormitories retrieved successfully")
 }
+
+// RestoreDormitory handles restoring a soft-deleted dormitory
+// @Summary Restore dormitory
+// @Description Restore a soft-deleted dormitory with its members
+// @Tags dormitories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dormitory ID"
+// @Success 200 {object} dto.DormitoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/dormitories/{id}/restore [post]
+func (h *DormitoryHandler) RestoreDormitory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	resp, err := h.dormitoryUseCase.RestoreDormitory(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Deleted dormitory not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to restore dormitory", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Dormitory restored successfully")
+}
+
+// AddMember handles adding a user to a dormitory
+// @Summary Add dormitory member
+// @Description Give a user access to a dormitory
+// @Tags dormitories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dormitory ID"
+// @Param request body dto.AddDormitoryMemberRequest true "Add member request"
+// @Success 201 {object} dto.DormitoryMemberResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/dormitories/{id}/members [post]
+func (h *DormitoryHandler) AddMember(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	var req dto.AddDormitoryMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.dormitoryUseCase.AddMember(c.Request.Context(), id, req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Dormitory not found")
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		case domainErrors.ErrDormitoryMemberExists:
+			response.ErrorConflict(c, "User is already a member of this dormitory")
+		default:
+			response.ErrorInternalServer(c, "Failed to add dormitory member", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Dormitory member added successfully")
+}
+
+// RemoveMember handles removing a user from a dormitory
+// @Summary Remove dormitory member
+// @Description Revoke a user's access to a dormitory
+// @Tags dormitories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dormitory ID"
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/dormitories/{id}/members/{user_id} [delete]
+func (h *DormitoryHandler) RemoveMember(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	err = h.dormitoryUseCase.RemoveMember(c.Request.Context(), id, userID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Dormitory not found")
+		case domainErrors.ErrDormitoryMemberNotFound:
+			response.ErrorNotFound(c, "User is not a member of this dormitory")
+		default:
+			response.ErrorInternalServer(c, "Failed to remove dormitory member", err.Error())
+		}
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
+
+// ListMembers handles listing the members of a dormitory with pagination
+// @Summary List dormitory members
+// @Description Get paginated list of users assigned to a dormitory
+// @Tags dormitories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Dormitory ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Success 200 {object} dto.ListDormitoryMembersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/dormitories/{id}/members [get]
+func (h *DormitoryHandler) ListMembers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	resp, err := h.dormitoryUseCase.ListMembers(c.Request.Context(), id, page, pageSize)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Dormitory not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to list dormitory members", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Dormitory members retrieved successfully")
+}
+
+// ListUserDormitories handles listing the dormitories a user is assigned to
+// @Summary List user dormitories
+// @Description Get the dormitories a user can access through membership
+// @Tags dormitories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {array} dto.DormitoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /api/users/{id}/dormitories [get]
+func (h *DormitoryHandler) ListUserDormitories(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	resp, err := h.dormitoryUseCase.ListUserDormitories(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to list user dormitories", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "User dormitories retrieved successfully")
+}
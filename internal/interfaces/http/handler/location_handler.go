@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
@@ -30,9 +31,20 @@ func (h *LocationHandler) ListProvinces(c *gin.Context) {
 	page, pageSize := parsePagination(c)
 	search := c.Query("search")
 
-	resp, err := h.useCase.ListProvinces(c.Request.Context(), page, pageSize, search)
+	var resp interface{}
+	var err error
+	if query, ok := cursorQuery(c); ok {
+		resp, err = h.useCase.ListProvincesByCursor(c.Request.Context(), query, search)
+	} else {
+		resp, err = h.useCase.ListProvinces(c.Request.Context(), page, pageSize, search)
+	}
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list provinces", err.Error())
+		switch err {
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list provinces", err.Error())
+		}
 		return
 	}
 
@@ -68,9 +80,20 @@ func (h *LocationHandler) ListRegencies(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.useCase.ListRegencies(c.Request.Context(), page, pageSize, provinceID, search)
+	var resp interface{}
+	var err error
+	if query, ok := cursorQuery(c); ok {
+		resp, err = h.useCase.ListRegenciesByCursor(c.Request.Context(), query, provinceID, search)
+	} else {
+		resp, err = h.useCase.ListRegencies(c.Request.Context(), page, pageSize, provinceID, search)
+	}
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list regencies", err.Error())
+		switch err {
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list regencies", err.Error())
+		}
 		return
 	}
 
@@ -106,9 +129,20 @@ func (h *LocationHandler) ListDistricts(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.useCase.ListDistricts(c.Request.Context(), page, pageSize, regencyID, search)
+	var resp interface{}
+	var err error
+	if query, ok := cursorQuery(c); ok {
+		resp, err = h.useCase.ListDistrictsByCursor(c.Request.Context(), query, regencyID, search)
+	} else {
+		resp, err = h.useCase.ListDistricts(c.Request.Context(), page, pageSize, regencyID, search)
+	}
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list districts", err.Error())
+		switch err {
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list districts", err.Error())
+		}
 		return
 	}
 
@@ -144,9 +178,20 @@ func (h *LocationHandler) ListVillages(c *gin.Context) {
 		}
 	}
 
-	resp, err := h.useCase.ListVillages(c.Request.Context(), page, pageSize, districtID, search)
+	var resp interface{}
+	var err error
+	if query, ok := cursorQuery(c); ok {
+		resp, err = h.useCase.ListVillagesByCursor(c.Request.Context(), query, districtID, search)
+	} else {
+		resp, err = h.useCase.ListVillages(c.Request.Context(), page, pageSize, districtID, search)
+	}
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list villages", err.Error())
+		switch err {
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list villages", err.Error())
+		}
 		return
 	}
 
@@ -3,8 +3,10 @@ package mocks
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 )
 
 // MockAuthUseCase is a mock implementation of AuthUseCase
@@ -35,3 +37,80 @@ func (m *MockAuthUseCase) RefreshToken(ctx context.Context, req dto.RefreshToken
 	}
 	return args.Get(0).(*dto.AuthResponse), args.Error(1)
 }
+
+func (m *MockAuthUseCase) Logout(ctx context.Context, req dto.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) LogoutAll(ctx context.Context, userID uuid.UUID, currentTokenID string) error {
+	args := m.Called(ctx, userID, currentTokenID)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) RevokeAccessToken(ctx context.Context, req dto.RevokeTokenRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) SetupMFA(ctx context.Context, req dto.MFATokenRequest) (*dto.MFAEnrollResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MFAEnrollResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) VerifyMFA(ctx context.Context, req dto.MFAVerifyRequest) (*dto.AuthResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AuthResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) EnrollMFA(ctx context.Context, userID uuid.UUID) (*dto.MFAEnrollResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MFAEnrollResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) ConfirmMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.MFARecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) DisableMFA(ctx context.Context, userID uuid.UUID, req dto.MFACodeRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) JWKS() service.JSONWebKeySet {
+	args := m.Called()
+	return args.Get(0).(service.JSONWebKeySet)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// OIDCHandler handles login through external OpenID Connect identity providers
+type OIDCHandler struct {
+	oidcUseCase *usecase.OIDCUseCase
+}
+
+// NewOIDCHandler creates a new OIDC handler
+func NewOIDCHandler(oidcUseCase *usecase.OIDCUseCase) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUseCase: oidcUseCase,
+	}
+}
+
+// ListProviders handles listing the configured identity providers
+// @Summary List identity providers
+// @Description List the external identity providers available for login
+// @Tags auth
+// @Produce json
+// @Success 200 {object} dto.OIDCProvidersResponse
+// @Router /api/auth/oidc/providers [get]
+func (h *OIDCHandler) ListProviders(c *gin.Context) {
+	response.SuccessOK(c, h.oidcUseCase.ListProviders(), "Identity providers retrieved successfully")
+}
+
+// Authorize handles starting a login at an identity provider
+// @Summary Start external login
+// @Description Create an authorization URL (authorization code flow with PKCE) for the identity provider
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Success 200 {object} dto.OIDCAuthorizeResponse
+// @Failure 404 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Router /api/auth/oidc/{provider}/authorize [get]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	resp, err := h.oidcUseCase.Authorize(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		if err == domainErrors.ErrExternalAuthFailed {
+			response.Error(c, http.StatusBadGateway, "Identity provider unavailable")
+			return
+		}
+		handleOIDCError(c, err, "Failed to start external login")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Redirect the user to the authorization URL")
+}
+
+// Callback handles completing a login with the code returned by the identity provider
+// @Summary Complete external login
+// @Description Exchange the authorization code and state for access & refresh tokens
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param request body dto.OIDCCallbackRequest true "Authorization code and state"
+// @Success 200 {object} dto.AuthResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/auth/oidc/{provider}/callback [post]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.oidcUseCase.Callback(c.Request.Context(), c.Param("provider"), req)
+	if err != nil {
+		handleOIDCError(c, err, "Failed to complete external login")
+		return
+	}
+
+	if resp.MFARequired {
+		response.SuccessOK(c, resp, "Two-factor authentication required")
+		return
+	}
+
+	response.SuccessOK(c, resp, "Login successful")
+}
+
+// handleOIDCError maps external login errors to HTTP responses
+func handleOIDCError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case domainErrors.ErrIdentityProviderNotFound:
+		response.ErrorNotFound(c, "Identity provider not found")
+	case domainErrors.ErrInvalidToken:
+		response.ErrorUnauthorized(c, "Invalid or expired login state")
+	case domainErrors.ErrExternalAuthFailed:
+		response.ErrorUnauthorized(c, "External authentication failed")
+	case domainErrors.ErrExternalEmailNotVerified:
+		response.ErrorForbidden(c, "The identity provider has not verified this email")
+	case domainErrors.ErrEmailNotVerified:
+		response.ErrorForbidden(c, "Email not verified")
+	case domainErrors.ErrUserAlreadyExists:
+		response.ErrorConflict(c, "An account with this email already exists; sign in and verify your email first")
+	case domainErrors.ErrUserInactive:
+		response.ErrorUnauthorized(c, "Invalid credentials")
+	default:
+		response.ErrorInternalServer(c, fallback, err.Error())
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// PermissionHandler handles read-only permission requests
+// PermissionHandler handles permission management requests
 type PermissionHandler struct {
 	permissionUseCase *usecase.PermissionUseCase
 }
@@ -33,3 +36,98 @@ func (h *PermissionHandler) ListPermissions(c *gin.Context) {
 
 	response.SuccessOK(c, resp, "Permissions retrieved successfully")
 }
+
+// CreatePermission handles permission creation
+func (h *PermissionHandler) CreatePermission(c *gin.Context) {
+	var req dto.CreatePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.permissionUseCase.CreatePermission(c.Request.Context(), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrInvalidPermissionName, domainErrors.ErrPermissionSlugMismatch:
+			response.ErrorBadRequest(c, "Invalid permission", err.Error())
+		case domainErrors.ErrPermissionAlreadyExists:
+			response.ErrorConflict(c, "Permission already exists")
+		default:
+			response.ErrorInternalServer(c, "Failed to create permission", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Permission created successfully")
+}
+
+// UpdatePermission handles permission update
+func (h *PermissionHandler) UpdatePermission(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid permission ID", err.Error())
+		return
+	}
+
+	var req dto.UpdatePermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.permissionUseCase.UpdatePermission(c.Request.Context(), id, req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrPermissionNotFound:
+			response.ErrorNotFound(c, "Permission not found")
+		case domainErrors.ErrInvalidPermissionName, domainErrors.ErrPermissionSlugMismatch:
+			response.ErrorBadRequest(c, "Invalid permission", err.Error())
+		case domainErrors.ErrPermissionAlreadyExists:
+			response.ErrorConflict(c, "Permission already exists")
+		case domainErrors.ErrPermissionInUse:
+			response.ErrorForbidden(c, "Cannot rename permission assigned to a protected role")
+		default:
+			response.ErrorInternalServer(c, "Failed to update permission", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Permission updated successfully")
+}
+
+// DeletePermission handles permission deletion
+func (h *PermissionHandler) DeletePermission(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid permission ID", err.Error())
+		return
+	}
+
+	err = h.permissionUseCase.DeletePermission(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrPermissionNotFound:
+			response.ErrorNotFound(c, "Permission not found")
+		case domainErrors.ErrPermissionInUse:
+			response.ErrorForbidden(c, "Cannot delete permission assigned to a protected role")
+		default:
+			response.ErrorInternalServer(c, "Failed to delete permission", err.Error())
+		}
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
+
+// GetPermissionCatalog handles listing which endpoints each permission unlocks
+func (h *PermissionHandler) GetPermissionCatalog(c *gin.Context) {
+	resp, err := h.permissionUseCase.GetPermissionCatalog(c.Request.Context())
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to get permission catalog", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Permission catalog retrieved successfully")
+}
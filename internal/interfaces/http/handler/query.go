@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+)
+
+// cursorQuery reads cursor pagination parameters. It reports false when neither
+// cursor nor limit is given, in which case the list uses page/page_size instead.
+func cursorQuery(c *gin.Context) (dto.CursorQuery, bool) {
+	cursor, hasCursor := c.GetQuery("cursor")
+	limitParam, hasLimit := c.GetQuery("limit")
+	if !hasCursor && !hasLimit {
+		return dto.CursorQuery{}, false
+	}
+
+	limit, _ := strconv.Atoi(limitParam)
+	withCount, _ := strconv.ParseBool(c.Query("count"))
+	return dto.CursorQuery{Cursor: cursor, Limit: limit, WithCount: withCount}, true
+}
+
+// optionalBoolQuery parses a boolean query parameter, returning nil when it is absent
+func optionalBoolQuery(c *gin.Context, key string) (*bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
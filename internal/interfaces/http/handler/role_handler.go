@@ -36,6 +36,8 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 		switch err {
 		case domainErrors.ErrRoleAlreadyExists:
 			response.ErrorConflict(c, "Role already exists")
+		case domainErrors.ErrParentRoleNotFound, domainErrors.ErrRoleHierarchyCycle:
+			response.ErrorBadRequest(c, "Invalid parent role", err.Error())
 		default:
 			response.ErrorInternalServer(c, "Failed to create role", err.Error())
 		}
@@ -90,6 +92,10 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 			response.ErrorNotFound(c, "Role not found")
 		case domainErrors.ErrRoleAlreadyExists:
 			response.ErrorConflict(c, "Slug already taken")
+		case domainErrors.ErrParentRoleNotFound, domainErrors.ErrRoleHierarchyCycle:
+			response.ErrorBadRequest(c, "Invalid parent role", err.Error())
+		case domainErrors.ErrProtectedRole:
+			response.ErrorForbidden(c, "Cannot change parent of protected role")
 		default:
 			response.ErrorInternalServer(c, "Failed to update role", err.Error())
 		}
@@ -215,3 +221,36 @@ func (h *RoleHandler) RemovePermission(c *gin.Context) {
 
 	response.SuccessOK(c, nil, "Permission removed successfully")
 }
+
+// SyncPermissions handles replacing the permissions assigned directly to a role
+func (h *RoleHandler) SyncPermissions(c *gin.Context) {
+	roleIDStr := c.Param("id")
+	roleID, err := uuid.Parse(roleIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid role ID", err.Error())
+		return
+	}
+
+	var req dto.SyncRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.roleUseCase.SyncPermissions(c.Request.Context(), roleID, req.PermissionIDs)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrRoleNotFound:
+			response.ErrorNotFound(c, "Role not found")
+		case domainErrors.ErrPermissionNotFound:
+			response.ErrorNotFound(c, "Permission not found")
+		case domainErrors.ErrProtectedRole:
+			response.ErrorForbidden(c, "Cannot modify permissions of protected role")
+		default:
+			response.ErrorInternalServer(c, "Failed to sync permissions", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Permissions synced successfully")
+}
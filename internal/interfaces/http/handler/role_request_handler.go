@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/dto"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// RoleRequestHandler handles role request workflow requests
+type RoleRequestHandler struct {
+	roleRequestUseCase *usecase.RoleRequestUseCase
+}
+
+// NewRoleRequestHandler creates a new role request handler
+func NewRoleRequestHandler(roleRequestUseCase *usecase.RoleRequestUseCase) *RoleRequestHandler {
+	return &RoleRequestHandler{
+		roleRequestUseCase: roleRequestUseCase,
+	}
+}
+
+// RequestRole handles the current user requesting a role
+func (h *RoleRequestHandler) RequestRole(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	var req dto.RequestRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := h.roleRequestUseCase.RequestRole(c.Request.Context(), userID.(uuid.UUID), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrRoleNotFound:
+			response.ErrorNotFound(c, "Role not found")
+		case domainErrors.ErrRoleRequestExists:
+			response.ErrorConflict(c, "A pending request for this role already exists")
+		case domainErrors.ErrRoleAlreadyHeld:
+			response.ErrorConflict(c, "You already hold this role permanently")
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Expiry must be in the future")
+		default:
+			response.ErrorInternalServer(c, "Failed to request role", err.Error())
+		}
+		return
+	}
+
+	response.SuccessCreated(c, resp, "Role requested successfully")
+}
+
+// ListMyRoleRequests handles listing the current user's role requests
+func (h *RoleRequestHandler) ListMyRoleRequests(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	resp, err := h.roleRequestUseCase.ListMyRoleRequests(c.Request.Context(), userID.(uuid.UUID))
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list role requests", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Role requests retrieved successfully")
+}
+
+// ListRoleRequests handles listing role requests of all users
+func (h *RoleRequestHandler) ListRoleRequests(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	resp, err := h.roleRequestUseCase.ListRoleRequests(c.Request.Context(), c.Query("status"), page, pageSize)
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list role requests", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Role requests retrieved successfully")
+}
+
+// ApproveRoleRequest handles approving a pending role request
+func (h *RoleRequestHandler) ApproveRoleRequest(c *gin.Context) {
+	h.review(c, h.roleRequestUseCase.ApproveRoleRequest, "Role request approved successfully")
+}
+
+// DenyRoleRequest handles denying a pending role request
+func (h *RoleRequestHandler) DenyRoleRequest(c *gin.Context) {
+	h.review(c, h.roleRequestUseCase.DenyRoleRequest, "Role request denied successfully")
+}
+
+type reviewRoleRequestFunc func(ctx context.Context, id, reviewerID uuid.UUID, req dto.ReviewRoleRequestRequest) (*dto.RoleRequestResponse, error)
+
+func (h *RoleRequestHandler) review(c *gin.Context, decide reviewRoleRequestFunc, message string) {
+	reviewerID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid role request ID", err.Error())
+		return
+	}
+
+	// The body is optional
+	var req dto.ReviewRoleRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.ErrorValidation(c, err)
+		return
+	}
+
+	resp, err := decide(c.Request.Context(), id, reviewerID.(uuid.UUID), req)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrRoleRequestNotFound:
+			response.ErrorNotFound(c, "Role request not found")
+		case domainErrors.ErrRoleRequestNotPending:
+			response.ErrorConflict(c, "Role request has already been reviewed")
+		case domainErrors.ErrOwnRoleRequest:
+			response.ErrorForbidden(c, "Cannot review your own role request")
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Expiry must be in the future")
+		default:
+			response.ErrorInternalServer(c, "Failed to review role request", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, message)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/your-org/go-backend-starter/internal/application/usecase"
+	domainErrors "github.com/your-org/go-backend-starter/internal/domain/errors"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// SessionHandler handles login session requests
+type SessionHandler struct {
+	sessionUseCase *usecase.SessionUseCase
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(sessionUseCase *usecase.SessionUseCase) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUseCase,
+	}
+}
+
+// ListMySessions handles listing the current user's active sessions
+func (h *SessionHandler) ListMySessions(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	h.listSessions(c, userID.(uuid.UUID))
+}
+
+// RevokeMySession handles revoking one of the current user's sessions
+func (h *SessionHandler) RevokeMySession(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		response.ErrorUnauthorized(c, "User not authenticated")
+		return
+	}
+
+	h.revokeSession(c, userID.(uuid.UUID), c.Param("id"))
+}
+
+// ListUserSessions handles listing the active sessions of any user
+func (h *SessionHandler) ListUserSessions(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	h.listSessions(c, userID)
+}
+
+// RevokeUserSession handles revoking a session of any user
+func (h *SessionHandler) RevokeUserSession(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	h.revokeSession(c, userID, c.Param("session_id"))
+}
+
+func (h *SessionHandler) listSessions(c *gin.Context, userID uuid.UUID) {
+	resp, err := h.sessionUseCase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		response.ErrorInternalServer(c, "Failed to list sessions", err.Error())
+		return
+	}
+
+	response.SuccessOK(c, resp, "Sessions retrieved successfully")
+}
+
+func (h *SessionHandler) revokeSession(c *gin.Context, userID uuid.UUID, sessionIDStr string) {
+	sessionID, err := uuid.Parse(sessionIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid session ID", err.Error())
+		return
+	}
+
+	err = h.sessionUseCase.RevokeSession(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrSessionNotFound:
+			response.ErrorNotFound(c, "Session not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to revoke session", err.Error())
+		}
+		return
+	}
+
+	response.SuccessNoContent(c)
+}
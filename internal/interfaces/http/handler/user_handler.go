@@ -2,7 +2,6 @@ package handler
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -42,15 +41,9 @@ func (h *UserHandler) Me(c *gin.Context) {
 
 	roles := make([]string, 0, len(userEntity.Roles))
 	permissions := make([]string, 0)
-	isAdminAllDorms := false
 	for _, r := range userEntity.Roles {
 		roles = append(roles, r.Name)
-		// Use slug/name case-insensitively to detect admin and super_admin
-		if strings.EqualFold(r.Slug, "admin") || strings.EqualFold(r.Slug, "super_admin") ||
-			strings.EqualFold(r.Name, "admin") || strings.EqualFold(r.Name, "super_admin") {
-			isAdminAllDorms = true
-		}
-		for _, p := range r.Permissions {
+		for _, p := range r.EffectivePermissions() {
 			permissions = append(permissions, p.Name)
 		}
 	}
@@ -62,7 +55,7 @@ func (h *UserHandler) Me(c *gin.Context) {
 			Name: d.Name,
 		})
 	}
-	if isAdminAllDorms {
+	if userEntity.CanAccessAllDormitories() {
 		dorms = append(dorms, dto.UserDormitorySummary{
 			ID:   "*",
 			Name: "All dormitories",
@@ -229,31 +222,119 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	response.SuccessNoContent(c)
 }
 
-// ListUsers handles listing users with pagination
+// ListUsers handles listing users with pagination, search, filters and sorting
 // @Summary List users
-// @Description Get paginated list of users
+// @Description Get paginated list of users, optionally searched, filtered and sorted
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Page size" default(10)
+// @Param q query string false "Search by name or email"
+// @Param role query string false "Filter by role slug"
+// @Param is_active query bool false "Filter by active flag"
+// @Param dormitory_id query string false "Filter by dormitory membership"
+// @Param sort query string false "Sort field (name, email, is_active, created_at, updated_at), prefix with - for descending" default(-created_at)
+// @Param cursor query string false "Cursor from next_cursor; switches to cursor pagination"
+// @Param limit query int false "Page size in cursor pagination; switches to cursor pagination" default(10)
+// @Param count query bool false "Include the total in cursor pagination"
+// @Param deleted query string false "Set to \"only\" to list deleted users instead"
 // @Success 200 {object} dto.ListUsersResponse
+// @Success 200 {object} dto.CursorUsersResponse
 // @Failure 400 {object} map[string]string
 // @Router /api/users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
 
-	resp, err := h.userUseCase.ListUsers(c.Request.Context(), page, pageSize)
+	filter := dto.ListUsersFilter{
+		Search: c.Query("q"),
+		Role:   c.Query("role"),
+		Sort:   c.Query("sort"),
+	}
+	isActive, err := optionalBoolQuery(c, "is_active")
 	if err != nil {
-		response.ErrorInternalServer(c, "Failed to list users", err.Error())
+		response.ErrorBadRequest(c, "Invalid is_active filter", err.Error())
+		return
+	}
+	filter.IsActive = isActive
+	if dormitoryID := c.Query("dormitory_id"); dormitoryID != "" {
+		id, err := uuid.Parse(dormitoryID)
+		if err != nil {
+			response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+			return
+		}
+		filter.DormitoryID = &id
+	}
+
+	var resp interface{}
+	switch c.Query("deleted") {
+	case "":
+		if query, ok := cursorQuery(c); ok {
+			resp, err = h.userUseCase.ListUsersByCursor(c.Request.Context(), query, filter)
+		} else {
+			resp, err = h.userUseCase.ListUsers(c.Request.Context(), page, pageSize, filter)
+		}
+	case "only":
+		resp, err = h.userUseCase.ListDeletedUsers(c.Request.Context(), page, pageSize)
+	default:
+		response.ErrorBadRequest(c, "Invalid deleted filter", "deleted must be \"only\"")
+		return
+	}
+	if err != nil {
+		switch err {
+		case domainErrors.ErrInvalidSortField:
+			response.ErrorBadRequest(c, "Invalid sort field")
+		case domainErrors.ErrCursorSortNotUsable:
+			response.ErrorBadRequest(c, "Cursor pagination only supports sort=-created_at")
+		case domainErrors.ErrInvalidCursor:
+			response.ErrorBadRequest(c, "Invalid cursor")
+		default:
+			response.ErrorInternalServer(c, "Failed to list users", err.Error())
+		}
 		return
 	}
 
 	response.SuccessOK(c, resp, "Users retrieved successfully")
 }
 
+// RestoreUser handles restoring a soft-deleted user
+// @Summary Restore user
+// @Description Restore a soft-deleted user with their roles and dormitories
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /api/users/{id}/restore [post]
+func (h *UserHandler) RestoreUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	resp, err := h.userUseCase.RestoreUser(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "Deleted user not found")
+		case domainErrors.ErrUserAlreadyExists:
+			response.ErrorConflict(c, "Another user with this email already exists")
+		default:
+			response.ErrorInternalServer(c, "Failed to restore user", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "User restored successfully")
+}
+
 // AssignRoleToUser handles assigning a role to a user
 func (h *UserHandler) AssignRoleToUser(c *gin.Context) {
 	userIDStr := c.Param("id")
@@ -275,9 +356,11 @@ func (h *UserHandler) AssignRoleToUser(c *gin.Context) {
 		return
 	}
 
-	err = h.userUseCase.AssignRoleToUser(c.Request.Context(), userID, roleID)
+	err = h.userUseCase.AssignRoleToUser(c.Request.Context(), userID, roleID, req.ExpiresAt)
 	if err != nil {
 		switch err {
+		case domainErrors.ErrBadRequest:
+			response.ErrorBadRequest(c, "Expiry must be in the future")
 		case domainErrors.ErrUserNotFound:
 			response.ErrorNotFound(c, "User not found")
 		case domainErrors.ErrRoleNotFound:
@@ -322,3 +405,108 @@ func (h *UserHandler) RemoveRoleFromUser(c *gin.Context) {
 
 	response.SuccessOK(c, nil, "Role removed successfully")
 }
+
+// ListDormitoryRoles handles listing the roles a user holds within specific dormitories
+func (h *UserHandler) ListDormitoryRoles(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	resp, err := h.userUseCase.ListDormitoryRoles(c.Request.Context(), userID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to list dormitory roles", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, resp, "Dormitory roles retrieved successfully")
+}
+
+// AssignDormitoryRole handles assigning a role to a user within a dormitory
+func (h *UserHandler) AssignDormitoryRole(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	var req dto.AssignDormitoryRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ErrorBadRequest(c, "Invalid request body", err.Error())
+		return
+	}
+
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid role ID", err.Error())
+		return
+	}
+
+	dormitoryID, err := uuid.Parse(req.DormitoryID)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	err = h.userUseCase.AssignDormitoryRole(c.Request.Context(), userID, dormitoryID, roleID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		case domainErrors.ErrDormitoryNotFound:
+			response.ErrorNotFound(c, "Dormitory not found")
+		case domainErrors.ErrRoleNotFound:
+			response.ErrorNotFound(c, "Role not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to assign dormitory role", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Dormitory role assigned successfully")
+}
+
+// RemoveDormitoryRole handles removing a role from a user within a dormitory
+func (h *UserHandler) RemoveDormitoryRole(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid user ID", err.Error())
+		return
+	}
+
+	dormitoryIDStr := c.Param("dormitory_id")
+	dormitoryID, err := uuid.Parse(dormitoryIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid dormitory ID", err.Error())
+		return
+	}
+
+	roleIDStr := c.Param("role_id")
+	roleID, err := uuid.Parse(roleIDStr)
+	if err != nil {
+		response.ErrorBadRequest(c, "Invalid role ID", err.Error())
+		return
+	}
+
+	err = h.userUseCase.RemoveDormitoryRole(c.Request.Context(), userID, dormitoryID, roleID)
+	if err != nil {
+		switch err {
+		case domainErrors.ErrUserNotFound:
+			response.ErrorNotFound(c, "User not found")
+		default:
+			response.ErrorInternalServer(c, "Failed to remove dormitory role", err.Error())
+		}
+		return
+	}
+
+	response.SuccessOK(c, nil, "Dormitory role removed successfully")
+}
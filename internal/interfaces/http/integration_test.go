@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,6 +19,8 @@ import (
 	appService "github.com/your-org/go-backend-starter/internal/application/service"
 	"github.com/your-org/go-backend-starter/internal/application/usecase"
 	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/repository"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
 	"github.com/your-org/go-backend-starter/internal/infrastructure/database"
 	infraRepo "github.com/your-org/go-backend-starter/internal/infrastructure/repository"
 	infraService "github.com/your-org/go-backend-starter/internal/infrastructure/service"
@@ -61,40 +66,50 @@ func (r *testUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entity.User{}, id).Error
 }
 
-func (r *testUserRepository) List(ctx context.Context, limit, offset int) ([]*entity.User, int64, error) {
-	var users []*entity.User
-	var total int64
-	err := r.db.WithContext(ctx).Model(&entity.User{}).Count(&total).Error
-	if err != nil {
-		return nil, 0, err
-	}
-	err = r.db.WithContext(ctx).Limit(limit).Offset(offset).Find(&users).Error
-	return users, total, err
+func (r *testUserRepository) List(ctx context.Context, filter repository.UserFilter, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	return infraRepo.NewUserRepository().List(ctx, filter, limit, offset, with...)
+}
+
+func (r *testUserRepository) ListAfter(ctx context.Context, filter repository.UserFilter, page repository.KeysetPage, with ...repository.Relation) ([]*entity.User, int64, error) {
+	return infraRepo.NewUserRepository().ListAfter(ctx, filter, page, with...)
+}
+
+func (r *testUserRepository) ListDeleted(ctx context.Context, limit, offset int, with ...repository.Relation) ([]*entity.User, int64, error) {
+	return infraRepo.NewUserRepository().ListDeleted(ctx, limit, offset, with...)
+}
+
+func (r *testUserRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	return infraRepo.NewUserRepository().GetDeletedByID(ctx, id)
+}
+
+func (r *testUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return infraRepo.NewUserRepository().Restore(ctx, id)
+}
+
+func (r *testUserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return infraRepo.NewUserRepository().PurgeDeleted(ctx, before)
 }
 
+// GetWithRoles uses the real repository so permissions inherited through parent roles are resolved
 func (r *testUserRepository) GetWithRoles(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	var user entity.User
-	err := r.db.WithContext(ctx).Preload("Roles").Preload("Roles.Permissions").Where("id = ?", id).First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+	return infraRepo.NewUserRepository().GetWithRoles(ctx, id)
 }
 
+// GetWithRolesAndDormitories uses the real repository so permissions inherited through parent roles are resolved
 func (r *testUserRepository) GetWithRolesAndDormitories(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	var user entity.User
-	err := r.db.WithContext(ctx).Preload("Roles").Preload("Roles.Permissions").Preload("Dormitories").Where("id = ?", id).First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+	return infraRepo.NewUserRepository().GetWithRolesAndDormitories(ctx, id)
 }
 
-func (r *testUserRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID) error {
-	return r.db.WithContext(ctx).Create(&entity.UserRole{
-		UserID: userID,
-		RoleID: roleID,
-	}).Error
+func (r *testUserRepository) AssignRole(ctx context.Context, userID, roleID uuid.UUID, expiresAt *time.Time) error {
+	return infraRepo.NewUserRepository().AssignRole(ctx, userID, roleID, expiresAt)
+}
+
+func (r *testUserRepository) HasPermanentRole(ctx context.Context, userID, roleID uuid.UUID) (bool, error) {
+	return infraRepo.NewUserRepository().HasPermanentRole(ctx, userID, roleID)
+}
+
+func (r *testUserRepository) RevokeExpiredRoles(ctx context.Context, before time.Time) ([]*entity.UserRole, error) {
+	return infraRepo.NewUserRepository().RevokeExpiredRoles(ctx, before)
 }
 
 func (r *testUserRepository) RemoveRole(ctx context.Context, userID, roleID uuid.UUID) error {
@@ -103,7 +118,33 @@ func (r *testUserRepository) RemoveRole(ctx context.Context, userID, roleID uuid
 		Delete(&entity.UserRole{}).Error
 }
 
+func (r *testUserRepository) GetDormitoryRoles(ctx context.Context, userID uuid.UUID) ([]*entity.UserDormitoryRole, error) {
+	var scopedRoles []*entity.UserDormitoryRole
+	err := r.db.WithContext(ctx).Preload("Role").Preload("Dormitory").Where("user_id = ?", userID).Find(&scopedRoles).Error
+	return scopedRoles, err
+}
+
+func (r *testUserRepository) AssignDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	return r.db.WithContext(ctx).Create(&entity.UserDormitoryRole{
+		UserID:      userID,
+		DormitoryID: dormitoryID,
+		RoleID:      roleID,
+	}).Error
+}
+
+func (r *testUserRepository) RemoveDormitoryRole(ctx context.Context, userID, dormitoryID, roleID uuid.UUID) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND dormitory_id = ? AND role_id = ?", userID, dormitoryID, roleID).
+		Delete(&entity.UserDormitoryRole{}).Error
+}
+
 func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
+	r, _, cleanup := setupTestRouterWithIdP(t)
+	return r, cleanup
+}
+
+// setupTestRouterWithIdP sets up the router with a stub identity provider named "stub"
+func setupTestRouterWithIdP(t *testing.T) (*gin.Engine, *testutil.StubIdP, func()) {
 	gin.SetMode(gin.TestMode)
 
 	// Setup test database
@@ -120,23 +161,52 @@ func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
 	dormitoryRepo := infraRepo.NewDormitoryRepository()
 	permissionRepo := infraRepo.NewPermissionRepository()
 	auditLogRepo := infraRepo.NewAuditLogRepository()
+	refreshTokenRepo := infraRepo.NewRefreshTokenRepository()
+	sessionRepo := infraRepo.NewSessionRepository()
+	userTokenRepo := infraRepo.NewUserTokenRepository()
+	mfaRepo := infraRepo.NewMFARepository()
+	apiKeyRepo := infraRepo.NewAPIKeyRepository()
+	linkedIdentityRepo := infraRepo.NewLinkedIdentityRepository()
+	oidcStateRepo := infraRepo.NewOIDCStateRepository()
 	provinceRepo := infraRepo.NewProvinceRepository()
 	regencyRepo := infraRepo.NewRegencyRepository()
 	districtRepo := infraRepo.NewDistrictRepository()
 	villageRepo := infraRepo.NewVillageRepository()
+	roleRequestRepo := infraRepo.NewRoleRequestRepository()
+	txManager := infraRepo.NewTransactionManager()
 
 	// Initialize services
-	tokenService := infraService.NewJWTService()
+	tokenService, err := infraService.NewJWTService()
+	require.NoError(t, err)
+	tokenDenylist := infraService.NewTokenDenylistFromEnv()
+	mailer := infraService.NewMailerFromEnv()
+	otpService := infraService.NewTOTPService("Test App")
 	auditLogger := appService.NewAuditLogger(auditLogRepo)
+	loginThrottle := appService.NewLoginThrottle(infraService.NewMemoryLoginAttemptStore(), appService.DefaultLoginThrottleOptions())
+	permissionRegistry := appService.NewPermissionRegistry()
+	cursorCodec := appService.NewCursorCodec([]byte("test-cursor-secret"))
+	idp := testutil.NewStubIdP(t, "test-client", "test-secret")
+	stubProvider, err := infraService.NewOIDCProvider(infraService.OIDCConfig{
+		Name:         "stub",
+		IssuerURL:    idp.Issuer(),
+		ClientID:     idp.ClientID,
+		ClientSecret: idp.ClientSecret,
+		RedirectURL:  "http://localhost:3000/auth/callback",
+	})
+	require.NoError(t, err)
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, tokenService)
-	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, auditLogger)
-	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger)
-	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger)
-	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo)
-	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo)
-	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo)
+	authUseCase := usecase.NewAuthUseCase(userRepo, refreshTokenRepo, sessionRepo, userTokenRepo, mfaRepo, tokenService, tokenDenylist, otpService, mailer, auditLogger, loginThrottle, txManager, usecase.DefaultAuthOptions())
+	userUseCase := usecase.NewUserUseCase(userRepo, roleRepo, dormitoryRepo, auditLogger, cursorCodec, txManager)
+	dormitoryUseCase := usecase.NewDormitoryUseCase(dormitoryRepo, userRepo, auditLogger, cursorCodec)
+	roleUseCase := usecase.NewRoleUseCase(roleRepo, permissionRepo, auditLogger, txManager)
+	locationUseCase := usecase.NewLocationUseCase(provinceRepo, regencyRepo, districtRepo, villageRepo, cursorCodec)
+	permissionUseCase := usecase.NewPermissionUseCase(permissionRepo, permissionRegistry, auditLogger, txManager)
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepo, cursorCodec)
+	apiKeyUseCase := usecase.NewAPIKeyUseCase(apiKeyRepo, userRepo, permissionRepo, auditLogger)
+	sessionUseCase := usecase.NewSessionUseCase(sessionRepo, refreshTokenRepo, auditLogger)
+	roleRequestUseCase := usecase.NewRoleRequestUseCase(roleRequestRepo, userRepo, roleRepo, auditLogger, txManager)
+	oidcUseCase := usecase.NewOIDCUseCase([]service.IdentityProvider{stubProvider}, linkedIdentityRepo, oidcStateRepo, userRepo, roleRepo, authUseCase, auditLogger)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUseCase)
@@ -146,12 +216,20 @@ func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
 	locationHandler := handler.NewLocationHandler(locationUseCase)
 	permissionHandler := handler.NewPermissionHandler(permissionUseCase)
 	auditLogHandler := handler.NewAuditLogHandler(auditLogUseCase)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUseCase)
+	oidcHandler := handler.NewOIDCHandler(oidcUseCase)
+	sessionHandler := handler.NewSessionHandler(sessionUseCase)
+	roleRequestHandler := handler.NewRoleRequestHandler(roleRequestUseCase)
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(tokenService, userRepo)
+	authMiddleware := middleware.NewAuthMiddleware(tokenService, tokenDenylist, userRepo, apiKeyRepo)
+	rateLimiter := middleware.NewRateLimiter(infraService.NewMemoryRateLimitStore())
+	policyEngine, err := appService.NewPolicyEngine(nil)
+	require.NoError(t, err)
+	policyMiddleware := middleware.NewPolicyMiddleware(policyEngine, false)
 
 	// Setup router
-	r := router.SetupRouter(authHandler, userHandler, dormitoryHandler, roleHandler, locationHandler, permissionHandler, auditLogHandler, authMiddleware)
+	r := router.SetupRouter(authHandler, userHandler, dormitoryHandler, roleHandler, locationHandler, permissionHandler, auditLogHandler, apiKeyHandler, oidcHandler, sessionHandler, roleRequestHandler, authMiddleware, policyMiddleware, rateLimiter, permissionRegistry)
 
 	cleanup := func() {
 		database.DB = originalDB // Restore original DB
@@ -159,7 +237,7 @@ func setupTestRouter(t *testing.T) (*gin.Engine, func()) {
 		testutil.UnsetTestEnv()
 	}
 
-	return r, cleanup
+	return r, idp, cleanup
 }
 
 func TestAuthIntegration_RegisterAndLogin(t *testing.T) {
@@ -258,3 +336,1312 @@ func TestAuthIntegration_InvalidCredentials(t *testing.T) {
 
 	assert.Equal(t, http.StatusUnauthorized, loginW.Code)
 }
+
+func TestAuthIntegration_RefreshRotationAndReuse(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	refreshTokenOf := func(w *httptest.ResponseRecorder) string {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp["data"].(map[string]interface{})["refresh_token"].(string)
+	}
+
+	registerW := postJSON("/api/auth/register", dto.RegisterRequest{
+		Email:    "rotation@example.com",
+		Password: "password123",
+		Name:     "Rotation User",
+	})
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	firstToken := refreshTokenOf(registerW)
+
+	// First refresh rotates the token
+	refreshW := postJSON("/api/auth/refresh", dto.RefreshTokenRequest{RefreshToken: firstToken})
+	require.Equal(t, http.StatusOK, refreshW.Code)
+	secondToken := refreshTokenOf(refreshW)
+	assert.NotEqual(t, firstToken, secondToken)
+
+	// Reusing the rotated token is rejected and revokes the family
+	reuseW := postJSON("/api/auth/refresh", dto.RefreshTokenRequest{RefreshToken: firstToken})
+	assert.Equal(t, http.StatusUnauthorized, reuseW.Code)
+
+	// The latest token of the family is no longer usable either
+	revokedW := postJSON("/api/auth/refresh", dto.RefreshTokenRequest{RefreshToken: secondToken})
+	assert.Equal(t, http.StatusUnauthorized, revokedW.Code)
+}
+
+func TestAuthIntegration_Logout(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := postJSON("/api/auth/register", dto.RegisterRequest{
+		Email:    "logout@example.com",
+		Password: "password123",
+		Name:     "Logout User",
+	})
+	require.Equal(t, http.StatusCreated, registerW.Code)
+
+	var registerResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registerResp))
+	refreshToken := registerResp["data"].(map[string]interface{})["refresh_token"].(string)
+
+	logoutW := postJSON("/api/auth/logout", dto.LogoutRequest{RefreshToken: refreshToken})
+	assert.Equal(t, http.StatusOK, logoutW.Code)
+
+	refreshW := postJSON("/api/auth/refresh", dto.RefreshTokenRequest{RefreshToken: refreshToken})
+	assert.Equal(t, http.StatusUnauthorized, refreshW.Code)
+}
+
+func TestAuthIntegration_LogoutAllRevokesAccessToken(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	registerBody, _ := json.Marshal(dto.RegisterRequest{
+		Email:    "logoutall@example.com",
+		Password: "password123",
+		Name:     "Logout All User",
+	})
+	registerReqHTTP, _ := http.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(registerBody))
+	registerReqHTTP.Header.Set("Content-Type", "application/json")
+	registerW := httptest.NewRecorder()
+	router.ServeHTTP(registerW, registerReqHTTP)
+	require.Equal(t, http.StatusCreated, registerW.Code)
+
+	var registerResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registerResp))
+	accessToken := registerResp["data"].(map[string]interface{})["access_token"].(string)
+
+	authorized := func(method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, authorized(http.MethodGet, "/api/me").Code)
+	assert.Equal(t, http.StatusOK, authorized(http.MethodPost, "/api/auth/logout-all").Code)
+
+	// The access token used for logout-all is denylisted immediately
+	assert.Equal(t, http.StatusUnauthorized, authorized(http.MethodGet, "/api/me").Code)
+}
+
+func TestAuthIntegration_LoginThrottling(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	postJSON := func(path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := postJSON("/api/auth/register", dto.RegisterRequest{
+		Email:    "throttle@example.com",
+		Password: "password123",
+		Name:     "Throttle User",
+	})
+	require.Equal(t, http.StatusCreated, registerW.Code)
+
+	wrongLogin := dto.LoginRequest{Email: "throttle@example.com", Password: "wrongpassword"}
+	assert.Equal(t, http.StatusUnauthorized, postJSON("/api/auth/login", wrongLogin).Code)
+
+	// An immediate retry has to wait for the backoff, even with the right password
+	retryW := postJSON("/api/auth/login", dto.LoginRequest{Email: "throttle@example.com", Password: "password123"})
+	assert.Equal(t, http.StatusTooManyRequests, retryW.Code)
+	assert.NotEmpty(t, retryW.Header().Get("Retry-After"))
+}
+
+func TestAuthIntegration_APIKeys(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, headers map[string]string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	dataOf := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp["data"].(map[string]interface{})
+	}
+
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "apikey@example.com",
+		Password: "password123",
+		Name:     "API Key User",
+	}, nil)
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	registerData := dataOf(registerW)
+	jwtAuth := map[string]string{"Authorization": "Bearer " + registerData["access_token"].(string)}
+	userID := uuid.MustParse(registerData["user"].(map[string]interface{})["id"].(string))
+
+	// Grant the user a role with two permissions
+	role := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "operator",
+		Slug:     "operator",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"},
+			{ID: uuid.New(), Name: "user:update", Slug: "user-update", Resource: "user", Action: "update"},
+		},
+	}
+	require.NoError(t, database.DB.Create(role).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: role.ID}).Error)
+
+	// Keys cannot be scoped beyond the owner's permissions
+	deniedW := send(http.MethodPost, "/api/me/api-keys", dto.CreateAPIKeyRequest{
+		Name:        "too broad",
+		Permissions: []string{"role:update"},
+	}, jwtAuth)
+	assert.Equal(t, http.StatusForbidden, deniedW.Code)
+
+	createW := send(http.MethodPost, "/api/me/api-keys", dto.CreateAPIKeyRequest{
+		Name:        "ci",
+		Permissions: []string{"user:read"},
+	}, jwtAuth)
+	require.Equal(t, http.StatusCreated, createW.Code)
+	created := dataOf(createW)
+	plainKey := created["key"].(string)
+	assert.True(t, strings.HasPrefix(plainKey, entity.APIKeyPrefix))
+	assert.Equal(t, plainKey[:len(created["prefix"].(string))], created["prefix"])
+
+	// Both headers resolve the owner, restricted to the key's scope
+	for _, headers := range []map[string]string{
+		{"X-API-Key": plainKey},
+		{"Authorization": "Bearer " + plainKey},
+	} {
+		meW := send(http.MethodGet, "/api/me", nil, headers)
+		require.Equal(t, http.StatusOK, meW.Code)
+		me := dataOf(meW)
+		assert.Equal(t, "apikey@example.com", me["email"])
+		assert.Equal(t, []interface{}{"user:read"}, me["permissions"])
+	}
+
+	// Keys cannot manage keys
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/me/api-keys", nil, map[string]string{"X-API-Key": plainKey}).Code)
+
+	// Nor the account's security, whatever their scope
+	keyAuth := map[string]string{"X-API-Key": plainKey}
+	for _, route := range []struct{ method, path string }{
+		{http.MethodPost, "/api/auth/logout-all"},
+		{http.MethodPost, "/api/me/mfa/enroll"},
+		{http.MethodPost, "/api/me/mfa/confirm"},
+		{http.MethodDelete, "/api/me/mfa"},
+		{http.MethodGet, "/api/me/sessions"},
+		{http.MethodDelete, "/api/me/sessions/" + uuid.NewString()},
+		{http.MethodGet, "/api/me/role-requests"},
+		{http.MethodPost, "/api/me/role-requests"},
+	} {
+		assert.Equal(t, http.StatusForbidden, send(route.method, route.path, nil, keyAuth).Code, "%s %s", route.method, route.path)
+	}
+	var mfaCount int64
+	require.NoError(t, database.DB.Model(&entity.UserMFA{}).Where("user_id = ?", userID).Count(&mfaCount).Error)
+	assert.Zero(t, mfaCount)
+	// The JWT session of the owner is untouched
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/api/me/sessions", nil, jwtAuth).Code)
+
+	// Usage is tracked
+	getW := send(http.MethodGet, "/api/me/api-keys/"+created["id"].(string), nil, jwtAuth)
+	require.Equal(t, http.StatusOK, getW.Code)
+	assert.NotEmpty(t, dataOf(getW)["last_used_at"])
+
+	// Revoked keys stop working
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/me/api-keys/"+created["id"].(string), nil, jwtAuth).Code)
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/me", nil, map[string]string{"X-API-Key": plainKey}).Code)
+}
+
+func TestAuthIntegration_OIDCLogin(t *testing.T) {
+	router, idp, cleanup := setupTestRouterWithIdP(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	dataOf := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp["data"].(map[string]interface{})
+	}
+	signIn := func(user testutil.StubIdPUser) dto.OIDCCallbackRequest {
+		authorizeW := send(http.MethodGet, "/api/auth/oidc/stub/authorize", nil, "")
+		require.Equal(t, http.StatusOK, authorizeW.Code)
+		code, state := idp.Authorize(t, dataOf(authorizeW)["authorization_url"].(string), user)
+		return dto.OIDCCallbackRequest{Code: code, State: state}
+	}
+
+	// Provisioned users get the default role
+	require.NoError(t, database.DB.Create(&entity.Role{ID: uuid.New(), Name: "user", Slug: "user", IsActive: true}).Error)
+
+	providersW := send(http.MethodGet, "/api/auth/oidc/providers", nil, "")
+	require.Equal(t, http.StatusOK, providersW.Code)
+	assert.Equal(t, []interface{}{"stub"}, dataOf(providersW)["providers"])
+	assert.Equal(t, http.StatusNotFound, send(http.MethodGet, "/api/auth/oidc/unknown/authorize", nil, "").Code)
+
+	student := testutil.StubIdPUser{Subject: "campus-123", Email: "student@campus.test", EmailVerified: true, Name: "Student"}
+	callback := signIn(student)
+	loginW := send(http.MethodPost, "/api/auth/oidc/stub/callback", callback, "")
+	require.Equal(t, http.StatusOK, loginW.Code)
+	accessToken := dataOf(loginW)["access_token"].(string)
+
+	meW := send(http.MethodGet, "/api/me", nil, accessToken)
+	require.Equal(t, http.StatusOK, meW.Code)
+	me := dataOf(meW)
+	assert.Equal(t, "student@campus.test", me["email"])
+	assert.Equal(t, []interface{}{"user"}, me["roles"])
+
+	// The state is single-use
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodPost, "/api/auth/oidc/stub/callback", callback, "").Code)
+
+	// Signing in again resolves the linked identity instead of provisioning a new user
+	student.Email = "renamed@campus.test"
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/api/auth/oidc/stub/callback", signIn(student), "").Code)
+	var users, identities int64
+	database.DB.Model(&entity.User{}).Count(&users)
+	database.DB.Model(&entity.LinkedIdentity{}).Count(&identities)
+	assert.Equal(t, int64(1), users)
+	assert.Equal(t, int64(1), identities)
+
+	// Existing accounts are only linked through a verified email
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "local@example.com",
+		Password: "password123",
+		Name:     "Local User",
+	}, "")
+	require.Equal(t, http.StatusCreated, registerW.Code)
+
+	unverified := testutil.StubIdPUser{Subject: "campus-456", Email: "local@example.com", EmailVerified: false}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/auth/oidc/stub/callback", signIn(unverified), "").Code)
+
+	// The local account has not verified its email yet, so it may belong to someone else
+	verified := testutil.StubIdPUser{Subject: "campus-456", Email: "local@example.com", EmailVerified: true}
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/api/auth/oidc/stub/callback", signIn(verified), "").Code)
+
+	require.NoError(t, database.DB.Model(&entity.User{}).Where("email = ?", "local@example.com").Update("email_verified_at", time.Now()).Error)
+	linkW := send(http.MethodPost, "/api/auth/oidc/stub/callback", signIn(verified), "")
+	require.Equal(t, http.StatusOK, linkW.Code)
+	assert.Equal(t, "local@example.com", dataOf(linkW)["user"].(map[string]interface{})["email"])
+
+	// An unverified email is never used to provision an account
+	squatter := testutil.StubIdPUser{Subject: "other-789", Email: "victim@example.com", EmailVerified: false}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/auth/oidc/stub/callback", signIn(squatter), "").Code)
+	var squatted int64
+	database.DB.Model(&entity.User{}).Where("email = ?", "victim@example.com").Count(&squatted)
+	assert.Equal(t, int64(0), squatted)
+}
+
+func TestAuthIntegration_Sessions(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken, userAgent string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "sessions@example.com",
+		Password: "password123",
+		Name:     "Session User",
+	}, "", "laptop-browser")
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	laptop := decode(registerW)["data"].(map[string]interface{})
+	userID := laptop["user"].(map[string]interface{})["id"].(string)
+
+	loginW := send(http.MethodPost, "/api/auth/login", dto.LoginRequest{
+		Email:    "sessions@example.com",
+		Password: "password123",
+	}, "", "phone-app")
+	require.Equal(t, http.StatusOK, loginW.Code)
+	phoneToken := decode(loginW)["data"].(map[string]interface{})["access_token"].(string)
+
+	listW := send(http.MethodGet, "/api/me/sessions", nil, phoneToken, "phone-app")
+	require.Equal(t, http.StatusOK, listW.Code)
+	sessions := decode(listW)["data"].([]interface{})
+	require.Len(t, sessions, 2)
+
+	var laptopSessionID string
+	for _, s := range sessions {
+		session := s.(map[string]interface{})
+		if session["user_agent"] == "laptop-browser" {
+			laptopSessionID = session["id"].(string)
+		}
+	}
+	require.NotEmpty(t, laptopSessionID)
+
+	// Revoking the laptop session invalidates its refresh token
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/me/sessions/"+laptopSessionID, nil, phoneToken, "phone-app").Code)
+	refreshW := send(http.MethodPost, "/api/auth/refresh", dto.RefreshTokenRequest{RefreshToken: laptop["refresh_token"].(string)}, "", "laptop-browser")
+	assert.Equal(t, http.StatusUnauthorized, refreshW.Code)
+
+	listW = send(http.MethodGet, "/api/me/sessions", nil, phoneToken, "phone-app")
+	require.Equal(t, http.StatusOK, listW.Code)
+	assert.Len(t, decode(listW)["data"].([]interface{}), 1)
+
+	// Sessions of other users are managed through /api/users/:id/sessions with user:update
+	otherW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "admin-sessions@example.com",
+		Password: "password123",
+		Name:     "Admin User",
+	}, "", "admin-browser")
+	require.Equal(t, http.StatusCreated, otherW.Code)
+	adminData := decode(otherW)["data"].(map[string]interface{})
+	adminToken := adminData["access_token"].(string)
+	adminID := uuid.MustParse(adminData["user"].(map[string]interface{})["id"].(string))
+
+	assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, "/api/me/sessions/"+laptopSessionID, nil, adminToken, "admin-browser").Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/users/"+userID+"/sessions", nil, adminToken, "admin-browser").Code)
+
+	role := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "support",
+		Slug:        "support",
+		IsActive:    true,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "user:update", Slug: "user-update", Resource: "user", Action: "update"}},
+	}
+	require.NoError(t, database.DB.Create(role).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: adminID, RoleID: role.ID}).Error)
+
+	adminListW := send(http.MethodGet, "/api/users/"+userID+"/sessions", nil, adminToken, "admin-browser")
+	require.Equal(t, http.StatusOK, adminListW.Code)
+	phoneSessionID := decode(adminListW)["data"].([]interface{})[0].(map[string]interface{})["id"].(string)
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/users/"+userID+"/sessions/"+phoneSessionID, nil, adminToken, "admin-browser").Code)
+
+	listW = send(http.MethodGet, "/api/me/sessions", nil, phoneToken, "phone-app")
+	require.Equal(t, http.StatusOK, listW.Code)
+	assert.Empty(t, decode(listW)["data"].([]interface{}))
+}
+
+func TestAuthIntegration_DormitoryScopedRoles(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email string) (string, uuid.UUID) {
+		w := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+			Email:    email,
+			Password: "password123",
+			Name:     "Test User",
+		}, "")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+
+	adminToken, adminID := register("admin-scoped@example.com")
+	wardenToken, wardenID := register("warden-scoped@example.com")
+
+	// The admin manages users globally
+	adminRole := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "support",
+		Slug:        "support",
+		IsActive:    true,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "user:update", Slug: "user-update", Resource: "user", Action: "update"}},
+	}
+	require.NoError(t, database.DB.Create(adminRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: adminID, RoleID: adminRole.ID}).Error)
+
+	wardenRole := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "Warden",
+		Slug:        "warden",
+		IsActive:    true,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "dorm:update", Slug: "dorm-update", Resource: "dorm", Action: "update"}},
+	}
+	require.NoError(t, database.DB.Create(wardenRole).Error)
+
+	assigned := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A", IsActive: true}
+	other := &entity.Dormitory{ID: uuid.New(), Name: "Asrama B", IsActive: true}
+	require.NoError(t, database.DB.Create(assigned).Error)
+	require.NoError(t, database.DB.Create(other).Error)
+	// The warden may access the other dormitory, but holds no role there
+	require.NoError(t, database.DB.Create(&entity.UserDormitory{UserID: wardenID, DormitoryID: other.ID}).Error)
+
+	update := dto.UpdateDormitoryRequest{Name: "Renamed"}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPut, "/api/dormitories/"+assigned.ID.String(), update, wardenToken).Code)
+
+	// Granting a scoped role requires user:update
+	grant := dto.AssignDormitoryRoleRequest{RoleID: wardenRole.ID.String(), DormitoryID: assigned.ID.String()}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/users/"+wardenID.String()+"/dormitory-roles", grant, wardenToken).Code)
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/api/users/"+wardenID.String()+"/dormitory-roles", grant, adminToken).Code)
+
+	listW := send(http.MethodGet, "/api/users/"+wardenID.String()+"/dormitory-roles", nil, adminToken)
+	require.Equal(t, http.StatusOK, listW.Code)
+	var listResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(listW.Body.Bytes(), &listResp))
+	scopedRoles := listResp["data"].([]interface{})
+	require.Len(t, scopedRoles, 1)
+	assert.Equal(t, "Asrama A", scopedRoles[0].(map[string]interface{})["dormitory_name"])
+
+	// The scoped role only grants its permissions within its dormitory
+	assert.Equal(t, http.StatusOK, send(http.MethodPut, "/api/dormitories/"+assigned.ID.String(), update, wardenToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPut, "/api/dormitories/"+other.ID.String(), update, wardenToken).Code)
+
+	// Revoking the scoped role removes the permission again
+	assert.Equal(t, http.StatusOK, send(http.MethodDelete, "/api/users/"+wardenID.String()+"/dormitory-roles/"+assigned.ID.String()+"/"+wardenRole.ID.String(), nil, adminToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPut, "/api/dormitories/"+assigned.ID.String(), update, wardenToken).Code)
+}
+
+func TestAuthIntegration_PermissionManagement(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) map[string]interface{} {
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "permissions@example.com",
+		Password: "password123",
+		Name:     "Permission Manager",
+	}, "")
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	registerData := decode(registerW)["data"].(map[string]interface{})
+	token := registerData["access_token"].(string)
+	userID := uuid.MustParse(registerData["user"].(map[string]interface{})["id"].(string))
+
+	createReq := dto.CreatePermissionRequest{Name: "room:read"}
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/permissions", createReq, token).Code)
+
+	manager := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "Permission Manager",
+		Slug:        "permission_manager",
+		IsActive:    true,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "permission:*", Slug: "permission-all", Resource: "permission", Action: "*"}},
+	}
+	require.NoError(t, database.DB.Create(manager).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: manager.ID}).Error)
+
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/api/permissions", dto.CreatePermissionRequest{Name: "room read"}, token).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPost, "/api/permissions", dto.CreatePermissionRequest{Name: "room:read", Slug: "room-view"}, token).Code)
+
+	createW := send(http.MethodPost, "/api/permissions", createReq, token)
+	require.Equal(t, http.StatusCreated, createW.Code)
+	created := decode(createW)["data"].(map[string]interface{})
+	assert.Equal(t, "room-read", created["slug"])
+	permissionID := created["id"].(string)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/api/permissions", createReq, token).Code)
+
+	updateW := send(http.MethodPut, "/api/permissions/"+permissionID, dto.UpdatePermissionRequest{Name: "room:view"}, token)
+	require.Equal(t, http.StatusOK, updateW.Code)
+	assert.Equal(t, "room-view", decode(updateW)["data"].(map[string]interface{})["slug"])
+
+	// Permissions of protected roles cannot be deleted
+	protected := &entity.Role{ID: uuid.New(), Name: "Protected", Slug: "protected", IsActive: true, IsProtected: true}
+	require.NoError(t, database.DB.Create(protected).Error)
+	require.NoError(t, database.DB.Create(&entity.RolePermission{RoleID: protected.ID, PermissionID: uuid.MustParse(permissionID)}).Error)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodDelete, "/api/permissions/"+permissionID, nil, token).Code)
+
+	// Other grants are removed along with the permission
+	require.NoError(t, database.DB.Where("role_id = ?", protected.ID).Delete(&entity.RolePermission{}).Error)
+	require.NoError(t, database.DB.Create(&entity.RolePermission{RoleID: manager.ID, PermissionID: uuid.MustParse(permissionID)}).Error)
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, "/api/permissions/"+permissionID, nil, token).Code)
+	var grants int64
+	require.NoError(t, database.DB.Model(&entity.RolePermission{}).Where("permission_id = ?", permissionID).Count(&grants).Error)
+	assert.Zero(t, grants)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, "/api/permissions/"+permissionID, nil, token).Code)
+}
+
+func TestAuthIntegration_PermissionCatalog(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	registerBody, _ := json.Marshal(dto.RegisterRequest{
+		Email:    "catalog@example.com",
+		Password: "password123",
+		Name:     "Catalog Reader",
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(registerBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var registerResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &registerResp))
+	registerData := registerResp["data"].(map[string]interface{})
+	token := registerData["access_token"].(string)
+	userID := uuid.MustParse(registerData["user"].(map[string]interface{})["id"].(string))
+
+	getCatalog := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/permissions/catalog", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusForbidden, getCatalog().Code)
+
+	reader := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "Catalog Reader",
+		Slug:     "catalog_reader",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "role:read", Slug: "role-read", Resource: "role", Action: "read"},
+			{ID: uuid.New(), Name: "report:export", Slug: "report-export", Resource: "report", Action: "export"},
+		},
+	}
+	require.NoError(t, database.DB.Create(reader).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: reader.ID}).Error)
+
+	w = getCatalog()
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Data []dto.PermissionCatalogEntry `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	catalog := make(map[string]dto.PermissionCatalogEntry, len(resp.Data))
+	for _, entry := range resp.Data {
+		catalog[entry.Name] = entry
+	}
+
+	// Permissions required by routes are listed even when they are not stored
+	require.Contains(t, catalog, "user:update")
+	assert.Contains(t, catalog["user:update"].Endpoints, "POST /api/users/:id/roles")
+	assert.Contains(t, catalog["user:update"].Endpoints, "POST /api/auth/revoke")
+	assert.Contains(t, catalog["role:read"].Endpoints, "GET /api/permissions/catalog")
+	assert.Contains(t, catalog["dorm:update"].Endpoints, "PUT /api/dormitories/:id")
+	assert.False(t, catalog["dorm:access_all"].Orphaned)
+	assert.Empty(t, catalog["dorm:access_all"].Endpoints)
+	assert.True(t, catalog["report:export"].Orphaned)
+}
+
+func TestAuthIntegration_RoleHierarchy(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "hierarchy@example.com",
+		Password: "password123",
+		Name:     "Role Editor",
+	}, "")
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	var registerResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registerResp))
+	registerData := registerResp["data"].(map[string]interface{})
+	token := registerData["access_token"].(string)
+	userID := uuid.MustParse(registerData["user"].(map[string]interface{})["id"].(string))
+
+	// The editor role only holds role permissions through its parent
+	viewer := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "Role Viewer",
+		Slug:        "role_viewer",
+		IsActive:    true,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "role:read", Slug: "role-read", Resource: "role", Action: "read"}},
+	}
+	require.NoError(t, database.DB.Create(viewer).Error)
+	editor := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "Role Editor",
+		Slug:        "role_editor",
+		IsActive:    true,
+		ParentID:    &viewer.ID,
+		Permissions: []entity.Permission{{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"}},
+	}
+	require.NoError(t, database.DB.Create(editor).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: editor.ID}).Error)
+
+	getW := send(http.MethodGet, "/api/roles/"+editor.ID.String(), nil, token)
+	require.Equal(t, http.StatusOK, getW.Code)
+	var getResp struct {
+		Data dto.RoleResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(getW.Body.Bytes(), &getResp))
+	assert.Equal(t, viewer.ID.String(), getResp.Data.ParentID)
+	assert.Equal(t, []string{"user:read"}, getResp.Data.Permissions)
+	assert.Equal(t, []string{"role:read"}, getResp.Data.InheritedPermissions)
+
+	// Only role:read is inherited, so updating roles is still forbidden
+	parentID := editor.ID.String()
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPut, "/api/roles/"+viewer.ID.String(), dto.UpdateRoleRequest{ParentID: &parentID}, token).Code)
+
+	for _, name := range []string{"role:create", "role:update"} {
+		resource, action, _ := entity.ParsePermissionName(name)
+		permission := &entity.Permission{ID: uuid.New(), Name: name, Slug: entity.PermissionSlug(name), Resource: resource, Action: action}
+		require.NoError(t, database.DB.Create(permission).Error)
+		require.NoError(t, database.DB.Create(&entity.RolePermission{RoleID: viewer.ID, PermissionID: permission.ID}).Error)
+	}
+
+	// The viewer cannot inherit from its own child
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodPut, "/api/roles/"+viewer.ID.String(), dto.UpdateRoleRequest{ParentID: &parentID}, token).Code)
+
+	createW := send(http.MethodPost, "/api/roles", dto.CreateRoleRequest{Name: "Role Auditor", Slug: "role_auditor", IsActive: true, ParentID: editor.ID.String()}, token)
+	require.Equal(t, http.StatusCreated, createW.Code)
+	var createResp struct {
+		Data dto.RoleResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(createW.Body.Bytes(), &createResp))
+	assert.ElementsMatch(t, []string{"user:read", "role:read", "role:create", "role:update"}, createResp.Data.InheritedPermissions)
+}
+
+func TestAuthIntegration_SyncRolePermissions(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	registerW := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+		Email:    "sync@example.com",
+		Password: "password123",
+		Name:     "Role Manager",
+	}, "")
+	require.Equal(t, http.StatusCreated, registerW.Code)
+	var registerResp map[string]interface{}
+	require.NoError(t, json.Unmarshal(registerW.Body.Bytes(), &registerResp))
+	registerData := registerResp["data"].(map[string]interface{})
+	token := registerData["access_token"].(string)
+	userID := uuid.MustParse(registerData["user"].(map[string]interface{})["id"].(string))
+
+	newPermission := func(name string) entity.Permission {
+		resource, action, _ := entity.ParsePermissionName(name)
+		permission := entity.Permission{ID: uuid.New(), Name: name, Slug: entity.PermissionSlug(name), Resource: resource, Action: action}
+		require.NoError(t, database.DB.Create(&permission).Error)
+		return permission
+	}
+	roleAll := newPermission("role:*")
+	userRead := newPermission("user:read")
+	dormRead := newPermission("dorm:read")
+	auditRead := newPermission("audit:read")
+
+	manager := &entity.Role{ID: uuid.New(), Name: "Role Manager", Slug: "role_manager", IsActive: true, Permissions: []entity.Permission{roleAll}}
+	require.NoError(t, database.DB.Create(manager).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: manager.ID}).Error)
+	staff := &entity.Role{ID: uuid.New(), Name: "Staff", Slug: "staff", IsActive: true, Permissions: []entity.Permission{userRead, dormRead}}
+	require.NoError(t, database.DB.Create(staff).Error)
+	protected := &entity.Role{ID: uuid.New(), Name: "Protected", Slug: "protected", IsActive: true, IsProtected: true}
+	require.NoError(t, database.DB.Create(protected).Error)
+
+	staffPermissions := func() []string {
+		var names []string
+		require.NoError(t, database.DB.Model(&entity.Permission{}).
+			Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+			Where("role_permissions.role_id = ?", staff.ID).
+			Order("permissions.name").
+			Pluck("permissions.name", &names).Error)
+		return names
+	}
+
+	syncW := send(http.MethodPut, "/api/roles/"+staff.ID.String()+"/permissions", dto.SyncRolePermissionsRequest{
+		PermissionIDs: []string{dormRead.ID.String(), auditRead.ID.String()},
+	}, token)
+	require.Equal(t, http.StatusOK, syncW.Code)
+	var syncResp struct {
+		Data dto.SyncRolePermissionsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(syncW.Body.Bytes(), &syncResp))
+	assert.Equal(t, []string{"audit:read"}, syncResp.Data.Added)
+	assert.Equal(t, []string{"user:read"}, syncResp.Data.Removed)
+	assert.ElementsMatch(t, []string{"audit:read", "dorm:read"}, syncResp.Data.Role.Permissions)
+	assert.Equal(t, []string{"audit:read", "dorm:read"}, staffPermissions())
+
+	// Assigning a permission the role already has is a no-op
+	assignReq := dto.AssignPermissionRequest{PermissionID: dormRead.ID.String()}
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/api/roles/"+staff.ID.String()+"/permissions", assignReq, token).Code)
+	assert.Equal(t, []string{"audit:read", "dorm:read"}, staffPermissions())
+
+	// An unknown permission rejects the whole set
+	unknownW := send(http.MethodPut, "/api/roles/"+staff.ID.String()+"/permissions", dto.SyncRolePermissionsRequest{
+		PermissionIDs: []string{userRead.ID.String(), uuid.New().String()},
+	}, token)
+	assert.Equal(t, http.StatusNotFound, unknownW.Code)
+	assert.Equal(t, []string{"audit:read", "dorm:read"}, staffPermissions())
+
+	protectedW := send(http.MethodPut, "/api/roles/"+protected.ID.String()+"/permissions", dto.SyncRolePermissionsRequest{
+		PermissionIDs: []string{userRead.ID.String()},
+	}, token)
+	assert.Equal(t, http.StatusForbidden, protectedW.Code)
+
+	// An empty set removes every direct permission
+	clearW := send(http.MethodPut, "/api/roles/"+staff.ID.String()+"/permissions", dto.SyncRolePermissionsRequest{PermissionIDs: []string{}}, token)
+	require.Equal(t, http.StatusOK, clearW.Code)
+	assert.Empty(t, staffPermissions())
+}
+
+func TestAuthIntegration_RoleRequests(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	register := func(email string) (string, uuid.UUID) {
+		w := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+			Email:    email,
+			Password: "password123",
+			Name:     "Test User",
+		}, "")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+	requesterToken, requesterID := register("requester@example.com")
+	reviewerToken, reviewerID := register("reviewer@example.com")
+
+	newPermission := func(name string) entity.Permission {
+		resource, action, _ := entity.ParsePermissionName(name)
+		permission := entity.Permission{ID: uuid.New(), Name: name, Slug: entity.PermissionSlug(name), Resource: resource, Action: action}
+		require.NoError(t, database.DB.Create(&permission).Error)
+		return permission
+	}
+	reviewer := &entity.Role{ID: uuid.New(), Name: "Reviewer", Slug: "reviewer", IsActive: true, Permissions: []entity.Permission{newPermission("role:update")}}
+	require.NoError(t, database.DB.Create(reviewer).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: reviewerID, RoleID: reviewer.ID}).Error)
+	auditor := &entity.Role{ID: uuid.New(), Name: "Auditor", Slug: "auditor", IsActive: true, Permissions: []entity.Permission{newPermission("role:read")}}
+	require.NoError(t, database.DB.Create(auditor).Error)
+
+	requestW := send(http.MethodPost, "/api/me/role-requests", dto.RequestRoleRequest{RoleID: auditor.ID.String(), Reason: "Quarterly review"}, requesterToken)
+	require.Equal(t, http.StatusCreated, requestW.Code)
+	var requestResp struct {
+		Data dto.RoleRequestResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(requestW.Body.Bytes(), &requestResp))
+	requestID := requestResp.Data.ID
+	assert.Equal(t, "pending", requestResp.Data.Status)
+
+	// Only one pending request per role
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/api/me/role-requests", dto.RequestRoleRequest{RoleID: auditor.ID.String(), Reason: "Again"}, requesterToken).Code)
+
+	// Reviewing requires role:update
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/role-requests", nil, requesterToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/role-requests/"+requestID+"/approve", nil, requesterToken).Code)
+
+	listW := send(http.MethodGet, "/api/role-requests?status=pending", nil, reviewerToken)
+	require.Equal(t, http.StatusOK, listW.Code)
+	var listResp struct {
+		Data dto.ListRoleRequestsResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listW.Body.Bytes(), &listResp))
+	require.Len(t, listResp.Data.RoleRequests, 1)
+	assert.Equal(t, "requester@example.com", listResp.Data.RoleRequests[0].UserEmail)
+
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/roles", nil, requesterToken).Code)
+
+	expiresAt := time.Now().Add(time.Hour)
+	approveW := send(http.MethodPost, "/api/role-requests/"+requestID+"/approve", dto.ReviewRoleRequestRequest{Note: "Approved", ExpiresAt: &expiresAt}, reviewerToken)
+	require.Equal(t, http.StatusOK, approveW.Code)
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/api/roles", nil, requesterToken).Code)
+
+	// A request is reviewed once
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, "/api/role-requests/"+requestID+"/deny", nil, reviewerToken).Code)
+
+	// The grant stops counting as soon as it expires
+	require.NoError(t, database.DB.Model(&entity.UserRole{}).
+		Where("user_id = ? AND role_id = ?", requesterID, auditor.ID).
+		Update("expires_at", time.Now().Add(-time.Second)).Error)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/roles", nil, requesterToken).Code)
+
+	// Denied requests do not grant the role
+	secondW := send(http.MethodPost, "/api/me/role-requests", dto.RequestRoleRequest{RoleID: auditor.ID.String(), Reason: "Extension"}, requesterToken)
+	require.Equal(t, http.StatusCreated, secondW.Code)
+	require.NoError(t, json.Unmarshal(secondW.Body.Bytes(), &requestResp))
+	assert.Equal(t, http.StatusOK, send(http.MethodPost, "/api/role-requests/"+requestResp.Data.ID+"/deny", nil, reviewerToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/roles", nil, requesterToken).Code)
+
+	// Reviewers cannot approve their own requests
+	ownW := send(http.MethodPost, "/api/me/role-requests", dto.RequestRoleRequest{RoleID: auditor.ID.String(), Reason: "Self"}, reviewerToken)
+	require.Equal(t, http.StatusCreated, ownW.Code)
+	require.NoError(t, json.Unmarshal(ownW.Body.Bytes(), &requestResp))
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, "/api/role-requests/"+requestResp.Data.ID+"/approve", nil, reviewerToken).Code)
+
+	mineW := send(http.MethodGet, "/api/me/role-requests", nil, requesterToken)
+	require.Equal(t, http.StatusOK, mineW.Code)
+	var mineResp struct {
+		Data []dto.RoleRequestResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(mineW.Body.Bytes(), &mineResp))
+	require.Len(t, mineResp.Data, 2)
+	assert.ElementsMatch(t, []string{"approved", "denied"}, []string{mineResp.Data[0].Status, mineResp.Data[1].Status})
+}
+
+func TestAuthIntegration_DormitoryMembers(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email string) (string, uuid.UUID) {
+		w := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+			Email:    email,
+			Password: "password123",
+			Name:     "Test User",
+		}, "")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+
+	managerToken, managerID := register("manager-members@example.com")
+	residentToken, residentID := register("resident-members@example.com")
+
+	// The manager may manage every dormitory and read users
+	managerRole := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "Housing",
+		Slug:     "housing",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "dorm:update", Slug: "dorm-update", Resource: "dorm", Action: "update"},
+			{ID: uuid.New(), Name: "dorm:access_all", Slug: "dorm-access_all", Resource: "dorm", Action: "access_all"},
+			{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"},
+		},
+	}
+	require.NoError(t, database.DB.Create(managerRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: managerID, RoleID: managerRole.ID}).Error)
+
+	dormitory := &entity.Dormitory{ID: uuid.New(), Name: "Asrama A", IsActive: true}
+	require.NoError(t, database.DB.Create(dormitory).Error)
+	membersPath := "/api/dormitories/" + dormitory.ID.String() + "/members"
+
+	// Without membership the resident cannot open the dormitory
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/dormitories/"+dormitory.ID.String(), nil, residentToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, membersPath, dto.AddDormitoryMemberRequest{UserID: residentID.String()}, residentToken).Code)
+
+	add := dto.AddDormitoryMemberRequest{UserID: residentID.String()}
+	require.Equal(t, http.StatusCreated, send(http.MethodPost, membersPath, add, managerToken).Code)
+	assert.Equal(t, http.StatusConflict, send(http.MethodPost, membersPath, add, managerToken).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, membersPath, dto.AddDormitoryMemberRequest{UserID: uuid.New().String()}, managerToken).Code)
+
+	// Membership opens the dormitory and shows up in /api/me
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/api/dormitories/"+dormitory.ID.String(), nil, residentToken).Code)
+	meW := send(http.MethodGet, "/api/me", nil, residentToken)
+	require.Equal(t, http.StatusOK, meW.Code)
+	var meResp struct {
+		Data dto.UserResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(meW.Body.Bytes(), &meResp))
+	require.Len(t, meResp.Data.Dormitories, 1)
+	assert.Equal(t, dormitory.ID.String(), meResp.Data.Dormitories[0].ID)
+
+	listW := send(http.MethodGet, membersPath+"?page=1&page_size=10", nil, managerToken)
+	require.Equal(t, http.StatusOK, listW.Code)
+	var listResp struct {
+		Data dto.ListDormitoryMembersResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(listW.Body.Bytes(), &listResp))
+	assert.Equal(t, int64(1), listResp.Data.Total)
+	require.Len(t, listResp.Data.Members, 1)
+	assert.Equal(t, "resident-members@example.com", listResp.Data.Members[0].Email)
+
+	userDormsW := send(http.MethodGet, "/api/users/"+residentID.String()+"/dormitories", nil, managerToken)
+	require.Equal(t, http.StatusOK, userDormsW.Code)
+	var userDormsResp struct {
+		Data []dto.DormitoryResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(userDormsW.Body.Bytes(), &userDormsResp))
+	require.Len(t, userDormsResp.Data, 1)
+	assert.Equal(t, "Asrama A", userDormsResp.Data[0].Name)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/users/"+managerID.String()+"/dormitories", nil, residentToken).Code)
+
+	memberPath := membersPath + "/" + residentID.String()
+	assert.Equal(t, http.StatusNoContent, send(http.MethodDelete, memberPath, nil, managerToken).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodDelete, memberPath, nil, managerToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/dormitories/"+dormitory.ID.String(), nil, residentToken).Code)
+}
+
+func TestAuthIntegration_SoftDelete(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(method, path string, body interface{}, accessToken string) *httptest.ResponseRecorder {
+		var payload []byte
+		if body != nil {
+			payload, _ = json.Marshal(body)
+		}
+		req, _ := http.NewRequest(method, path, bytes.NewBuffer(payload))
+		req.Header.Set("Content-Type", "application/json")
+		if accessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+accessToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email string) (string, uuid.UUID) {
+		w := send(http.MethodPost, "/api/auth/register", dto.RegisterRequest{
+			Email:    email,
+			Password: "password123",
+			Name:     "Test User",
+		}, "")
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+
+	adminToken, adminID := register("admin-trash@example.com")
+	readerToken, readerID := register("reader-trash@example.com")
+	victimToken, victimID := register("victim-trash@example.com")
+
+	adminRole := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "Trash Admin",
+		Slug:     "trash-admin",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"},
+			{ID: uuid.New(), Name: "user:delete", Slug: "user-delete", Resource: "user", Action: "delete"},
+			{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read"},
+			{ID: uuid.New(), Name: "dorm:delete", Slug: "dorm-delete", Resource: "dorm", Action: "delete"},
+		},
+	}
+	require.NoError(t, database.DB.Create(adminRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: adminID, RoleID: adminRole.ID}).Error)
+
+	readerRole := &entity.Role{
+		ID:          uuid.New(),
+		Name:        "Trash Reader",
+		Slug:        "trash-reader",
+		IsActive:    true,
+		Permissions: []entity.Permission{adminRole.Permissions[0], adminRole.Permissions[2]},
+	}
+	require.NoError(t, database.DB.Create(readerRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: readerID, RoleID: readerRole.ID}).Error)
+
+	userPath := "/api/users/" + victimID.String()
+	require.Equal(t, http.StatusNoContent, send(http.MethodDelete, userPath, nil, adminToken).Code)
+
+	// The deleted user is hidden and can no longer authenticate
+	assert.Equal(t, http.StatusNotFound, send(http.MethodGet, userPath, nil, adminToken).Code)
+	assert.Equal(t, http.StatusUnauthorized, send(http.MethodGet, "/api/me", nil, victimToken).Code)
+
+	// Browsing the trash requires user:delete
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, "/api/users", nil, readerToken).Code)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/users?deleted=only", nil, readerToken).Code)
+	assert.Equal(t, http.StatusBadRequest, send(http.MethodGet, "/api/users?deleted=bogus", nil, adminToken).Code)
+
+	trashW := send(http.MethodGet, "/api/users?deleted=only", nil, adminToken)
+	require.Equal(t, http.StatusOK, trashW.Code)
+	var trashResp struct {
+		Data dto.ListUsersResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(trashW.Body.Bytes(), &trashResp))
+	require.Len(t, trashResp.Data.Users, 1)
+	assert.Equal(t, victimID.String(), trashResp.Data.Users[0].ID)
+	assert.NotEmpty(t, trashResp.Data.Users[0].DeletedAt)
+
+	assert.Equal(t, http.StatusForbidden, send(http.MethodPost, userPath+"/restore", nil, readerToken).Code)
+	require.Equal(t, http.StatusOK, send(http.MethodPost, userPath+"/restore", nil, adminToken).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, userPath+"/restore", nil, adminToken).Code)
+	assert.Equal(t, http.StatusOK, send(http.MethodGet, userPath, nil, adminToken).Code)
+
+	dormitory := &entity.Dormitory{ID: uuid.New(), Name: "Asrama Lama", IsActive: true}
+	require.NoError(t, database.DB.Create(dormitory).Error)
+	require.NoError(t, database.DB.Delete(dormitory).Error)
+
+	dormTrashW := send(http.MethodGet, "/api/dormitories?deleted=only", nil, adminToken)
+	require.Equal(t, http.StatusOK, dormTrashW.Code)
+	var dormTrashResp struct {
+		Data dto.ListDormitoriesResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(dormTrashW.Body.Bytes(), &dormTrashResp))
+	require.Len(t, dormTrashResp.Data.Dormitories, 1)
+	assert.Equal(t, dormitory.ID.String(), dormTrashResp.Data.Dormitories[0].ID)
+	assert.Equal(t, http.StatusForbidden, send(http.MethodGet, "/api/dormitories?deleted=only", nil, readerToken).Code)
+
+	dormPath := "/api/dormitories/" + dormitory.ID.String()
+	require.Equal(t, http.StatusOK, send(http.MethodPost, dormPath+"/restore", nil, adminToken).Code)
+	assert.Equal(t, http.StatusNotFound, send(http.MethodPost, dormPath+"/restore", nil, adminToken).Code)
+}
+
+func TestAuthIntegration_ListFilters(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(path, accessToken string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email, name string) (string, uuid.UUID) {
+		body, _ := json.Marshal(dto.RegisterRequest{Email: email, Password: "password123", Name: name})
+		req, _ := http.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+
+	token, userID := register("zara-filters@example.com", "Zara")
+	register("yusuf-filters@example.com", "Yusuf")
+
+	readerRole := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "Reader",
+		Slug:     "reader",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"},
+		},
+	}
+	require.NoError(t, database.DB.Create(readerRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: readerRole.ID}).Error)
+
+	list := func(query string) dto.ListUsersResponse {
+		w := send("/api/users?"+query, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data dto.ListUsersResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	byName := list("sort=name")
+	require.Len(t, byName.Users, 2)
+	assert.Equal(t, "Yusuf", byName.Users[0].Name)
+	assert.Equal(t, "Zara", byName.Users[1].Name)
+
+	searched := list("q=zara")
+	require.Len(t, searched.Users, 1)
+	assert.Equal(t, int64(1), searched.Total)
+
+	byRole := list("role=reader&is_active=true")
+	require.Len(t, byRole.Users, 1)
+	assert.Equal(t, userID.String(), byRole.Users[0].ID)
+
+	assert.Equal(t, http.StatusBadRequest, send("/api/users?sort=password", token).Code)
+	assert.Equal(t, http.StatusBadRequest, send("/api/users?is_active=maybe", token).Code)
+	assert.Equal(t, http.StatusBadRequest, send("/api/users?dormitory_id=nope", token).Code)
+}
+
+func TestAuthIntegration_CursorPagination(t *testing.T) {
+	router, cleanup := setupTestRouter(t)
+	defer cleanup()
+
+	send := func(path, accessToken string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	register := func(email string) (string, uuid.UUID) {
+		body, _ := json.Marshal(dto.RegisterRequest{Email: email, Password: "password123", Name: "Test User"})
+		req, _ := http.NewRequest(http.MethodPost, "/api/auth/register", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		data := resp["data"].(map[string]interface{})
+		return data["access_token"].(string), uuid.MustParse(data["user"].(map[string]interface{})["id"].(string))
+	}
+
+	token, userID := register("cursor-1@example.com")
+	register("cursor-2@example.com")
+	register("cursor-3@example.com")
+
+	readerRole := &entity.Role{
+		ID:       uuid.New(),
+		Name:     "Reader",
+		Slug:     "reader",
+		IsActive: true,
+		Permissions: []entity.Permission{
+			{ID: uuid.New(), Name: "user:read", Slug: "user-read", Resource: "user", Action: "read"},
+			{ID: uuid.New(), Name: "dorm:read", Slug: "dorm-read", Resource: "dorm", Action: "read"},
+		},
+	}
+	require.NoError(t, database.DB.Create(readerRole).Error)
+	require.NoError(t, database.DB.Create(&entity.UserRole{UserID: userID, RoleID: readerRole.ID}).Error)
+
+	list := func(query string) dto.CursorUsersResponse {
+		w := send("/api/users?"+query, token)
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp struct {
+			Data dto.CursorUsersResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp.Data
+	}
+
+	first := list("limit=2&count=true")
+	require.Len(t, first.Users, 2)
+	assert.Equal(t, 2, first.Limit)
+	require.NotNil(t, first.Total)
+	assert.Equal(t, int64(3), *first.Total)
+	require.NotEmpty(t, first.NextCursor)
+
+	second := list("limit=2&cursor=" + url.QueryEscape(first.NextCursor))
+	require.Len(t, second.Users, 1)
+	assert.Empty(t, second.NextCursor)
+	assert.Nil(t, second.Total)
+
+	seen := map[string]bool{}
+	for _, user := range append(first.Users, second.Users...) {
+		seen[user.ID] = true
+	}
+	assert.Len(t, seen, 3)
+
+	// Offset pagination keeps working
+	offsetW := send("/api/users?page=2&page_size=2", token)
+	require.Equal(t, http.StatusOK, offsetW.Code)
+	var offsetResp struct {
+		Data dto.ListUsersResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(offsetW.Body.Bytes(), &offsetResp))
+	assert.Equal(t, 2, offsetResp.Data.TotalPages)
+	assert.Len(t, offsetResp.Data.Users, 1)
+
+	// Tampered, foreign or unsortable cursor requests are rejected
+	assert.Equal(t, http.StatusBadRequest, send("/api/users?cursor=tampered", token).Code)
+	assert.Equal(t, http.StatusBadRequest, send("/api/dormitories?cursor="+url.QueryEscape(first.NextCursor), token).Code)
+	assert.Equal(t, http.StatusBadRequest, send("/api/users?limit=2&sort=name", token).Code)
+	assert.Equal(t, http.StatusOK, send("/api/users?limit=2&sort=-created_at", token).Code)
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -13,26 +14,41 @@ import (
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// AuthMiddleware handles JWT authentication
+// apiKeyLastUsedInterval limits how often last-used tracking writes to storage
+const apiKeyLastUsedInterval = time.Minute
+
+// AuthMiddleware handles JWT and API key authentication
 type AuthMiddleware struct {
-	tokenService service.TokenService
-	userRepo     repository.UserRepository
+	tokenService  service.TokenService
+	tokenDenylist service.TokenDenylist
+	userRepo      repository.UserRepository
+	apiKeyRepo    repository.APIKeyRepository
 }
 
 // NewAuthMiddleware creates a new auth middleware
 func NewAuthMiddleware(
 	tokenService service.TokenService,
+	tokenDenylist service.TokenDenylist,
 	userRepo repository.UserRepository,
+	apiKeyRepo repository.APIKeyRepository,
 ) *AuthMiddleware {
 	return &AuthMiddleware{
-		tokenService: tokenService,
-		userRepo:     userRepo,
+		tokenService:  tokenService,
+		tokenDenylist: tokenDenylist,
+		userRepo:      userRepo,
+		apiKeyRepo:    apiKeyRepo,
 	}
 }
 
-// RequireAuth is a middleware that requires valid JWT token
+// RequireAuth is a middleware that requires a valid JWT access token or API key.
+// API keys are accepted in the X-API-Key header or as "Bearer pat_...".
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if apiKey := extractAPIKey(c); apiKey != "" {
+			m.authenticateAPIKey(c, apiKey)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			response.ErrorUnauthorized(c, "Authorization header required")
@@ -62,6 +78,34 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Only access tokens grant API access (not refresh or mfa_pending tokens)
+		if claims.Type != service.TokenTypeAccess {
+			response.ErrorUnauthorized(c, "Invalid token")
+			c.Abort()
+			return
+		}
+
+		// Access tokens are always issued with a jti; without one the token
+		// could not be revoked
+		if claims.TokenID == "" {
+			response.ErrorUnauthorized(c, "Invalid token")
+			c.Abort()
+			return
+		}
+
+		// Check if the token has been revoked
+		revoked, err := m.tokenDenylist.IsRevoked(c.Request.Context(), claims.TokenID)
+		if err != nil {
+			response.ErrorInternalServer(c, "Failed to verify token")
+			c.Abort()
+			return
+		}
+		if revoked {
+			response.ErrorUnauthorized(c, "Token revoked")
+			c.Abort()
+			return
+		}
+
 		// Get user with roles and dormitories
 		user, err := m.userRepo.GetWithRolesAndDormitories(c.Request.Context(), claims.UserID)
 		if err != nil {
@@ -78,55 +122,153 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 		}
 
 		// Store user info in context
+		c.Set("token_id", claims.TokenID)
 		c.Set("user_id", claims.UserID)
 		c.Set("user_email", claims.Email)
 		c.Set("user_roles", claims.Roles)
 		c.Set("user", user)
+		c.Set("permissions", user.PermissionSet())
+
+		c.Next()
+	}
+}
+
+// RejectAPIKey is a middleware that blocks requests authenticated with an API key,
+// for endpoints that need an interactive login (e.g. managing API keys)
+func (m *AuthMiddleware) RejectAPIKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get("api_key_id"); exists {
+			response.ErrorForbidden(c, "API keys cannot access this endpoint")
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
 }
 
-// RequirePermission is a middleware that requires specific permission
+// authenticateAPIKey resolves the owner of a personal access token.
+// The user in the context only holds the permissions in the key's scope.
+func (m *AuthMiddleware) authenticateAPIKey(c *gin.Context, plainKey string) {
+	ctx := c.Request.Context()
+
+	apiKey, err := m.apiKeyRepo.GetByKeyHash(ctx, entity.HashAPIKey(plainKey))
+	if err != nil {
+		response.ErrorUnauthorized(c, "Invalid API key")
+		c.Abort()
+		return
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		response.ErrorUnauthorized(c, "API key expired")
+		c.Abort()
+		return
+	}
+
+	// Get user with roles and dormitories
+	user, err := m.userRepo.GetWithRolesAndDormitories(ctx, apiKey.UserID)
+	if err != nil {
+		response.ErrorUnauthorized(c, "User not found")
+		c.Abort()
+		return
+	}
+
+	// Check if user is active
+	if !user.IsActive {
+		response.ErrorForbidden(c, "User is inactive")
+		c.Abort()
+		return
+	}
+
+	// Track usage (best-effort)
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		_ = m.apiKeyRepo.UpdateLastUsed(ctx, apiKey.ID, now)
+	}
+
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = role.Name
+	}
+
+	// Store user info in context
+	c.Set("api_key_id", apiKey.ID)
+	c.Set("api_key_scope", apiKey.PermissionSet())
+	c.Set("user_id", user.ID)
+	c.Set("user_email", user.Email)
+	c.Set("user_roles", roles)
+	restricted := apiKey.Restrict(user)
+	c.Set("user", restricted)
+	c.Set("permissions", restricted.PermissionSet())
+
+	c.Next()
+}
+
+// extractAPIKey returns the personal access token sent with the request, if any
+func extractAPIKey(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && strings.HasPrefix(token, entity.APIKeyPrefix) {
+		return token
+	}
+	return ""
+}
+
+// RequirePermission is a middleware that requires specific permission.
+// The permission may be granted directly, through a wildcard pattern such as
+// "user:*" or through an implied action (e.g. "user:update" implies "user:read").
+// After RequireDormitoryAccess, roles scoped to that dormitory are taken into account.
 func (m *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// RequireAuth should already have run for this route (protected group).
-		// We only rely on the user that was set in the context by RequireAuth.
-		user, exists := c.Get("user")
+		// We only rely on the permission set it resolved once for the request.
+		value, exists := c.Get("permissions")
 		if !exists {
 			response.ErrorUnauthorized(c, "User not found in context")
 			c.Abort()
 			return
 		}
 
-		userEntity, ok := user.(*entity.User)
+		permissions, ok := value.(*entity.PermissionSet)
 		if !ok {
-			response.ErrorInternalServer(c, "Invalid user type")
+			response.ErrorInternalServer(c, "Invalid permission set type")
 			c.Abort()
 			return
 		}
 
 		// Check permission
-		if !userEntity.HasPermission(permission) {
+		if !permissions.Has(permission) {
 			response.ErrorForbidden(c, "Permission denied")
 			c.Abort()
 			return
 		}
 
-		log.Printf("RequirePermission: user=%s checking=%s", userEntity.Email, permission)
+		log.Printf("RequirePermission: user=%s checking=%s", c.GetString("user_email"), permission)
 
 		c.Next()
 	}
 }
 
-// RequireDormitoryAccess is a middleware that checks if user can access a dormitory
-func (m *AuthMiddleware) RequireDormitoryAccess() gin.HandlerFunc {
+// RequirePermissionForQuery requires a permission only when the query parameter is set,
+// e.g. for listing deleted records through an endpoint that is otherwise open to every user
+func (m *AuthMiddleware) RequirePermissionForQuery(param, permission string) gin.HandlerFunc {
+	requirePermission := m.RequirePermission(permission)
 	return func(c *gin.Context) {
-		// First check auth
-		m.RequireAuth()(c)
-		if c.IsAborted() {
+		if c.Query(param) == "" {
+			c.Next()
 			return
 		}
+		requirePermission(c)
+	}
+}
+
+// RequireDormitoryAccess is a middleware that checks if user can access a dormitory
+func (m *AuthMiddleware) RequireDormitoryAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// RequireAuth should already have run for this route (protected group).
+		// Running it here again would call c.Next() and execute the rest of the
+		// chain before the dormitory has been checked.
 
 		// Get dormitory ID from URL parameter or request body
 		dormitoryIDStr := c.Param("id")
@@ -163,15 +305,31 @@ func (m *AuthMiddleware) RequireDormitoryAccess() gin.HandlerFunc {
 			return
 		}
 
+		// Reuse the permission set RequireAuth resolved for the request
+		value, exists := c.Get("permissions")
+		if !exists {
+			response.ErrorUnauthorized(c, "User not found in context")
+			c.Abort()
+			return
+		}
+		permissions, ok := value.(*entity.PermissionSet)
+		if !ok {
+			response.ErrorInternalServer(c, "Invalid permission set type")
+			c.Abort()
+			return
+		}
+
 		// Check if user can access this dormitory
-		if !userEntity.CanAccessDormitory(dormitoryID) {
+		if !permissions.Has(entity.PermissionDormAccessAll) && !userEntity.IsAssignedToDormitory(dormitoryID) {
 			response.ErrorForbidden(c, "Access denied to this dormitory")
 			c.Abort()
 			return
 		}
 
-		// Store dormitory ID in context
+		// Store dormitory ID in context and evaluate further permission checks
+		// with the roles the user holds within this dormitory
 		c.Set("dormitory_id", dormitoryID)
+		c.Set("permissions", userEntity.PermissionSetFor(dormitoryID))
 
 		c.Next()
 	}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/your-org/go-backend-starter/internal/application/usecase/mocks"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/domain/service"
+)
+
+func TestAuthMiddleware_RequireDormitoryAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	assigned := uuid.New()
+	other := uuid.New()
+	admin := &entity.User{
+		ID:          uuid.New(),
+		Email:       "admin@example.com",
+		Roles:       []entity.Role{{Slug: "admin", Permissions: []entity.Permission{{Name: "dorm:*"}}}},
+		Dormitories: []entity.Dormitory{{ID: assigned}},
+	}
+
+	request := func(permissions *entity.PermissionSet, id uuid.UUID) int {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user", admin)
+			c.Set("permissions", permissions)
+		})
+		router.GET("/dormitories/:id", NewAuthMiddleware(nil, nil, nil, nil).RequireDormitoryAccess(), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/dormitories/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, request(admin.PermissionSet(), other))
+	assert.Equal(t, http.StatusOK, request(entity.NewPermissionSet(), assigned))
+
+	// The resolved set decides, e.g. an API key of the admin scoped to dorm:read only
+	assert.Equal(t, http.StatusForbidden, request(entity.NewPermissionSet("dorm:read"), other))
+}
+
+func TestAuthMiddleware_RequireAuth_TokenID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	user := &entity.User{ID: uuid.New(), Email: "user@example.com", IsActive: true}
+
+	tests := []struct {
+		name         string
+		tokenID      string
+		setupMocks   func(denylist *mocks.MockTokenDenylist, userRepo *mocks.MockUserRepository)
+		expectedCode int
+	}{
+		{
+			name:    "success - token with jti not revoked",
+			tokenID: "jti-1",
+			setupMocks: func(denylist *mocks.MockTokenDenylist, userRepo *mocks.MockUserRepository) {
+				denylist.On("IsRevoked", mock.Anything, "jti-1").Return(false, nil)
+				userRepo.On("GetWithRolesAndDormitories", mock.Anything, user.ID).Return(user, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:    "failure - revoked token",
+			tokenID: "jti-1",
+			setupMocks: func(denylist *mocks.MockTokenDenylist, userRepo *mocks.MockUserRepository) {
+				denylist.On("IsRevoked", mock.Anything, "jti-1").Return(true, nil)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "failure - token without jti cannot be revoked",
+			tokenID:      "",
+			setupMocks:   func(denylist *mocks.MockTokenDenylist, userRepo *mocks.MockUserRepository) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := new(mocks.MockTokenService)
+			denylist := new(mocks.MockTokenDenylist)
+			userRepo := new(mocks.MockUserRepository)
+			tokenService.On("ValidateToken", "access_token").Return(&service.TokenClaims{
+				TokenID: tt.tokenID,
+				Type:    service.TokenTypeAccess,
+				UserID:  user.ID,
+				Email:   user.Email,
+			}, nil)
+			tt.setupMocks(denylist, userRepo)
+
+			router := gin.New()
+			router.GET("/me", NewAuthMiddleware(tokenService, denylist, userRepo, nil).RequireAuth(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+			req.Header.Set("Authorization", "Bearer access_token")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			tokenService.AssertExpectations(t)
+			denylist.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
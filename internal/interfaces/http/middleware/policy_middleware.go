@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
+)
+
+// ResourceResolver builds the resource attributes of a request for policy evaluation
+type ResourceResolver func(c *gin.Context) appService.Resource
+
+// ResourceFromParam resolves a resource of the given type identified by a URL parameter
+func ResourceFromParam(resourceType, param string) ResourceResolver {
+	return func(c *gin.Context) appService.Resource {
+		return appService.Resource{Type: resourceType, ID: c.Param(param)}
+	}
+}
+
+// ResourceFromQuery resolves a resource of the given type whose attributes are the
+// named query parameters, e.g. resource.dormitory_id for ?dormitory_id=...
+func ResourceFromQuery(resourceType string, params ...string) ResourceResolver {
+	return func(c *gin.Context) appService.Resource {
+		attributes := make(map[string]string, len(params))
+		for _, param := range params {
+			attributes[param] = c.Query(param)
+		}
+		return appService.Resource{Type: resourceType, Attributes: attributes}
+	}
+}
+
+// WithBodyFields extends a resolver with an attribute that is "true" when the JSON
+// body of the request sets any of the fields and "false" otherwise. A body that is
+// not a JSON object counts as setting them. The body is left for the handler to bind.
+func WithBodyFields(resolver ResourceResolver, attribute string, fields ...string) ResourceResolver {
+	return func(c *gin.Context) appService.Resource {
+		resource := resolver(c)
+		if resource.Attributes == nil {
+			resource.Attributes = make(map[string]string)
+		}
+
+		resource.Attributes[attribute] = strconv.FormatBool(bodySetsAnyField(c, fields))
+		return resource
+	}
+}
+
+// bodySetsAnyField checks if the JSON body of the request sets any of the fields,
+// restoring the body afterwards
+func bodySetsAnyField(c *gin.Context, fields []string) bool {
+	if c.Request.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return true
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return false
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return true
+	}
+	for _, field := range fields {
+		if _, ok := object[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PolicyMiddleware authorizes requests with the attribute-based policy engine
+type PolicyMiddleware struct {
+	engine  appService.PolicyEngine
+	explain bool
+}
+
+// NewPolicyMiddleware creates a new policy middleware.
+// With explain enabled every decision is logged with its trace and denied
+// responses include the explanation, which is meant for debugging only.
+func NewPolicyMiddleware(engine appService.PolicyEngine, explain bool) *PolicyMiddleware {
+	return &PolicyMiddleware{
+		engine:  engine,
+		explain: explain,
+	}
+}
+
+// RequirePolicy is a middleware that requires the policy engine to allow an action
+// on the resource of the request. Without matching policies it behaves like
+// RequirePermission.
+func (m *PolicyMiddleware) RequirePolicy(action string, resource ResourceResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// RequireAuth should already have run for this route (protected group).
+		value, exists := c.Get("user")
+		if !exists {
+			response.ErrorUnauthorized(c, "User not found in context")
+			c.Abort()
+			return
+		}
+
+		user, ok := value.(*entity.User)
+		if !ok {
+			response.ErrorInternalServer(c, "Invalid user type")
+			c.Abort()
+			return
+		}
+
+		var permissions *entity.PermissionSet
+		if value, exists := c.Get("permissions"); exists {
+			permissions, _ = value.(*entity.PermissionSet)
+		}
+
+		req := appService.AccessRequest{
+			Subject:  appService.SubjectFromUser(user, permissions),
+			Action:   action,
+			Resource: resource(c),
+		}
+		if value, exists := c.Get("api_key_scope"); exists {
+			req.Subject.Scope, _ = value.(*entity.PermissionSet)
+		}
+
+		var decision appService.Decision
+		if m.explain {
+			decision = m.engine.Explain(req)
+			log.Printf("RequirePolicy: user=%s action=%s resource=%s/%s allowed=%t: %s",
+				user.Email, action, req.Resource.Type, req.Resource.ID, decision.Allowed, decision.Explanation())
+		} else {
+			decision = m.engine.Evaluate(req)
+		}
+
+		if !decision.Allowed {
+			if m.explain {
+				response.ErrorForbidden(c, "Permission denied", decision.Explanation())
+			} else {
+				response.ErrorForbidden(c, "Permission denied")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("policy_decision", decision)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
+)
+
+func TestPolicyMiddleware_RequirePolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	assigned := uuid.New()
+	warden := &entity.User{
+		ID:          uuid.New(),
+		Email:       "warden@example.com",
+		Roles:       []entity.Role{{Name: "Warden", Slug: "warden"}},
+		Dormitories: []entity.Dormitory{{ID: assigned}},
+	}
+
+	engine, err := appService.NewPolicyEngine([]appService.Policy{{
+		Name:       "wardens-update-assigned-dormitories",
+		Effect:     appService.PolicyAllow,
+		Actions:    []string{"dorm:update"},
+		Resources:  []string{"dormitory"},
+		Roles:      []string{"warden"},
+		Conditions: []string{"subject.dormitories contains resource.id"},
+	}})
+	require.NoError(t, err)
+
+	newRouter := func(explain bool, keyScope ...string) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			c.Set("user", warden)
+			c.Set("permissions", warden.PermissionSet())
+			if keyScope != nil {
+				c.Set("api_key_scope", entity.NewPermissionSet(keyScope...))
+			}
+		})
+		policies := NewPolicyMiddleware(engine, explain)
+		router.PUT("/dormitories/:id", policies.RequirePolicy("dorm:update", ResourceFromParam("dormitory", "id")), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return router
+	}
+
+	request := func(router *gin.Engine, id uuid.UUID) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/dormitories/"+id.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	router := newRouter(false)
+	assert.Equal(t, http.StatusOK, request(router, assigned).Code)
+
+	w := request(router, uuid.New())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "wardens-update-assigned-dormitories")
+
+	// Explain mode tells why the request was denied
+	w = request(newRouter(true), uuid.New())
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp["error"], "subject.dormitories contains resource.id => false")
+
+	// An API key without dorm:update cannot use the allow policy of its owner
+	assert.Equal(t, http.StatusForbidden, request(newRouter(false, "dorm:read"), assigned).Code)
+	assert.Equal(t, http.StatusOK, request(newRouter(false, "dorm:update"), assigned).Code)
+}
+
+func TestPolicyMiddleware_UserUpdateAndAuditResources(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	dormitory := uuid.New()
+	user := &entity.User{
+		ID:             uuid.New(),
+		Email:          "warden@example.com",
+		DormitoryRoles: []entity.UserDormitoryRole{{DormitoryID: dormitory, Role: entity.Role{Slug: "warden", Permissions: []entity.Permission{{Name: "audit:read"}}}}},
+	}
+
+	engine, err := appService.NewPolicyEngine([]appService.Policy{
+		{
+			Name:       "users-update-own-profile",
+			Effect:     appService.PolicyAllow,
+			Actions:    []string{"user:update"},
+			Resources:  []string{"user"},
+			Conditions: []string{"resource.id == subject.id", `resource.privileged == "false"`},
+		},
+		{
+			Name:       "audit-read-own-dormitory",
+			Effect:     appService.PolicyDeny,
+			Actions:    []string{"audit:read"},
+			Resources:  []string{"audit_log"},
+			Conditions: []string{"resource.dormitory_id not in subject.dormitories"},
+		},
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("user", user)
+		c.Set("permissions", entity.NewPermissionSet("audit:read"))
+	})
+	policies := NewPolicyMiddleware(engine, false)
+	router.PUT("/users/:id", policies.RequirePolicy("user:update", WithBodyFields(ResourceFromParam("user", "id"), "privileged", "email", "is_active", "role_ids")), func(c *gin.Context) {
+		// The handler can still bind the body
+		var body map[string]interface{}
+		require.NoError(t, c.ShouldBindJSON(&body))
+		c.Status(http.StatusOK)
+	})
+	router.GET("/audit-logs", policies.RequirePolicy("audit:read", ResourceFromQuery("audit_log", "dormitory_id")), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	request := func(method, path, body string) int {
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	self := "/users/" + user.ID.String()
+	assert.Equal(t, http.StatusOK, request(http.MethodPut, self, `{"name":"New Name"}`))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, self, `{"name":"New Name","role_ids":["x"]}`))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, self, `{"is_active":true}`))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, self, `not json`))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodPut, "/users/"+uuid.New().String(), `{"name":"New Name"}`))
+
+	// The dormitory-scoped role makes the dormitory the subject's own
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/audit-logs?dormitory_id="+dormitory.String(), ""))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/audit-logs?dormitory_id="+uuid.New().String(), ""))
+	assert.Equal(t, http.StatusForbidden, request(http.MethodGet, "/audit-logs", ""))
+}
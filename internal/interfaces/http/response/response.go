@@ -3,8 +3,11 @@ package response
 import (
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -162,6 +165,32 @@ func ErrorConflict(c *gin.Context, message string, errorDetail ...string) {
 	Error(c, http.StatusConflict, message, errorDetail...)
 }
 
+// ErrorLocked sends a 423 Locked error response with a Retry-After header
+func ErrorLocked(c *gin.Context, message string, retryAfter time.Duration, errorDetail ...string) {
+	if message == "" {
+		message = "Locked"
+	}
+	setRetryAfter(c, retryAfter)
+	Error(c, http.StatusLocked, message, errorDetail...)
+}
+
+// ErrorTooManyRequests sends a 429 Too Many Requests error response with a Retry-After header
+func ErrorTooManyRequests(c *gin.Context, message string, retryAfter time.Duration, errorDetail ...string) {
+	if message == "" {
+		message = "Too many requests"
+	}
+	setRetryAfter(c, retryAfter)
+	Error(c, http.StatusTooManyRequests, message, errorDetail...)
+}
+
+// setRetryAfter sets the Retry-After header in whole seconds, rounded up
+func setRetryAfter(c *gin.Context, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+}
+
 // ErrorInternalServer sends a 500 Internal Server Error response
 func ErrorInternalServer(c *gin.Context, message string, errorDetail ...string) {
 	if message == "" {
@@ -0,0 +1,54 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/interfaces/http/middleware"
+)
+
+// permissionRoutes registers routes that require a permission and records the
+// permission of each route in the permission registry, so that the permission
+// is declared in one place only
+type permissionRoutes struct {
+	registry       *appService.PermissionRegistry
+	authMiddleware *middleware.AuthMiddleware
+}
+
+// GET registers a GET route that requires a permission
+func (p permissionRoutes) GET(group *gin.RouterGroup, path, permission string, handlers ...gin.HandlerFunc) {
+	p.handle(group, http.MethodGet, path, permission, handlers...)
+}
+
+// POST registers a POST route that requires a permission
+func (p permissionRoutes) POST(group *gin.RouterGroup, path, permission string, handlers ...gin.HandlerFunc) {
+	p.handle(group, http.MethodPost, path, permission, handlers...)
+}
+
+// PUT registers a PUT route that requires a permission
+func (p permissionRoutes) PUT(group *gin.RouterGroup, path, permission string, handlers ...gin.HandlerFunc) {
+	p.handle(group, http.MethodPut, path, permission, handlers...)
+}
+
+// DELETE registers a DELETE route that requires a permission
+func (p permissionRoutes) DELETE(group *gin.RouterGroup, path, permission string, handlers ...gin.HandlerFunc) {
+	p.handle(group, http.MethodDelete, path, permission, handlers...)
+}
+
+// handle registers a route guarded by RequirePermission. The permission check
+// runs right before the handler (the last of handlers), after any route middleware.
+func (p permissionRoutes) handle(group *gin.RouterGroup, method, path, permission string, handlers ...gin.HandlerFunc) {
+	p.record(group, method, path, permission)
+
+	last := len(handlers) - 1
+	chain := make([]gin.HandlerFunc, 0, len(handlers)+1)
+	chain = append(chain, handlers[:last]...)
+	chain = append(chain, p.authMiddleware.RequirePermission(permission), handlers[last])
+	group.Handle(method, path, chain...)
+}
+
+// record records the permission of a route that is guarded another way (e.g. RequirePolicy)
+func (p permissionRoutes) record(group *gin.RouterGroup, method, path, permission string) {
+	p.registry.RegisterRoute(permission, method, group.BasePath()+path)
+}
@@ -1,13 +1,19 @@
 package router
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	appService "github.com/your-org/go-backend-starter/internal/application/service"
+	"github.com/your-org/go-backend-starter/internal/domain/entity"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/handler"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/middleware"
 	"github.com/your-org/go-backend-starter/internal/interfaces/http/response"
 )
 
-// SetupRouter configures all routes
+// SetupRouter configures all routes.
+// The permission required by each route is recorded in permissionRegistry.
 func SetupRouter(
 	authHandler *handler.AuthHandler,
 	userHandler *handler.UserHandler,
@@ -16,9 +22,17 @@ func SetupRouter(
 	locationHandler *handler.LocationHandler,
 	permissionHandler *handler.PermissionHandler,
 	auditLogHandler *handler.AuditLogHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	oidcHandler *handler.OIDCHandler,
+	sessionHandler *handler.SessionHandler,
+	roleRequestHandler *handler.RoleRequestHandler,
 	authMiddleware *middleware.AuthMiddleware,
+	policyMiddleware *middleware.PolicyMiddleware,
+	rateLimiter *middleware.RateLimiter,
+	permissionRegistry *appService.PermissionRegistry,
 ) *gin.Engine {
 	router := gin.Default()
+	routes := permissionRoutes{registry: permissionRegistry, authMiddleware: authMiddleware}
 
 	// Global CORS middleware so all routes are covered
 	router.Use(middleware.NewCORSMiddlewareFromEnv())
@@ -30,78 +44,163 @@ func SetupRouter(
 		response.SuccessOK(c, gin.H{"status": "ok"}, "Service is healthy")
 	})
 
+	// Public keys for verifying access tokens
+	router.GET("/.well-known/jwks.json", authHandler.JWKS)
+
+	// Rate limit policies per route group
+	authRateLimit := middleware.RateLimitPolicy{Name: "auth", Limit: 30, Window: time.Minute, Key: middleware.RateLimitByIP}
+	publicRateLimit := middleware.RateLimitPolicy{Name: "public", Limit: 120, Window: time.Minute, Key: middleware.RateLimitByIP}
+	apiRateLimit := middleware.RateLimitPolicy{Name: "api", Limit: 600, Window: time.Minute, Key: middleware.RateLimitByUserID}
+
 	// API routes
 	api := router.Group("/api")
 	{
 		// Auth routes (public)
 		auth := api.Group("/auth")
+		auth.Use(rateLimiter.Limit(authRateLimit))
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/forgot-password", authHandler.ForgotPassword)
+			auth.POST("/reset-password", authHandler.ResetPassword)
+			auth.POST("/verify-email", authHandler.VerifyEmail)
+			auth.POST("/resend-verification", authHandler.ResendVerification)
+			auth.POST("/mfa/setup", authHandler.SetupMFA)
+			auth.POST("/mfa/verify", authHandler.VerifyMFA)
+			auth.GET("/oidc/providers", oidcHandler.ListProviders)
+			auth.GET("/oidc/:provider/authorize", oidcHandler.Authorize)
+			auth.POST("/oidc/:provider/callback", oidcHandler.Callback)
+			auth.POST("/logout-all", authMiddleware.RequireAuth(), authMiddleware.RejectAPIKey(), authHandler.LogoutAll)
+			routes.POST(auth, "/revoke", "user:update", authMiddleware.RequireAuth(), authHandler.RevokeToken)
 		}
 
 		// Public location routes (no auth)
-		api.GET("/provinces", locationHandler.ListProvinces)
-		api.GET("/provinces/:id", locationHandler.GetProvince)
-		api.GET("/regencies", locationHandler.ListRegencies)
-		api.GET("/regencies/:id", locationHandler.GetRegency)
-		api.GET("/districts", locationHandler.ListDistricts)
-		api.GET("/districts/:id", locationHandler.GetDistrict)
-		api.GET("/villages", locationHandler.ListVillages)
-		api.GET("/villages/:id", locationHandler.GetVillage)
+		locations := api.Group("")
+		locations.Use(rateLimiter.Limit(publicRateLimit))
+		{
+			locations.GET("/provinces", locationHandler.ListProvinces)
+			locations.GET("/provinces/:id", locationHandler.GetProvince)
+			locations.GET("/regencies", locationHandler.ListRegencies)
+			locations.GET("/regencies/:id", locationHandler.GetRegency)
+			locations.GET("/districts", locationHandler.ListDistricts)
+			locations.GET("/districts/:id", locationHandler.GetDistrict)
+			locations.GET("/villages", locationHandler.ListVillages)
+			locations.GET("/villages/:id", locationHandler.GetVillage)
+		}
 
 		// Protected routes
 		protected := api.Group("")
-		protected.Use(authMiddleware.RequireAuth())
+		protected.Use(authMiddleware.RequireAuth(), rateLimiter.Limit(apiRateLimit))
 		{
 			// Current user
 			protected.GET("/me", userHandler.Me)
 
+			// Account security (not manageable with an API key, whatever its scope)
+			account := protected.Group("/me")
+			account.Use(authMiddleware.RejectAPIKey())
+			{
+				account.POST("/mfa/enroll", authHandler.EnrollMFA)
+				account.POST("/mfa/confirm", authHandler.ConfirmMFA)
+				account.DELETE("/mfa", authHandler.DisableMFA)
+				account.GET("/sessions", sessionHandler.ListMySessions)
+				account.DELETE("/sessions/:id", sessionHandler.RevokeMySession)
+				account.GET("/role-requests", roleRequestHandler.ListMyRoleRequests)
+				account.POST("/role-requests", roleRequestHandler.RequestRole)
+			}
+
+			// Personal access tokens (not manageable with an API key)
+			apiKeys := protected.Group("/me/api-keys")
+			apiKeys.Use(authMiddleware.RejectAPIKey())
+			{
+				apiKeys.GET("", apiKeyHandler.ListAPIKeys)
+				apiKeys.POST("", apiKeyHandler.CreateAPIKey)
+				apiKeys.GET("/:id", apiKeyHandler.GetAPIKey)
+				apiKeys.PUT("/:id", apiKeyHandler.UpdateAPIKey)
+				apiKeys.DELETE("/:id", apiKeyHandler.DeleteAPIKey)
+			}
+
 			// Audit log routes (read-only)
 			auditLogs := protected.Group("/audit-logs")
 			{
-				auditLogs.GET("", authMiddleware.RequirePermission("audit:read"), auditLogHandler.ListAuditLogs)
+				// Policies see the dormitory_id filter as resource.dormitory_id
+				auditLogs.GET("", policyMiddleware.RequirePolicy("audit:read", middleware.ResourceFromQuery("audit_log", "dormitory_id")), auditLogHandler.ListAuditLogs)
+				routes.record(auditLogs, http.MethodGet, "", "audit:read")
 			}
 
 			// User routes
 			users := protected.Group("/users")
 			{
-				users.GET("", userHandler.ListUsers)
+				// Listing deleted users (?deleted=only) requires user:delete
+				users.GET("", authMiddleware.RequirePermissionForQuery("deleted", "user:delete"), userHandler.ListUsers)
 				users.GET("/:id", userHandler.GetUser)
-				users.POST("", authMiddleware.RequirePermission("user:create"), userHandler.CreateUser)
-				users.PUT("/:id", authMiddleware.RequirePermission("user:update"), userHandler.UpdateUser)
-				users.DELETE("/:id", authMiddleware.RequirePermission("user:delete"), userHandler.DeleteUser)
-				users.POST("/:id/roles", authMiddleware.RequirePermission("user:update"), userHandler.AssignRoleToUser)
-				users.DELETE("/:id/roles/:role_id", authMiddleware.RequirePermission("user:update"), userHandler.RemoveRoleFromUser)
+				routes.POST(users, "", "user:create", userHandler.CreateUser)
+				// resource.privileged is "true" when the body changes the email, status or roles
+				users.PUT("/:id", policyMiddleware.RequirePolicy("user:update", middleware.WithBodyFields(middleware.ResourceFromParam("user", "id"), "privileged", "email", "is_active", "role_ids")), userHandler.UpdateUser)
+				routes.record(users, http.MethodPut, "/:id", "user:update")
+				routes.DELETE(users, "/:id", "user:delete", userHandler.DeleteUser)
+				routes.POST(users, "/:id/restore", "user:delete", userHandler.RestoreUser)
+				routes.POST(users, "/:id/roles", "user:update", userHandler.AssignRoleToUser)
+				routes.DELETE(users, "/:id/roles/:role_id", "user:update", userHandler.RemoveRoleFromUser)
+				routes.GET(users, "/:id/dormitory-roles", "user:read", userHandler.ListDormitoryRoles)
+				routes.POST(users, "/:id/dormitory-roles", "user:update", userHandler.AssignDormitoryRole)
+				routes.DELETE(users, "/:id/dormitory-roles/:dormitory_id/:role_id", "user:update", userHandler.RemoveDormitoryRole)
+				routes.GET(users, "/:id/dormitories", "user:read", dormitoryHandler.ListUserDormitories)
+				routes.GET(users, "/:id/sessions", "user:update", sessionHandler.ListUserSessions)
+				routes.DELETE(users, "/:id/sessions/:session_id", "user:update", sessionHandler.RevokeUserSession)
 			}
 
 			// Dormitory routes
 			dormitories := protected.Group("/dormitories")
 			{
-				dormitories.GET("", dormitoryHandler.ListDormitories)
+				// Listing deleted dormitories (?deleted=only) requires dorm:delete
+				dormitories.GET("", authMiddleware.RequirePermissionForQuery("deleted", "dorm:delete"), dormitoryHandler.ListDormitories)
 				dormitories.GET("/:id", authMiddleware.RequireDormitoryAccess(), dormitoryHandler.GetDormitory)
-				dormitories.POST("", authMiddleware.RequirePermission("dorm:create"), dormitoryHandler.CreateDormitory)
-				dormitories.PUT("/:id", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:update"), dormitoryHandler.UpdateDormitory)
-				dormitories.DELETE("/:id", authMiddleware.RequireDormitoryAccess(), authMiddleware.RequirePermission("dorm:delete"), dormitoryHandler.DeleteDormitory)
+				routes.POST(dormitories, "", "dorm:create", dormitoryHandler.CreateDormitory)
+				routes.POST(dormitories, "/:id/restore", "dorm:delete", dormitoryHandler.RestoreDormitory)
+				dormitories.PUT("/:id", authMiddleware.RequireDormitoryAccess(), policyMiddleware.RequirePolicy("dorm:update", middleware.ResourceFromParam("dormitory", "id")), dormitoryHandler.UpdateDormitory)
+				dormitories.DELETE("/:id", authMiddleware.RequireDormitoryAccess(), policyMiddleware.RequirePolicy("dorm:delete", middleware.ResourceFromParam("dormitory", "id")), dormitoryHandler.DeleteDormitory)
+				// Members (roles scoped to the dormitory apply after RequireDormitoryAccess)
+				routes.GET(dormitories, "/:id/members", "dorm:read", authMiddleware.RequireDormitoryAccess(), dormitoryHandler.ListMembers)
+				routes.POST(dormitories, "/:id/members", "dorm:update", authMiddleware.RequireDormitoryAccess(), dormitoryHandler.AddMember)
+				routes.DELETE(dormitories, "/:id/members/:user_id", "dorm:update", authMiddleware.RequireDormitoryAccess(), dormitoryHandler.RemoveMember)
+				// Policies fall back to these permissions when none applies
+				routes.record(dormitories, http.MethodPut, "/:id", "dorm:update")
+				routes.record(dormitories, http.MethodDelete, "/:id", "dorm:delete")
+				// Checked by RequireDormitoryAccess rather than by a route
+				permissionRegistry.Declare(entity.PermissionDormAccessAll)
 			}
 
 			// Role routes
 			roles := protected.Group("/roles")
 			{
-				roles.GET("", authMiddleware.RequirePermission("role:read"), roleHandler.ListRoles)
-				roles.GET("/:id", authMiddleware.RequirePermission("role:read"), roleHandler.GetRole)
-				roles.POST("", authMiddleware.RequirePermission("role:create"), roleHandler.CreateRole)
-				roles.PUT("/:id", authMiddleware.RequirePermission("role:update"), roleHandler.UpdateRole)
-				roles.DELETE("/:id", authMiddleware.RequirePermission("role:delete"), roleHandler.DeleteRole)
-				roles.POST("/:id/permissions", authMiddleware.RequirePermission("role:update"), roleHandler.AssignPermission)
-				roles.DELETE("/:id/permissions", authMiddleware.RequirePermission("role:update"), roleHandler.RemovePermission)
+				routes.GET(roles, "", "role:read", roleHandler.ListRoles)
+				routes.GET(roles, "/:id", "role:read", roleHandler.GetRole)
+				routes.POST(roles, "", "role:create", roleHandler.CreateRole)
+				routes.PUT(roles, "/:id", "role:update", roleHandler.UpdateRole)
+				routes.DELETE(roles, "/:id", "role:delete", roleHandler.DeleteRole)
+				routes.POST(roles, "/:id/permissions", "role:update", roleHandler.AssignPermission)
+				routes.DELETE(roles, "/:id/permissions", "role:update", roleHandler.RemovePermission)
+				routes.PUT(roles, "/:id/permissions", "role:update", roleHandler.SyncPermissions)
+			}
+
+			// Role request review routes
+			roleRequests := protected.Group("/role-requests")
+			{
+				routes.GET(roleRequests, "", "role:update", roleRequestHandler.ListRoleRequests)
+				routes.POST(roleRequests, "/:id/approve", "role:update", roleRequestHandler.ApproveRoleRequest)
+				routes.POST(roleRequests, "/:id/deny", "role:update", roleRequestHandler.DenyRoleRequest)
 			}
 
-			// Permission routes (read-only)
+			// Permission routes
 			permissions := protected.Group("/permissions")
 			{
-				permissions.GET("", authMiddleware.RequirePermission("role:read"), permissionHandler.ListPermissions)
+				routes.GET(permissions, "", "role:read", permissionHandler.ListPermissions)
+				routes.GET(permissions, "/catalog", "role:read", permissionHandler.GetPermissionCatalog)
+				routes.POST(permissions, "", "permission:create", permissionHandler.CreatePermission)
+				routes.PUT(permissions, "/:id", "permission:update", permissionHandler.UpdatePermission)
+				routes.DELETE(permissions, "/:id", "permission:delete", permissionHandler.DeletePermission)
 			}
 		}
 	}
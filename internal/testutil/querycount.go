@@ -0,0 +1,46 @@
+package testutil
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryCounter is a GORM logger that counts the statements it traces
+// before handing them to the logger it wraps
+type queryCounter struct {
+	logger.Interface
+	count int64
+}
+
+func (c *queryCounter) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	atomic.AddInt64(&c.count, 1)
+	c.Interface.Trace(ctx, begin, fc, err)
+}
+
+// CountQueries runs fn and returns the number of statements it issued through db,
+// reads and writes alike. Use it to catch code paths that query once per row.
+// Sessions derived from db share its logger, so their statements are counted too.
+func CountQueries(t *testing.T, db *gorm.DB, fn func()) int {
+	t.Helper()
+
+	counter := &queryCounter{Interface: db.Logger}
+	db.Logger = counter
+	defer func() { db.Logger = counter.Interface }()
+
+	fn()
+	return int(atomic.LoadInt64(&counter.count))
+}
+
+// AssertQueryCount fails the test unless fn issues exactly want statements through db
+func AssertQueryCount(t *testing.T, db *gorm.DB, want int, fn func()) {
+	t.Helper()
+
+	if got := CountQueries(t, db, fn); got != want {
+		t.Errorf("Expected %d queries, got %d", want, got)
+	}
+}
@@ -25,6 +25,18 @@ func SetupTestDB(t *testing.T) *gorm.DB {
 		&entity.UserRole{},
 		&entity.RolePermission{},
 		&entity.UserDormitory{},
+		&entity.UserDormitoryRole{},
+		&entity.RefreshToken{},
+		&entity.Session{},
+		&entity.RevokedToken{},
+		&entity.UserToken{},
+		&entity.UserMFA{},
+		&entity.MFARecoveryCode{},
+		&entity.LoginAttempt{},
+		&entity.APIKey{},
+		&entity.LinkedIdentity{},
+		&entity.OIDCLoginState{},
+		&entity.RoleRequest{},
 	)
 	if err != nil {
 		t.Fatalf("Failed to migrate test database: %v", err)